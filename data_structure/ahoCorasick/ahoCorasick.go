@@ -0,0 +1,432 @@
+package ahoCorasick
+
+//@Title		ahoCorasick
+//@Description
+//		AC自动机-Aho-Corasick
+//		用于在一段文本中同时查找多个模式串
+//		先将所有模式串插入以字节为分叉的单词查找树,再按层次遍历为每个状态建立失配指针和输出指针
+//		匹配时只需对文本进行一次扫描,不会回退,时间复杂度为O(文本长度+匹配数)
+//		支持三种匹配模式:
+//			Overlapping:报告所有模式串的所有出现位置,包括相互重叠的
+//			LeftmostLongest:报告互不重叠的匹配,每次选择起点最靠左的匹配,起点相同时选择最长的
+//			LeftmostFirst:报告互不重叠的匹配,每次选择起点最靠左的匹配,起点相同时选择模式串编号最小的
+//		最左模式下路径上已出现匹配的状态不再沿失配指针回退,只继续寻找起点相同的更优匹配
+//		确定匹配后从其终点开始的扫描结果在建立时预先算出,因此同样不需要回退
+//		可选择忽略ASCII字母的大小写,匹配结果中的位置仍为原文本中的字节下标
+//		支持以io.Reader的形式流式读入文本
+//		建立后不可修改,因此不需要进行并发控制
+
+import (
+	"io"
+)
+
+//MatchKind匹配模式
+type MatchKind int
+
+const (
+	Overlapping     MatchKind = iota //报告所有匹配,允许重叠
+	LeftmostLongest                  //报告互不重叠的匹配,起点相同时选择最长的
+	LeftmostFirst                    //报告互不重叠的匹配,起点相同时选择编号最小的
+)
+
+//Match匹配结果结构体
+//Pattern为匹配到的模式串在创建时传入的切片中的下标
+//匹配区间为文本中的左闭右开区间[Start,End)
+type Match struct {
+	Pattern int //模式串编号
+	Start   int //匹配起点,包含
+	End     int //匹配终点,不包含
+}
+
+//ahoCorasick AC自动机结构体
+//该实例存储所有状态节点,根节点编号为0
+//同时保存所有模式串、匹配模式以及是否忽略大小写
+type AhoCorasick struct {
+	nodes      []*node    //状态节点
+	patterns   []string   //模式串
+	kind       MatchKind  //匹配模式
+	ignoreCase bool       //是否忽略ASCII字母的大小写
+	emits      []emission //最左模式下各状态已确定匹配的链表节点
+}
+
+//ahoCorasick AC自动机接口
+//存放了ahoCorasick AC自动机可使用的函数
+//对应函数介绍见下方
+type ahoCorasicker interface {
+	Size() (num int)                                          //返回模式串的数量
+	Pattern(id int) (p string)                                //返回编号为id的模式串
+	Kind() (kind MatchKind)                                   //返回匹配模式
+	FindAll(text string) (ms []Match)                         //返回文本中的所有匹配
+	Find(text string) (m Match, b bool)                       //返回文本中的第一个匹配
+	Contains(text string) (b bool)                            //判断文本中是否存在匹配
+	Stream(r io.Reader, f func(m Match) (b bool)) (err error) //从r中读入文本并对每个匹配调用f
+}
+
+//@title    New
+//@description
+//		以模式串patterns新建一个ahoCorasick AC自动机并返回
+//		模式串的编号即为其在patterns中的下标,空串不会被匹配
+//		kind为匹配模式,ignoreCase为true时忽略ASCII字母的大小写
+//		若kind不是已定义的匹配模式则返回nil
+//@receiver		nil
+//@param    	patterns	[]string				模式串
+//@param    	kind		MatchKind				匹配模式
+//@param    	ignoreCase	bool					是否忽略大小写?
+//@return    	ac        	*AhoCorasick			新建的ahoCorasick指针
+func New(patterns []string, kind MatchKind, ignoreCase bool) (ac *AhoCorasick) {
+	if kind != Overlapping && kind != LeftmostLongest && kind != LeftmostFirst {
+		return nil
+	}
+	ac = &AhoCorasick{
+		nodes:      []*node{newNode(0)},
+		patterns:   make([]string, len(patterns)),
+		kind:       kind,
+		ignoreCase: ignoreCase,
+		emits:      make([]emission, 0),
+	}
+	copy(ac.patterns, patterns)
+	for id, p := range ac.patterns {
+		ac.insert(p, id)
+	}
+	ac.build()
+	return ac
+}
+
+//@title    fold
+//@description
+//		以ahoCorasick AC自动机做接收者
+//		忽略大小写时将ASCII大写字母转换为小写字母,否则原样返回
+//@receiver		ac			*AhoCorasick			接受者ahoCorasick的指针
+//@param    	c			byte					待转换的字节
+//@return    	d			byte					转换后的字节
+func (ac *AhoCorasick) fold(c byte) (d byte) {
+	if ac.ignoreCase && c >= 'A' && c <= 'Z' {
+		return c - 'A' + 'a'
+	}
+	return c
+}
+
+//@title    insert
+//@description
+//		以ahoCorasick AC自动机做接收者
+//		将编号为id的模式串p插入单词查找树
+//		空串直接忽略
+//		最左优先模式下若编号更小的模式串是p的前缀,则p永远不会被匹配,同样忽略
+//@receiver		ac			*AhoCorasick			接受者ahoCorasick的指针
+//@param    	p			string					模式串
+//@param    	id			int						模式串编号
+//@return    	nil
+func (ac *AhoCorasick) insert(p string, id int) {
+	if len(p) == 0 {
+		return
+	}
+	s := 0
+	for i := 0; i < len(p); i++ {
+		if ac.kind == LeftmostFirst && len(ac.nodes[s].ids) > 0 {
+			return
+		}
+		c := ac.fold(p[i])
+		t := ac.nodes[s].get(c)
+		if t == -1 {
+			t = len(ac.nodes)
+			ac.nodes = append(ac.nodes, newNode(i+1))
+			ac.nodes[s].set(c, t)
+		}
+		s = t
+	}
+	ac.nodes[s].ids = append(ac.nodes[s].ids, id)
+}
+
+//@title    build
+//@description
+//		以ahoCorasick AC自动机做接收者
+//		按层次遍历为每个状态建立失配指针和输出指针
+//		子状态的失配指针为沿父状态的失配指针找到的第一个存在相同标签分叉的状态的该分叉
+//		最左模式下路径上已出现匹配的状态、以及沿失配指针只能到达这类状态的状态不再回退
+//		以模式串结尾且不存在分叉的状态也不再回退,避免丢失待定匹配
+//		同时算出各状态的待定匹配以及确定后从其终点扫描的结果
+//		由于层次遍历,计算某状态时比其浅的状态均已计算完毕
+//@receiver		ac			*AhoCorasick			接受者ahoCorasick的指针
+//@param    	nil
+//@return    	nil
+func (ac *AhoCorasick) build() {
+	leftmost := ac.kind != Overlapping
+	//past[s]表示s的路径上(包括s)存在以模式串结尾的状态
+	past := make([]bool, len(ac.nodes))
+	queue := make([]int, 0, len(ac.nodes))
+	queue = append(queue, 0)
+	for i := 0; i < len(queue); i++ {
+		s := queue[i]
+		ac.nodes[s].each(func(c byte, t int) {
+			queue = append(queue, t)
+			f := 0
+			if s != 0 {
+				f = ac.nodes[s].fail
+				for f > 0 && ac.nodes[f].get(c) == -1 {
+					f = ac.nodes[f].fail
+				}
+				if f != dead {
+					if g := ac.nodes[f].get(c); g != -1 {
+						f = g
+					}
+				}
+			}
+			n := ac.nodes[t]
+			past[t] = past[s] || len(n.ids) > 0
+			if leftmost && past[t] {
+				f = dead
+			}
+			n.fail = f
+			if f == dead {
+				n.dict = -1
+			} else if len(ac.nodes[f].ids) > 0 {
+				n.dict = f
+			} else {
+				n.dict = ac.nodes[f].dict
+			}
+			if leftmost {
+				if n.leaf() && n.dict != -1 {
+					n.fail = dead
+				}
+				ac.pend(s, c, t)
+			}
+		})
+	}
+}
+
+//@title    pend
+//@description
+//		以ahoCorasick AC自动机做接收者
+//		最左模式下计算父状态s读入字节c到达的状态t的待定匹配
+//		t以模式串结尾时待定匹配即为以t结尾的最优匹配,否则沿用s的待定匹配
+//		t的待定匹配之后的字节为s的对应字节再加上c,因此从s的扫描结果读入c即可得到t的扫描结果
+//@receiver		ac			*AhoCorasick			接受者ahoCorasick的指针
+//@param    	s			int						父状态
+//@param    	c			byte					分叉的标签
+//@param    	t			int						子状态
+//@return    	nil
+func (ac *AhoCorasick) pend(s int, c byte, t int) {
+	p, n := ac.nodes[s], ac.nodes[t]
+	switch {
+	case len(n.ids) > 0:
+		n.first, n.length = n.ids[0], n.depth
+	case n.dict != -1:
+		n.first, n.length = ac.nodes[n.dict].ids[0], ac.nodes[n.dict].depth
+	case p.first != -1:
+		n.first, n.length, n.back = p.first, p.length, p.back+1
+		tail := p.emits
+		n.restart = ac.shift(p.restart, c, p.back, func(m Match) {
+			ac.emits = append(ac.emits, emission{m: m, prev: tail})
+			tail = len(ac.emits) - 1
+		})
+		n.emits = tail
+	}
+}
+
+//@title    next
+//@description
+//		以ahoCorasick AC自动机做接收者
+//		返回状态s读入字节c后转移到的状态
+//		s不存在标签为c的分叉时沿失配指针回退,回退到根节点仍不存在时停留在根节点
+//@receiver		ac			*AhoCorasick			接受者ahoCorasick的指针
+//@param    	s			int						当前状态
+//@param    	c			byte					读入的字节
+//@return    	t			int						转移到的状态
+func (ac *AhoCorasick) next(s int, c byte) (t int) {
+	c = ac.fold(c)
+	for {
+		if t = ac.nodes[s].get(c); t != -1 {
+			return t
+		}
+		if s == 0 {
+			return 0
+		}
+		s = ac.nodes[s].fail
+	}
+}
+
+//@title    shift
+//@description
+//		以ahoCorasick AC自动机做接收者
+//		最左模式下返回状态s读入下标为pos的字节c后转移到的状态
+//		s不存在标签为c的分叉时沿失配指针回退,遇到不再回退的状态时确定其待定匹配并从其扫描结果继续
+//		每次确定至少一个匹配且状态深度严格减小,因此总开销仍为O(文本长度+匹配数)
+//@receiver		ac			*AhoCorasick			接受者ahoCorasick的指针
+//@param    	s			int						当前状态
+//@param    	c			byte					读入的字节
+//@param    	pos			int						c的下标
+//@param    	emit		func(m Match)			对确定的匹配调用的函数
+//@return    	t			int						转移到的状态
+func (ac *AhoCorasick) shift(s int, c byte, pos int, emit func(m Match)) (t int) {
+	c = ac.fold(c)
+	for {
+		if t = ac.nodes[s].get(c); t != -1 {
+			return t
+		}
+		if s == 0 {
+			return 0
+		}
+		if ac.nodes[s].fail != dead {
+			s = ac.nodes[s].fail
+		} else {
+			s = ac.settle(s, pos, emit)
+		}
+	}
+}
+
+//@title    settle
+//@description
+//		以ahoCorasick AC自动机做接收者
+//		最左模式下确定状态s的待定匹配,并按顺序确定从其终点扫描到pos的过程中出现的匹配
+//		返回从其终点以根状态扫描到pos后到达的状态
+//@receiver		ac			*AhoCorasick			接受者ahoCorasick的指针
+//@param    	s			int						当前状态
+//@param    	pos			int						当前位置
+//@param    	emit		func(m Match)			对确定的匹配调用的函数
+//@return    	t			int						扫描后到达的状态
+func (ac *AhoCorasick) settle(s int, pos int, emit func(m Match)) (t int) {
+	n := ac.nodes[s]
+	end := pos - n.back
+	emit(Match{Pattern: n.first, Start: end - n.length, End: end})
+	ms := make([]Match, 0)
+	for e := n.emits; e != -1; e = ac.emits[e].prev {
+		ms = append(ms, ac.emits[e].m)
+	}
+	for i := len(ms) - 1; i >= 0; i-- {
+		emit(Match{Pattern: ms[i].Pattern, Start: end + ms[i].Start, End: end + ms[i].End})
+	}
+	return n.restart
+}
+
+//@title    Size
+//@description
+//		以ahoCorasick AC自动机做接收者
+//		返回创建时传入的模式串的数量
+//		如果容器为nil返回0
+//@receiver		ac			*AhoCorasick			接受者ahoCorasick的指针
+//@param    	nil
+//@return    	num        	int						模式串的数量
+func (ac *AhoCorasick) Size() (num int) {
+	if ac == nil {
+		return 0
+	}
+	return len(ac.patterns)
+}
+
+//@title    Pattern
+//@description
+//		以ahoCorasick AC自动机做接收者
+//		返回编号为id的模式串
+//		若编号不存在则返回空串
+//@receiver		ac			*AhoCorasick			接受者ahoCorasick的指针
+//@param    	id			int						模式串编号
+//@return    	p			string					模式串
+func (ac *AhoCorasick) Pattern(id int) (p string) {
+	if ac == nil || id < 0 || id >= len(ac.patterns) {
+		return ""
+	}
+	return ac.patterns[id]
+}
+
+//@title    Kind
+//@description
+//		以ahoCorasick AC自动机做接收者
+//		返回该自动机的匹配模式
+//@receiver		ac			*AhoCorasick			接受者ahoCorasick的指针
+//@param    	nil
+//@return    	kind		MatchKind				匹配模式
+func (ac *AhoCorasick) Kind() (kind MatchKind) {
+	if ac == nil {
+		return Overlapping
+	}
+	return ac.kind
+}
+
+//@title    FindAll
+//@description
+//		以ahoCorasick AC自动机做接收者
+//		按匹配模式返回文本中的所有匹配
+//		重叠模式下按终点升序排列,终点相同时按长度降序排列,同一模式串重复出现时按编号升序排列
+//		最左模式下按起点升序排列
+//@receiver		ac			*AhoCorasick			接受者ahoCorasick的指针
+//@param    	text		string					待查找的文本
+//@return    	ms			[]Match					所有匹配
+func (ac *AhoCorasick) FindAll(text string) (ms []Match) {
+	ms = make([]Match, 0)
+	if ac == nil {
+		return ms
+	}
+	sc := newScanner(ac, func(m Match) bool {
+		ms = append(ms, m)
+		return true
+	})
+	sc.write([]byte(text))
+	sc.close()
+	return ms
+}
+
+//@title    Find
+//@description
+//		以ahoCorasick AC自动机做接收者
+//		返回文本中的第一个匹配
+//		重叠模式下为终点最靠左的匹配,最左模式下为起点最靠左的匹配
+//		找到后立即停止扫描,若不存在匹配则返回false
+//@receiver		ac			*AhoCorasick			接受者ahoCorasick的指针
+//@param    	text		string					待查找的文本
+//@return    	m			Match					第一个匹配
+//@return    	b			bool					找到了吗?
+func (ac *AhoCorasick) Find(text string) (m Match, b bool) {
+	if ac == nil {
+		return m, false
+	}
+	sc := newScanner(ac, func(x Match) bool {
+		m, b = x, true
+		return false
+	})
+	sc.write([]byte(text))
+	sc.close()
+	return m, b
+}
+
+//@title    Contains
+//@description
+//		以ahoCorasick AC自动机做接收者
+//		判断文本中是否存在任一模式串
+//@receiver		ac			*AhoCorasick			接受者ahoCorasick的指针
+//@param    	text		string					待查找的文本
+//@return    	b			bool					存在匹配吗?
+func (ac *AhoCorasick) Contains(text string) (b bool) {
+	_, b = ac.Find(text)
+	return b
+}
+
+//@title    Stream
+//@description
+//		以ahoCorasick AC自动机做接收者
+//		从r中分块读入文本,按匹配模式对每个匹配依次调用f,f返回false时停止
+//		匹配结果中的位置为从流开头计算的字节下标,匹配可以跨越读入的块
+//		扫描不会回退,不需要保留已读入的字节,占用的内存与文本长度无关
+//		读到io.EOF时正常结束,读取出现其他错误时返回该错误
+//@receiver		ac			*AhoCorasick			接受者ahoCorasick的指针
+//@param    	r			io.Reader				文本来源
+//@param    	f			func(m Match) bool		对匹配调用的函数
+//@return    	err			error					读取时出现的错误
+func (ac *AhoCorasick) Stream(r io.Reader, f func(m Match) (b bool)) (err error) {
+	if ac == nil || r == nil || f == nil {
+		return nil
+	}
+	sc := newScanner(ac, f)
+	buf := make([]byte, 4096)
+	for !sc.stopped {
+		n, e := r.Read(buf)
+		sc.write(buf[:n])
+		if e == io.EOF {
+			break
+		}
+		if e != nil {
+			return e
+		}
+	}
+	sc.close()
+	return nil
+}
@@ -0,0 +1,169 @@
+package ahoCorasick
+
+//@Title		ahoCorasick
+//@Description
+//		AC自动机的状态节点
+//		每个状态对应模式串所构成的单词查找树中的一个节点,即某个模式串的前缀
+//		分叉以字节为标签,较少时以有序小数组存储,较多时提升为256个分叉的稠密数组
+//		fail为失配指针,指向该前缀在树中存在的最长真后缀所对应的状态
+//		dict为输出指针,沿失配指针找到的第一个以模式串结尾的状态
+//		最左模式下路径上已出现匹配的状态不再回退,其失配指针为dead
+//		此时只沿分叉寻找起点相同的更优匹配,失败时直接确定该状态的待定匹配
+
+//denseLimit分叉数量超过该值时提升为稠密数组
+const denseLimit = 16
+
+//dead最左模式下不再回退的状态的失配指针
+const dead = -1
+
+//node状态节点结构体
+//ids为以该状态结尾的所有模式串的编号,按编号升序排列
+//最左模式下到达该状态时已扫描的文本中可能存在尚未确定的匹配,即待定匹配
+//待定匹配由状态唯一确定,其终点距当前位置back个字节
+//确定待定匹配后需从其终点以根状态扫描余下的back个字节,该扫描的结果同样由状态唯一确定,建立时预先算出
+type node struct {
+	labels  []byte //稀疏时各分叉的标签
+	sons    []int  //稀疏时各分叉的状态编号
+	dense   []int  //稠密时以标签为下标的分叉状态编号,不存在时为-1
+	fail    int    //失配指针,最左模式下可能为dead
+	dict    int    //输出指针,不存在时为-1
+	depth   int    //该状态对应前缀的长度
+	ids     []int  //以该状态结尾的模式串编号
+	first   int    //最左模式下待定匹配的模式串编号,不存在时为-1
+	length  int    //待定匹配的长度
+	back    int    //待定匹配的终点到当前位置的距离
+	restart int    //从待定匹配的终点以根状态扫描余下字节后到达的状态
+	emits   int    //上述扫描中确定的匹配在链表中的尾部,不存在时为-1
+}
+
+//emission已确定匹配的链表节点
+//同一链表中的匹配按终点升序排列,位置为相对于扫描起点的偏移
+//各状态的链表共享公共前缀,因此总长度不超过模式串的长度之和
+type emission struct {
+	m    Match //已确定的匹配
+	prev int   //链表中的前一个节点,不存在时为-1
+}
+
+//@title    newNode
+//@description
+//		新建一个深度为depth的状态节点并返回
+//@receiver		nil
+//@param    	depth		int						状态对应前缀的长度
+//@return    	n        	*node					新建的状态节点的指针
+func newNode(depth int) (n *node) {
+	return &node{
+		labels:  make([]byte, 0),
+		sons:    make([]int, 0),
+		dense:   nil,
+		fail:    0,
+		dict:    -1,
+		depth:   depth,
+		ids:     nil,
+		first:   -1,
+		length:  0,
+		back:    0,
+		restart: 0,
+		emits:   -1,
+	}
+}
+
+//@title    search
+//@description
+//		以node状态节点做接收者
+//		在稀疏存储的标签中二分查找第一个不小于c的位置
+//@receiver		n			*node					接受者node的指针
+//@param    	c			byte					标签
+//@return    	idx			int						第一个不小于c的位置
+func (n *node) search(c byte) (idx int) {
+	l, r := 0, len(n.labels)
+	for l < r {
+		m := (l + r) / 2
+		if n.labels[m] < c {
+			l = m + 1
+		} else {
+			r = m
+		}
+	}
+	return l
+}
+
+//@title    get
+//@description
+//		以node状态节点做接收者
+//		返回标签c对应的分叉的状态编号,不存在时返回-1
+//@receiver		n			*node					接受者node的指针
+//@param    	c			byte					标签
+//@return    	s			int						分叉的状态编号
+func (n *node) get(c byte) (s int) {
+	if n.dense != nil {
+		return n.dense[c]
+	}
+	if idx := n.search(c); idx < len(n.labels) && n.labels[idx] == c {
+		return n.sons[idx]
+	}
+	return -1
+}
+
+//@title    set
+//@description
+//		以node状态节点做接收者
+//		添加标签为c的分叉,c原本不存在
+//		分叉数量超过denseLimit时提升为稠密数组
+//@receiver		n			*node					接受者node的指针
+//@param    	c			byte					标签
+//@param    	s			int						分叉的状态编号
+//@return    	nil
+func (n *node) set(c byte, s int) {
+	if n.dense != nil {
+		n.dense[c] = s
+		return
+	}
+	idx := n.search(c)
+	n.labels = append(n.labels, 0)
+	n.sons = append(n.sons, 0)
+	copy(n.labels[idx+1:], n.labels[idx:])
+	copy(n.sons[idx+1:], n.sons[idx:])
+	n.labels[idx], n.sons[idx] = c, s
+	if len(n.labels) > denseLimit {
+		n.dense = make([]int, 256)
+		for i := range n.dense {
+			n.dense[i] = -1
+		}
+		for i, l := range n.labels {
+			n.dense[l] = n.sons[i]
+		}
+		n.labels, n.sons = nil, nil
+	}
+}
+
+//@title    each
+//@description
+//		以node状态节点做接收者
+//		按标签升序对每个分叉调用f
+//@receiver		n			*node					接受者node的指针
+//@param    	f			func(c byte, s int)		对分叉调用的函数
+//@return    	nil
+func (n *node) each(f func(c byte, s int)) {
+	if n.dense != nil {
+		for c, s := range n.dense {
+			if s != -1 {
+				f(byte(c), s)
+			}
+		}
+		return
+	}
+	for i, c := range n.labels {
+		f(c, n.sons[i])
+	}
+}
+
+//@title    leaf
+//@description
+//		以node状态节点做接收者
+//		判断该状态是否不存在任何分叉
+//@receiver		n			*node					接受者node的指针
+//@param    	nil
+//@return    	b			bool					不存在分叉吗?
+func (n *node) leaf() (b bool) {
+	return n.dense == nil && len(n.labels) == 0
+}
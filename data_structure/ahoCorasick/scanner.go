@@ -0,0 +1,112 @@
+package ahoCorasick
+
+//@Title		ahoCorasick
+//@Description
+//		AC自动机的扫描器
+//		逐字节读入文本并在自动机上转移,按匹配模式报告匹配
+//		重叠模式下沿输出指针报告以当前位置结尾的所有匹配
+//		最左模式下转移到不再回退的状态时确定其待定匹配,文本结束时依次确定剩余的待定匹配
+//		两种模式下扫描都不会回退,因此不需要保留已读入的字节
+
+//scanner扫描器结构体
+//pos为下一个待处理的字节下标
+type scanner struct {
+	ac      *AhoCorasick       //所属的AC自动机
+	emit    func(m Match) bool //报告匹配的函数,返回false时停止
+	pos     int                //下一个待处理的字节下标
+	state   int                //当前状态
+	stopped bool               //是否已停止
+}
+
+//@title    newScanner
+//@description
+//		新建一个扫描器并返回
+//@receiver		nil
+//@param    	ac			*AhoCorasick			所属的AC自动机
+//@param    	emit		func(m Match) bool		报告匹配的函数
+//@return    	sc        	*scanner				新建的扫描器指针
+func newScanner(ac *AhoCorasick, emit func(m Match) bool) (sc *scanner) {
+	return &scanner{
+		ac:   ac,
+		emit: emit,
+	}
+}
+
+//@title    report
+//@description
+//		以scanner扫描器做接收者
+//		报告一个匹配,报告函数返回false时停止扫描
+//		停止后不再报告任何匹配
+//@receiver		sc			*scanner				接受者scanner的指针
+//@param    	m			Match					匹配
+//@return    	nil
+func (sc *scanner) report(m Match) {
+	if sc.stopped {
+		return
+	}
+	if !sc.emit(m) {
+		sc.stopped = true
+	}
+}
+
+//@title    step
+//@description
+//		以scanner扫描器做接收者
+//		在自动机上处理下标为pos的字节c
+//@receiver		sc			*scanner				接受者scanner的指针
+//@param    	c			byte					待处理的字节
+//@return    	nil
+func (sc *scanner) step(c byte) {
+	ac := sc.ac
+	if ac.kind != Overlapping {
+		sc.state = ac.shift(sc.state, c, sc.pos, sc.report)
+		sc.pos++
+		return
+	}
+	sc.state = ac.next(sc.state, c)
+	sc.pos++
+	end := sc.pos
+	s := sc.state
+	if len(ac.nodes[s].ids) == 0 {
+		s = ac.nodes[s].dict
+	}
+	for ; s != -1 && !sc.stopped; s = ac.nodes[s].dict {
+		for _, id := range ac.nodes[s].ids {
+			sc.report(Match{Pattern: id, Start: end - ac.nodes[s].depth, End: end})
+			if sc.stopped {
+				return
+			}
+		}
+	}
+}
+
+//@title    write
+//@description
+//		以scanner扫描器做接收者
+//		读入一段文本并依次处理其中的每个字节
+//@receiver		sc			*scanner				接受者scanner的指针
+//@param    	p			[]byte					读入的文本
+//@return    	nil
+func (sc *scanner) write(p []byte) {
+	for i := 0; i < len(p) && !sc.stopped; i++ {
+		sc.step(p[i])
+	}
+}
+
+//@title    close
+//@description
+//		以scanner扫描器做接收者
+//		文本结束,最左模式下依次确定剩余的待定匹配
+//		每确定一个待定匹配后转移到从其终点扫描到文本末尾所到达的状态,该状态可能仍有待定匹配
+//@receiver		sc			*scanner				接受者scanner的指针
+//@param    	nil
+//@return    	nil
+func (sc *scanner) close() {
+	ac := sc.ac
+	if ac.kind == Overlapping {
+		return
+	}
+	for ac.nodes[sc.state].first != -1 && !sc.stopped {
+		sc.state = ac.settle(sc.state, sc.pos, sc.report)
+	}
+}
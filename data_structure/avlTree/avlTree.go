@@ -12,6 +12,7 @@ package avlTree
 
 import (
 	"github.com/hlccd/goSTL/utils/comparator"
+	"github.com/hlccd/goSTL/utils/cursor"
 	"github.com/hlccd/goSTL/utils/iterator"
 	"sync"
 )
@@ -33,13 +34,20 @@ type AvlTree struct {
 //存放了avlTree平衡二叉树可使用的函数
 //对应函数介绍见下方
 type avlTreer interface {
-	Iterator() (i *Iterator.Iterator) //返回包含该二叉树的所有元素,重复则返回多个
-	Size() (num int)                  //返回该二叉树中保存的元素个数
-	Clear()                           //清空该二叉树
-	Empty() (b bool)                  //判断该二叉树是否为空
-	Insert(e interface{}) (b bool)    //向二叉树中插入元素e
-	Erase(e interface{}) (b bool)     //从二叉树中删除元素e
-	Count(e interface{}) (num int)    //从二叉树中寻找元素e并返回其个数
+	Iterator() (i *Iterator.Iterator)                                             //返回包含该二叉树的所有元素,重复则返回多个
+	Size() (num int)                                                              //返回该二叉树中保存的元素个数
+	Clear()                                                                       //清空该二叉树
+	Empty() (b bool)                                                              //判断该二叉树是否为空
+	Insert(e interface{}) (b bool)                                                //向二叉树中插入元素e
+	Erase(e interface{}) (b bool)                                                 //从二叉树中删除元素e
+	Count(e interface{}) (num int)                                                //从二叉树中寻找元素e并返回其个数
+	Rank(e interface{}) (num int)                                                 //返回二叉树中比元素e小的元素个数
+	Select(k int) (e interface{})                                                 //返回二叉树中序下标为k的元素,下标从0开始
+	Kth(k int, isBack bool) (e interface{})                                       //返回二叉树中第k小或第k大的元素,k从1开始
+	CountRange(lo, hi interface{}) (num int)                                      //返回二叉树中处于[lo,hi]内的元素个数
+	LowerBound(e interface{}) (c *Cursor)                                         //返回指向不小于e的最小元素的游标
+	UpperBound(e interface{}) (c *Cursor)                                         //返回指向大于e的最小元素的游标
+	RangeIterator(lo, hi interface{}, isLoIn, isHiIn bool) (i *Iterator.Iterator) //返回包含区间内所有元素的迭代器
 }
 
 //@title    New
@@ -257,3 +265,174 @@ func (avl *AvlTree) Find(e interface{}) (ans interface{}) {
 	avl.mutex.Unlock()
 	return ans
 }
+
+//@title    Rank
+//@description
+//		以avlTree平衡二叉树做接收者
+//		返回二叉树中比元素e小的元素个数,即元素e在中缀序列中的首个下标
+//		重复元素计入多次
+//		借助节点中记录的子树元素数量,时间复杂度为O(log n)
+//@receiver		avl			*avlTree				接受者avlTree的指针
+//@param    	e			interface{}				待统计元素
+//@return    	num			int						比元素e小的元素个数
+func (avl *AvlTree) Rank(e interface{}) (num int) {
+	if avl == nil {
+		return 0
+	}
+	if avl.Empty() {
+		return 0
+	}
+	avl.mutex.Lock()
+	num = avl.root.rank(e, false, avl.cmp)
+	avl.mutex.Unlock()
+	return num
+}
+
+//@title    Select
+//@description
+//		以avlTree平衡二叉树做接收者
+//		返回二叉树中缀序列中下标为k的元素,下标从0开始
+//		重复元素占据多个下标,与Rank互为逆运算
+//		若k超出范围则返回nil
+//@receiver		avl			*avlTree				接受者avlTree的指针
+//@param    	k			int						待寻找元素的下标
+//@return    	e			interface{}				下标为k的元素
+func (avl *AvlTree) Select(k int) (e interface{}) {
+	if avl == nil {
+		return nil
+	}
+	if avl.Empty() {
+		return nil
+	}
+	avl.mutex.Lock()
+	e = avl.root.kth(k)
+	avl.mutex.Unlock()
+	return e
+}
+
+//@title    Kth
+//@description
+//		以avlTree平衡二叉树做接收者
+//		返回二叉树中第k小的元素,若isBack为true则返回第k大的元素
+//		k从1开始计数,重复元素计入多次
+//		若k超出范围则返回nil
+//@receiver		avl			*avlTree				接受者avlTree的指针
+//@param    	k			int						待寻找元素的位次
+//@param    	isBack		bool					是否从大到小计数?
+//@return    	e			interface{}				第k小或第k大的元素
+func (avl *AvlTree) Kth(k int, isBack bool) (e interface{}) {
+	if avl == nil {
+		return nil
+	}
+	if avl.Empty() {
+		return nil
+	}
+	avl.mutex.Lock()
+	if isBack {
+		e = avl.root.kth(avl.size - k)
+	} else {
+		e = avl.root.kth(k - 1)
+	}
+	avl.mutex.Unlock()
+	return e
+}
+
+//@title    CountRange
+//@description
+//		以avlTree平衡二叉树做接收者
+//		返回二叉树中不小于lo且不大于hi的元素个数,重复元素计入多次
+//		通过两次统计之差得到,时间复杂度为O(log n)
+//		若lo大于hi则返回0
+//@receiver		avl			*avlTree				接受者avlTree的指针
+//@param    	lo			interface{}				区间下界
+//@param    	hi			interface{}				区间上界
+//@return    	num			int						处于[lo,hi]内的元素个数
+func (avl *AvlTree) CountRange(lo, hi interface{}) (num int) {
+	if avl == nil {
+		return 0
+	}
+	if avl.Empty() {
+		return 0
+	}
+	avl.mutex.Lock()
+	if avl.cmp(lo, hi) <= 0 {
+		num = avl.root.rank(hi, true, avl.cmp) - avl.root.rank(lo, false, avl.cmp)
+	}
+	avl.mutex.Unlock()
+	return num
+}
+
+//@title    LowerBound
+//@description
+//		以avlTree平衡二叉树做接收者
+//		返回指向平衡二叉树中不小于元素e的最小元素的游标
+//		若存在重复元素则指向其中第一个
+//		若不存在则返回一个无效游标
+//		查找的时间复杂度为O(log n),创建游标后对平衡二叉树进行增删将使游标失效
+//@receiver		avl			*avlTree				接受者avlTree的指针
+//@param    	e			interface{}				待查找元素
+//@return    	c			*Cursor					指向下界的游标
+func (avl *AvlTree) LowerBound(e interface{}) (c *Cursor) {
+	if avl == nil {
+		return nil
+	}
+	avl.mutex.Lock()
+	var path []cursor.Node
+	if avl.root != nil && avl.cmp != nil {
+		path = avl.root.lowerBound(e, avl.cmp)
+	}
+	c = cursor.New(&avl.mutex, path)
+	avl.mutex.Unlock()
+	return c
+}
+
+//@title    UpperBound
+//@description
+//		以avlTree平衡二叉树做接收者
+//		返回指向平衡二叉树中大于元素e的最小元素的游标
+//		若存在重复元素则指向其中第一个,LowerBound(lo)到UpperBound(hi)之前的元素即为[lo,hi]区间内的元素
+//		若不存在则返回一个无效游标
+//		查找的时间复杂度为O(log n),创建游标后对平衡二叉树进行增删将使游标失效
+//@receiver		avl			*avlTree				接受者avlTree的指针
+//@param    	e			interface{}				待查找元素
+//@return    	c			*Cursor					指向上界的游标
+func (avl *AvlTree) UpperBound(e interface{}) (c *Cursor) {
+	if avl == nil {
+		return nil
+	}
+	avl.mutex.Lock()
+	var path []cursor.Node
+	if avl.root != nil && avl.cmp != nil {
+		path = avl.root.upperBound(e, avl.cmp)
+	}
+	c = cursor.New(&avl.mutex, path)
+	avl.mutex.Unlock()
+	return c
+}
+
+//@title    RangeIterator
+//@description
+//		以avlTree平衡二叉树做接收者
+//		将平衡二叉树中处于区间内的元素以中缀序列的形式放入迭代器中
+//		lo或hi为nil时表示该侧无界,isLoIn和isHiIn表示区间是否包含对应端点
+//		若允许重复存储则对于重复元素进行多次放入
+//		仅访问区间内的节点及其查找路径,时间复杂度为O(log n + k),迭代器可双向移动
+//@receiver		avl			*avlTree				接受者avlTree的指针
+//@param    	lo			interface{}				区间下界
+//@param    	hi			interface{}				区间上界
+//@param    	isLoIn		bool					是否包含下界?
+//@param    	isHiIn		bool					是否包含上界?
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (avl *AvlTree) RangeIterator(lo, hi interface{}, isLoIn, isHiIn bool) (i *Iterator.Iterator) {
+	if avl == nil {
+		return nil
+	}
+	avl.mutex.Lock()
+	es := make([]interface{}, 0)
+	if avl.root != nil && avl.cmp != nil {
+		avl.root.rangeOrder(lo, hi, isLoIn, isHiIn, avl.cmp, &es)
+	}
+	i = Iterator.New(&es)
+	avl.mutex.Unlock()
+	return i
+}
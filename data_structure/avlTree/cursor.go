@@ -0,0 +1,65 @@
+package avlTree
+
+//@Title		avlTree
+//@Description
+//		平衡二叉树的游标
+//		游标的移动逻辑由cursor包实现,此处仅让节点实现cursor.Node接口
+//		游标不持有元素的副本,在创建游标后对平衡二叉树进行增删将使游标失效
+
+import (
+	"github.com/hlccd/goSTL/utils/cursor"
+)
+
+//Cursor游标
+//指向平衡二叉树中的某一个元素,可双向移动
+type Cursor = cursor.Cursor
+
+//@title    Left
+//@description
+//		以node平衡二叉树节点做接收者
+//		返回该节点的左子节点,不存在时返回nil
+//@receiver		n			*node					接受者node的指针
+//@param    	nil
+//@return    	m			cursor.Node				左子节点
+func (n *node) Left() (m cursor.Node) {
+	if n.left == nil {
+		return nil
+	}
+	return n.left
+}
+
+//@title    Right
+//@description
+//		以node平衡二叉树节点做接收者
+//		返回该节点的右子节点,不存在时返回nil
+//@receiver		n			*node					接受者node的指针
+//@param    	nil
+//@return    	m			cursor.Node				右子节点
+func (n *node) Right() (m cursor.Node) {
+	if n.right == nil {
+		return nil
+	}
+	return n.right
+}
+
+//@title    Count
+//@description
+//		以node平衡二叉树节点做接收者
+//		返回该节点中存储的重复元素数量
+//@receiver		n			*node					接受者node的指针
+//@param    	nil
+//@return    	num			int						重复元素数量
+func (n *node) Count() (num int) {
+	return n.num
+}
+
+//@title    Value
+//@description
+//		以node平衡二叉树节点做接收者
+//		返回该节点中存储的元素
+//@receiver		n			*node					接受者node的指针
+//@param    	nil
+//@return    	e			interface{}				节点中存储的元素
+func (n *node) Value() (e interface{}) {
+	return n.value
+}
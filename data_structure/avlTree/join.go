@@ -0,0 +1,408 @@
+package avlTree
+
+//@Title		avlTree
+//@Description
+//		基于连接操作的平衡二叉树集合运算
+//		连接操作将两棵平衡二叉树与一个中间节点合并为一棵平衡二叉树,时间复杂度为O(|h1-h2|)
+//		拆分操作将一棵平衡二叉树按元素拆分为两棵平衡二叉树,时间复杂度为O(log n)
+//		以连接和拆分为基础实现并集、交集、差集和过滤,对于大小为m和n(m<=n)的两棵树时间复杂度为O(m log(n/m + 1))
+//		左右子树的计算互不影响,当子树规模超过阈值时使用协程并行计算
+//		集合运算将直接移动参与运算的平衡二叉树的节点,运算后原平衡二叉树均为空
+
+import (
+	"github.com/hlccd/goSTL/utils/comparator"
+	"sync"
+)
+
+//并行计算的阈值
+//参与运算的两棵子树的元素数量之和超过该值时,左右子树将在不同协程中计算
+const parallelThreshold = 1 << 12
+
+//@title    update
+//@description
+//		以node平衡二叉树节点做接收者
+//		根据左右子节点重新计算n节点的深度和子树元素数量
+//@receiver		n			*node					接受者node的指针
+//@param    	nil
+//@return    	nil
+func (n *node) update() {
+	n.depth = max(n.left.getDepth(), n.right.getDepth()) + 1
+	n.size = n.left.getSize() + n.right.getSize() + n.num
+}
+
+//@title    join
+//@description
+//		将平衡二叉树l、节点k和平衡二叉树r连接为一棵平衡二叉树并返回其根节点
+//		要求l中的元素均小于k中的元素,r中的元素均大于k中的元素
+//		若两侧高度差不超过1则直接以k为根节点
+//		否则沿较高一侧的内侧路径向下,直到高度差不超过1处连接,再自下而上进行调整
+//@receiver		nil
+//@param    	l			*node					元素较小的子树
+//@param    	k			*node					中间节点
+//@param    	r			*node					元素较大的子树
+//@return    	m        	*node					连接后的子树根节点
+func join(l, k, r *node) (m *node) {
+	if l.getDepth() > r.getDepth()+1 {
+		//左侧过高,沿左子树的右侧路径连接
+		l.right = join(l.right, k, r)
+		l.update()
+		return l.adjust()
+	}
+	if r.getDepth() > l.getDepth()+1 {
+		//右侧过高,沿右子树的左侧路径连接
+		r.left = join(l, k, r.left)
+		r.update()
+		return r.adjust()
+	}
+	k.left, k.right = l, r
+	k.update()
+	return k
+}
+
+//@title    splitMax
+//@description
+//		以node平衡二叉树节点做接收者
+//		将以n为根的子树中承载最大元素的节点拆分出来
+//		返回去掉该节点后的子树以及该节点
+//@receiver		n			*node					接受者node的指针
+//@param    	nil
+//@return    	l        	*node					去掉最大节点后的子树
+//@return    	k        	*node					承载最大元素的节点
+func (n *node) splitMax() (l, k *node) {
+	if n.right == nil {
+		l = n.left
+		n.left = nil
+		n.update()
+		return l, n
+	}
+	l, k = n.right.splitMax()
+	return join(n.left, n, l), k
+}
+
+//@title    join2
+//@description
+//		将平衡二叉树l和平衡二叉树r连接为一棵平衡二叉树并返回其根节点
+//		要求l中的元素均小于r中的元素
+//		取出l中的最大节点作为中间节点进行连接
+//@receiver		nil
+//@param    	l			*node					元素较小的子树
+//@param    	r			*node					元素较大的子树
+//@return    	m        	*node					连接后的子树根节点
+func join2(l, r *node) (m *node) {
+	if l == nil {
+		return r
+	}
+	l, k := l.splitMax()
+	return join(l, k, r)
+}
+
+//@title    split
+//@description
+//		以node平衡二叉树节点做接收者
+//		将以n为根的子树按元素e拆分为小于e的子树、与e相等的节点和大于e的子树
+//		若不存在与e相等的节点则该节点返回nil
+//		拆分沿查找路径进行,将路径两侧的子树依次连接起来
+//@receiver		n			*node					接受者node的指针
+//@param    	e			interface{}				拆分依据的元素
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	l        	*node					小于e的元素构成的子树
+//@return    	m        	*node					与e相等的节点
+//@return    	r        	*node					大于e的元素构成的子树
+func (n *node) split(e interface{}, cmp comparator.Comparator) (l, m, r *node) {
+	if n == nil {
+		return nil, nil, nil
+	}
+	c := cmp(e, n.value)
+	if c < 0 {
+		l, m, r = n.left.split(e, cmp)
+		return l, m, join(r, n, n.right)
+	}
+	if c > 0 {
+		l, m, r = n.right.split(e, cmp)
+		return join(n.left, n, l), m, r
+	}
+	l, r = n.left, n.right
+	n.left, n.right = nil, nil
+	n.update()
+	return l, n, r
+}
+
+//@title    fork
+//@description
+//		执行左右两侧的计算
+//		若isParallel为true则左侧在新协程中计算,右侧在当前协程中计算,两者都结束后返回
+//		否则依次计算左右两侧
+//@receiver		nil
+//@param    	isParallel	bool					是否并行计算?
+//@param    	left		func()					左侧的计算
+//@param    	right		func()					右侧的计算
+//@return    	nil
+func fork(isParallel bool, left, right func()) {
+	if !isParallel {
+		left()
+		right()
+		return
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		left()
+		wg.Done()
+	}()
+	right()
+	wg.Wait()
+}
+
+//@title    union
+//@description
+//		求以a和b为根的两棵子树的并集并返回其根节点
+//		以b中与a根节点相等的元素为界拆分b,将两侧分别与a的左右子树求并集后连接
+//		若允许重复则相等元素的数量相加,否则以b中的元素覆盖a中的元素
+//@receiver		nil
+//@param    	a			*node					第一棵子树
+//@param    	b			*node					第二棵子树
+//@param    	isMulti		bool					是否允许重复?
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	n        	*node					并集的根节点
+func union(a, b *node, isMulti bool, cmp comparator.Comparator) (n *node) {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	isParallel := a.size+b.size > parallelThreshold
+	bl, m, br := b.split(a.value, cmp)
+	al, ar := a.left, a.right
+	var l, r *node
+	fork(isParallel, func() {
+		l = union(al, bl, isMulti, cmp)
+	}, func() {
+		r = union(ar, br, isMulti, cmp)
+	})
+	if m != nil {
+		if isMulti {
+			a.num += m.num
+		} else {
+			a.value = m.value
+		}
+	}
+	return join(l, a, r)
+}
+
+//@title    intersect
+//@description
+//		求以a和b为根的两棵子树的交集并返回其根节点
+//		以b中与a根节点相等的元素为界拆分b,将两侧分别与a的左右子树求交集后连接
+//		若允许重复则相等元素的数量取两者中较小的一个
+//@receiver		nil
+//@param    	a			*node					第一棵子树
+//@param    	b			*node					第二棵子树
+//@param    	isMulti		bool					是否允许重复?
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	n        	*node					交集的根节点
+func intersect(a, b *node, isMulti bool, cmp comparator.Comparator) (n *node) {
+	if a == nil || b == nil {
+		return nil
+	}
+	isParallel := a.size+b.size > parallelThreshold
+	bl, m, br := b.split(a.value, cmp)
+	al, ar := a.left, a.right
+	var l, r *node
+	fork(isParallel, func() {
+		l = intersect(al, bl, isMulti, cmp)
+	}, func() {
+		r = intersect(ar, br, isMulti, cmp)
+	})
+	if m == nil {
+		//a根节点的元素不在b中
+		return join2(l, r)
+	}
+	if isMulti && m.num < a.num {
+		a.num = m.num
+	}
+	return join(l, a, r)
+}
+
+//@title    difference
+//@description
+//		求以a为根的子树去掉以b为根的子树中元素后的差集并返回其根节点
+//		以b中与a根节点相等的元素为界拆分b,将两侧分别与a的左右子树求差集后连接
+//		若允许重复则相等元素的数量相减,减至0时删除该节点
+//@receiver		nil
+//@param    	a			*node					被减的子树
+//@param    	b			*node					减去的子树
+//@param    	isMulti		bool					是否允许重复?
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	n        	*node					差集的根节点
+func difference(a, b *node, isMulti bool, cmp comparator.Comparator) (n *node) {
+	if a == nil {
+		return nil
+	}
+	if b == nil {
+		return a
+	}
+	isParallel := a.size+b.size > parallelThreshold
+	bl, m, br := b.split(a.value, cmp)
+	al, ar := a.left, a.right
+	var l, r *node
+	fork(isParallel, func() {
+		l = difference(al, bl, isMulti, cmp)
+	}, func() {
+		r = difference(ar, br, isMulti, cmp)
+	})
+	if m != nil {
+		if !isMulti || a.num <= m.num {
+			//a根节点的元素被全部减去
+			return join2(l, r)
+		}
+		a.num -= m.num
+	}
+	return join(l, a, r)
+}
+
+//@title    filter
+//@description
+//		过滤以n为根的子树,仅保留满足条件的元素,并返回其根节点
+//		左右子树分别过滤后,根据n节点是否满足条件选择是否以n为中间节点连接
+//@receiver		nil
+//@param    	n			*node					待过滤的子树
+//@param    	f			func(interface{}) bool	过滤条件,返回true的元素将被保留
+//@return    	m        	*node					过滤后的根节点
+func filter(n *node, f func(e interface{}) bool) (m *node) {
+	if n == nil {
+		return nil
+	}
+	isParallel := n.size > parallelThreshold
+	nl, nr := n.left, n.right
+	var l, r *node
+	fork(isParallel, func() {
+		l = filter(nl, f)
+	}, func() {
+		r = filter(nr, f)
+	})
+	if f(n.value) {
+		return join(l, n, r)
+	}
+	return join2(l, r)
+}
+
+//@title    takeOver
+//@description
+//		从a和b中取出根节点和比较器等信息,用于创建集合运算的结果
+//		结果沿用a是否允许重复的属性,比较器优先使用a的比较器
+//		取出后a和b均被置空
+//		依次对a和b加锁取出后立即解锁,不同时持有两把锁,避免并发调用Union(a,b)和Union(b,a)时相互等待
+//		若a和b为同一个平衡二叉树或任意一个不存在,则返回失败
+//@receiver		nil
+//@param    	a			*AvlTree				第一个平衡二叉树
+//@param    	b			*AvlTree				第二个平衡二叉树
+//@return    	ra        	*node					a的根节点
+//@return    	rb        	*node					b的根节点
+//@return    	t        	*AvlTree				承载运算结果的空平衡二叉树
+func takeOver(a, b *AvlTree) (ra, rb *node, t *AvlTree) {
+	if a == nil || b == nil || a == b {
+		return nil, nil, nil
+	}
+	a.mutex.Lock()
+	cmp, isMulti := a.cmp, a.isMulti
+	ra = a.root
+	a.root, a.size = nil, 0
+	a.mutex.Unlock()
+	b.mutex.Lock()
+	if cmp == nil {
+		cmp = b.cmp
+	}
+	rb = b.root
+	b.root, b.size = nil, 0
+	b.mutex.Unlock()
+	return ra, rb, New(isMulti, cmp)
+}
+
+//@title    Union
+//@description
+//		求两个平衡二叉树的并集并以新的平衡二叉树返回
+//		若允许重复则相等元素的数量相加,否则以b中的元素覆盖a中的元素
+//		结果沿用a是否允许重复的属性,两个平衡二叉树应使用相同的比较器
+//		运算将直接移动a和b的节点,运算后a和b均为空
+//		若a和b为同一个平衡二叉树或任意一个不存在则返回nil
+//@receiver		nil
+//@param    	a			*AvlTree				第一个平衡二叉树
+//@param    	b			*AvlTree				第二个平衡二叉树
+//@return    	t        	*AvlTree				并集
+func Union(a, b *AvlTree) (t *AvlTree) {
+	ra, rb, t := takeOver(a, b)
+	if t == nil {
+		return nil
+	}
+	t.root = union(ra, rb, t.isMulti, t.cmp)
+	t.size = t.root.getSize()
+	return t
+}
+
+//@title    Intersect
+//@description
+//		求两个平衡二叉树的交集并以新的平衡二叉树返回
+//		若允许重复则相等元素的数量取两者中较小的一个,元素取自a
+//		结果沿用a是否允许重复的属性,两个平衡二叉树应使用相同的比较器
+//		运算将直接移动a和b的节点,运算后a和b均为空
+//		若a和b为同一个平衡二叉树或任意一个不存在则返回nil
+//@receiver		nil
+//@param    	a			*AvlTree				第一个平衡二叉树
+//@param    	b			*AvlTree				第二个平衡二叉树
+//@return    	t        	*AvlTree				交集
+func Intersect(a, b *AvlTree) (t *AvlTree) {
+	ra, rb, t := takeOver(a, b)
+	if t == nil {
+		return nil
+	}
+	t.root = intersect(ra, rb, t.isMulti, t.cmp)
+	t.size = t.root.getSize()
+	return t
+}
+
+//@title    Difference
+//@description
+//		求平衡二叉树a去掉平衡二叉树b中元素后的差集并以新的平衡二叉树返回
+//		若允许重复则相等元素的数量相减
+//		结果沿用a是否允许重复的属性,两个平衡二叉树应使用相同的比较器
+//		运算将直接移动a和b的节点,运算后a和b均为空
+//		若a和b为同一个平衡二叉树或任意一个不存在则返回nil
+//@receiver		nil
+//@param    	a			*AvlTree				被减的平衡二叉树
+//@param    	b			*AvlTree				减去的平衡二叉树
+//@return    	t        	*AvlTree				差集
+func Difference(a, b *AvlTree) (t *AvlTree) {
+	ra, rb, t := takeOver(a, b)
+	if t == nil {
+		return nil
+	}
+	t.root = difference(ra, rb, t.isMulti, t.cmp)
+	t.size = t.root.getSize()
+	return t
+}
+
+//@title    Filter
+//@description
+//		过滤平衡二叉树a,将满足条件的元素以新的平衡二叉树返回
+//		过滤条件对每个不同的元素只调用一次,重复元素将被一并保留或删除
+//		过滤条件可能在多个协程中被同时调用
+//		运算将直接移动a的节点,运算后a为空
+//		若a不存在或过滤条件为nil则返回nil
+//@receiver		nil
+//@param    	a			*AvlTree				待过滤的平衡二叉树
+//@param    	f			func(interface{}) bool	过滤条件,返回true的元素将被保留
+//@return    	t        	*AvlTree				过滤结果
+func Filter(a *AvlTree, f func(e interface{}) bool) (t *AvlTree) {
+	if a == nil || f == nil {
+		return nil
+	}
+	a.mutex.Lock()
+	root := a.root
+	t = New(a.isMulti, a.cmp)
+	a.root, a.size = nil, 0
+	a.mutex.Unlock()
+	t.root = filter(root, f)
+	t.size = t.root.getSize()
+	return t
+}
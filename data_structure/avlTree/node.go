@@ -8,6 +8,7 @@ package avlTree
 //		增减节点后通过左右旋转的方式保持平衡二叉树的平衡
 import (
 	"github.com/hlccd/goSTL/utils/comparator"
+	"github.com/hlccd/goSTL/utils/cursor"
 )
 
 //node树节点结构体
@@ -18,6 +19,7 @@ type node struct {
 	value interface{} //节点中存储的元素
 	num   int         //该元素数量
 	depth int         //该节点的深度
+	size  int         //以该节点为根的子树中存储的元素数量,重复元素计入多次
 	left  *node       //左节点指针
 	right *node       //右节点指针
 }
@@ -26,7 +28,7 @@ type node struct {
 //@description
 //		新建一个平衡二叉树节点并返回
 //		将传入的元素e作为该节点的承载元素
-//		该节点的num和depth默认为1,左右子节点设为nil
+//		该节点的num,depth和size默认为1,左右子节点设为nil
 //@receiver		nil
 //@param    	e			interface{}				承载元素e
 //@return    	n        	*node					新建的二叉搜索树节点的指针
@@ -35,6 +37,7 @@ func newNode(e interface{}) (n *node) {
 		value: e,
 		num:   1,
 		depth: 1,
+		size:  1,
 		left:  nil,
 		right: nil,
 	}
@@ -78,6 +81,20 @@ func (n *node) getDepth() (depth int) {
 	return n.depth
 }
 
+//@title    getSize
+//@description
+//		以node平衡二叉树节点做接收者
+//		返回以该节点为根的子树中存储的元素数量,节点不存在返回0
+//@receiver		n			*node					接受者node的指针
+//@param    	nil
+//@return    	size        int						子树中存储的元素数量
+func (n *node) getSize() (size int) {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
 //@title    max
 //@description
 //		返回a和b中较大的值
@@ -106,9 +123,11 @@ func (n *node) leftRotate() (m *node) {
 	headNode := n.right
 	n.right = headNode.left
 	headNode.left = n
-	//更新结点高度
+	//更新结点高度和子树元素数量
 	n.depth = max(n.left.getDepth(), n.right.getDepth()) + 1
 	headNode.depth = max(headNode.left.getDepth(), headNode.right.getDepth()) + 1
+	n.size = n.left.getSize() + n.right.getSize() + n.num
+	headNode.size = headNode.left.getSize() + headNode.right.getSize() + headNode.num
 	return headNode
 }
 
@@ -125,9 +144,11 @@ func (n *node) rightRotate() (m *node) {
 	headNode := n.left
 	n.left = headNode.right
 	headNode.right = n
-	//更新结点高度
+	//更新结点高度和子树元素数量
 	n.depth = max(n.left.getDepth(), n.right.getDepth()) + 1
 	headNode.depth = max(headNode.left.getDepth(), headNode.right.getDepth()) + 1
+	n.size = n.left.getSize() + n.right.getSize() + n.num
+	headNode.size = headNode.left.getSize() + headNode.right.getSize() + headNode.num
 	return headNode
 }
 
@@ -241,6 +262,7 @@ func (n *node) insert(e interface{}, isMulti bool, cmp comparator.Comparator) (m
 			n = n.adjust()
 		}
 		n.depth = max(n.left.getDepth(), n.right.getDepth()) + 1
+		n.size = n.left.getSize() + n.right.getSize() + n.num
 		return n, b
 	}
 	if cmp(e, n.value) > 0 {
@@ -251,12 +273,14 @@ func (n *node) insert(e interface{}, isMulti bool, cmp comparator.Comparator) (m
 			n = n.adjust()
 		}
 		n.depth = max(n.left.getDepth(), n.right.getDepth()) + 1
+		n.size = n.left.getSize() + n.right.getSize() + n.num
 		return n, b
 	}
 	//该节点元素与待插入元素相同
 	if isMulti {
 		//允许重复,数目+1
 		n.num++
+		n.size++
 		return n, true
 	}
 	//不允许重复,对值进行覆盖
@@ -299,8 +323,8 @@ func (n *node) erase(e interface{}, cmp comparator.Comparator) (m *node, b bool)
 			if n.left != nil && n.right != nil {
 				//找到该节点后继节点进行交换删除
 				n.value, n.num = n.right.getMin()
-				//从右节点继续删除,同时可以保证删除的节点必然无左节点
-				n.right, b = n.right.erase(n.value, cmp)
+				//将后继节点从右子树中整体移除,其可能承载多个重复元素
+				n.right = n.right.eraseMin()
 			} else if n.left != nil {
 				n = n.left
 			} else {
@@ -311,11 +335,31 @@ func (n *node) erase(e interface{}, cmp comparator.Comparator) (m *node, b bool)
 	//当n节点仍然存在时,对其进行调整
 	if n != nil {
 		n.depth = max(n.left.getDepth(), n.right.getDepth()) + 1
+		n.size = n.left.getSize() + n.right.getSize() + n.num
 		n = n.adjust()
 	}
 	return n, b
 }
 
+//@title    eraseMin
+//@description
+//		以node平衡二叉树节点做接收者
+//		从以n为根的子树中将承载最小元素的节点整体删除
+//		无论该节点承载了多少个重复元素都一并删除
+//		删除后沿途对节点进行调整
+//@receiver		n			*node					接受者node的指针
+//@param    	nil
+//@return    	m        	*node					删除后的子树根节点
+func (n *node) eraseMin() (m *node) {
+	if n.left == nil {
+		return n.right
+	}
+	n.left = n.left.eraseMin()
+	n.depth = max(n.left.getDepth(), n.right.getDepth()) + 1
+	n.size = n.left.getSize() + n.right.getSize() + n.num
+	return n.adjust()
+}
+
 //@title    count
 //@description
 //		以node二叉搜索树节点做接收者
@@ -370,3 +414,144 @@ func (n *node) find(e interface{}, isMulti bool, cmp comparator.Comparator) (ans
 	//n中承载元素等于e,直接返回结果
 	return n.value
 }
+
+//@title    rank
+//@description
+//		以node平衡二叉树节点做接收者
+//		从n节点开始统计比元素e小的元素个数
+//		若isEqual为true则同时统计与e相等的元素
+//		通过子树元素数量,每层只需选择一侧继续统计
+//@receiver		n			*node					接受者node的指针
+//@param    	e			interface{}				待统计元素
+//@param    	isEqual		bool					是否统计相等的元素?
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	num        	int						满足条件的元素个数
+func (n *node) rank(e interface{}, isEqual bool, cmp comparator.Comparator) (num int) {
+	for n != nil {
+		c := cmp(e, n.value)
+		if c < 0 || (c == 0 && !isEqual) {
+			//n及其右子树均不满足条件,从左子树继续统计
+			n = n.left
+		} else {
+			//n及其左子树均满足条件,从右子树继续统计
+			num += n.left.getSize() + n.num
+			n = n.right
+		}
+	}
+	return num
+}
+
+//@title    kth
+//@description
+//		以node平衡二叉树节点做接收者
+//		从n节点开始寻找中缀序列中下标为k的元素,下标从0开始
+//		重复元素占据多个下标
+//		若k超出范围则返回nil
+//@receiver		n			*node					接受者node的指针
+//@param    	k			int						待寻找元素的下标
+//@return    	e        	interface{}				下标为k的元素
+func (n *node) kth(k int) (e interface{}) {
+	if k < 0 || k >= n.getSize() {
+		return nil
+	}
+	for n != nil {
+		l := n.left.getSize()
+		if k < l {
+			n = n.left
+		} else if k < l+n.num {
+			return n.value
+		} else {
+			k -= l + n.num
+			n = n.right
+		}
+	}
+	return nil
+}
+
+//@title    lowerBound
+//@description
+//		以node平衡二叉树节点做接收者
+//		从n节点开始寻找承载不小于元素e的最小元素的节点
+//		返回从n节点到该节点的路径,若不存在则返回空路径
+//@receiver		n			*node					接受者node的指针
+//@param    	e			interface{}				待查找元素
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	path        []cursor.Node				从n节点到目标节点的路径
+func (n *node) lowerBound(e interface{}, cmp comparator.Comparator) (path []cursor.Node) {
+	depth := 0
+	for n != nil {
+		path = append(path, n)
+		if cmp(n.value, e) >= 0 {
+			//n满足条件,继续从左子树寻找更小的满足条件的节点
+			depth = len(path)
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return path[:depth]
+}
+
+//@title    upperBound
+//@description
+//		以node平衡二叉树节点做接收者
+//		从n节点开始寻找承载大于元素e的最小元素的节点
+//		返回从n节点到该节点的路径,若不存在则返回空路径
+//@receiver		n			*node					接受者node的指针
+//@param    	e			interface{}				待查找元素
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	path        []cursor.Node				从n节点到目标节点的路径
+func (n *node) upperBound(e interface{}, cmp comparator.Comparator) (path []cursor.Node) {
+	depth := 0
+	for n != nil {
+		path = append(path, n)
+		if cmp(n.value, e) > 0 {
+			//n满足条件,继续从左子树寻找更小的满足条件的节点
+			depth = len(path)
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return path[:depth]
+}
+
+//@title    rangeOrder
+//@description
+//		以node平衡二叉树节点做接收者
+//		以中缀序列将处于区间内的元素放入es中
+//		lo或hi为nil时表示该侧无界,isLoIn和isHiIn表示区间是否包含对应端点
+//		仅当子树中可能存在区间内的元素时才进入该子树,时间复杂度为O(h + k),h为树高
+//@receiver		n			*node					接受者node的指针
+//@param    	lo			interface{}				区间下界
+//@param    	hi			interface{}				区间上界
+//@param    	isLoIn		bool					是否包含下界?
+//@param    	isHiIn		bool					是否包含上界?
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@param    	es			*[]interface{}			承载结果的切片指针
+//@return    	nil
+func (n *node) rangeOrder(lo, hi interface{}, isLoIn, isHiIn bool, cmp comparator.Comparator, es *[]interface{}) {
+	if n == nil {
+		return
+	}
+	cl, ch := 1, -1
+	if lo != nil {
+		cl = cmp(n.value, lo)
+	}
+	if hi != nil {
+		ch = cmp(n.value, hi)
+	}
+	if cl > 0 {
+		//n大于下界,左子树中可能存在区间内的元素
+		n.left.rangeOrder(lo, hi, isLoIn, isHiIn, cmp, es)
+	}
+	if (cl > 0 || (cl == 0 && isLoIn)) && (ch < 0 || (ch == 0 && isHiIn)) {
+		for i := 0; i < n.num; i++ {
+			*es = append(*es, n.value)
+		}
+	}
+	if ch < 0 {
+		//n小于上界,右子树中可能存在区间内的元素
+		n.right.rangeOrder(lo, hi, isLoIn, isHiIn, cmp, es)
+	}
+}
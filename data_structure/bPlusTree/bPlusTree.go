@@ -0,0 +1,525 @@
+package bPlusTree
+
+//@Title		bPlusTree
+//@Description
+//		B+树-B Plus Tree
+//		以多路平衡搜索树的形式实现,元素全部存放在叶子节点中,内部节点仅存放分隔元素
+//		每个节点连续存放至多degree个元素或子节点,相比二叉树减少了节点分配和指针跳转
+//		叶子节点之间以双向链表相连,范围遍历只需定位一次后沿链表顺序访问
+//		B+树实例保存根节点和比较器以及保存的数量
+//		可以在创建时设置节点是否可重复
+//		若节点可重复则增加元素对应的数值,否则对存储元素进行覆盖
+//		可以通过BulkLoad以O(n)的时间从有序序列直接构建
+//		使用互斥锁实现并发控制
+
+import (
+	"github.com/hlccd/goSTL/utils/comparator"
+	"github.com/hlccd/goSTL/utils/iterator"
+	"sync"
+)
+
+const (
+	defaultDegree = 64 //默认节点容量
+	minDegree     = 4  //最小节点容量
+)
+
+//bPlusTree B+树结构体
+//该实例存储B+树的根节点和节点容量
+//同时保存该树已经存储了多少个元素
+//树中排序使用的比较器在创建时传入,若不传入则在插入首个节点时从默认比较器中寻找
+//创建时传入是否允许该树出现重复值,如果不允许则进行覆盖,允许则对元素数目增加即可
+type BPlusTree struct {
+	root    *node                 //根节点指针
+	size    int                   //存储元素数量
+	degree  int                   //节点容量
+	cmp     comparator.Comparator //比较器
+	isMulti bool                  //是否允许重复
+	mutex   sync.Mutex            //并发控制锁
+}
+
+//bPlusTree B+树容器接口
+//存放了bPlusTree B+树可使用的函数
+//对应函数介绍见下方
+type bPlusTreer interface {
+	Iterator() (i *Iterator.Iterator)                                             //返回包含该树的所有元素,重复则返回多个
+	Size() (num int)                                                              //返回该树中保存的元素个数
+	Clear()                                                                       //清空该树
+	Empty() (b bool)                                                              //判断该树是否为空
+	Insert(e interface{}) (b bool)                                                //向树中插入元素e
+	Erase(e interface{}) (b bool)                                                 //从树中删除元素e
+	Count(e interface{}) (num int)                                                //从树中寻找元素e并返回其个数
+	Find(e interface{}) (ans interface{})                                         //从树中寻找与元素e相等的元素并返回
+	LowerBound(e interface{}) (c *Cursor)                                         //返回指向不小于e的最小元素的游标
+	UpperBound(e interface{}) (c *Cursor)                                         //返回指向大于e的最小元素的游标
+	RangeIterator(lo, hi interface{}, isLoIn, isHiIn bool) (i *Iterator.Iterator) //返回包含区间内所有元素的迭代器
+	BulkLoad(es []interface{}) (b bool)                                           //以有序序列es重建该树
+}
+
+//@title    New
+//@description
+//		新建一个bPlusTree B+树容器并返回
+//		使用默认的节点容量,初始根节点为nil
+//		传入该树是否为可重复属性,如果为true则保存重复值,否则对原有相等元素进行覆盖
+//		若有传入的比较器,则将传入的第一个比较器设为该树的比较器
+//@receiver		nil
+//@param    	isMulti		bool						该树是否保存重复值?
+//@param    	Cmp			 ...comparator.Comparator	bPlusTree比较器集
+//@return    	bt        	*BPlusTree					新建的bPlusTree指针
+func New(isMulti bool, cmps ...comparator.Comparator) (bt *BPlusTree) {
+	return NewWithDegree(defaultDegree, isMulti, cmps...)
+}
+
+//@title    NewWithDegree
+//@description
+//		新建一个节点容量为degree的bPlusTree B+树容器并返回
+//		每个节点至多存放degree个元素或子节点,degree小于4时按4处理
+//		传入该树是否为可重复属性,如果为true则保存重复值,否则对原有相等元素进行覆盖
+//		若有传入的比较器,则将传入的第一个比较器设为该树的比较器
+//@receiver		nil
+//@param    	degree		int							节点容量
+//@param    	isMulti		bool						该树是否保存重复值?
+//@param    	Cmp			 ...comparator.Comparator	bPlusTree比较器集
+//@return    	bt        	*BPlusTree					新建的bPlusTree指针
+func NewWithDegree(degree int, isMulti bool, cmps ...comparator.Comparator) (bt *BPlusTree) {
+	if degree < minDegree {
+		degree = minDegree
+	}
+	//判断是否有传入比较器,若有则设为该树默认比较器
+	var cmp comparator.Comparator
+	if len(cmps) == 0 {
+		cmp = nil
+	} else {
+		cmp = cmps[0]
+	}
+	return &BPlusTree{
+		root:    nil,
+		size:    0,
+		degree:  degree,
+		cmp:     cmp,
+		isMulti: isMulti,
+	}
+}
+
+//@title    first
+//@description
+//		以bPlusTree B+树做接收者
+//		返回该树最左侧的叶子节点,树为空时返回nil
+//@receiver		bt			*BPlusTree				接受者bPlusTree的指针
+//@param    	nil
+//@return    	n        	*node					最左侧的叶子节点
+func (bt *BPlusTree) first() (n *node) {
+	if bt.root == nil {
+		return nil
+	}
+	for n = bt.root; !n.isLeaf; n = n.children[0] {
+	}
+	return n
+}
+
+//@title    Iterator
+//@description
+//		以bPlusTree B+树做接收者
+//		从最左侧的叶子节点开始沿叶子链表将所有元素放入迭代器中
+//		若允许重复存储则对于重复元素进行多次放入
+//@receiver		bt			*BPlusTree				接受者bPlusTree的指针
+//@param    	nil
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (bt *BPlusTree) Iterator() (i *Iterator.Iterator) {
+	if bt == nil {
+		return nil
+	}
+	bt.mutex.Lock()
+	es := make([]interface{}, 0, bt.size)
+	for n := bt.first(); n != nil; n = n.next {
+		for j := range n.keys {
+			for k := 0; k < n.nums[j]; k++ {
+				es = append(es, n.keys[j])
+			}
+		}
+	}
+	bt.mutex.Unlock()
+	return Iterator.New(&es)
+}
+
+//@title    Size
+//@description
+//		以bPlusTree B+树做接收者
+//		返回该容器当前含有元素的数量
+//		如果容器为nil返回0
+//@receiver		bt			*BPlusTree				接受者bPlusTree的指针
+//@param    	nil
+//@return    	num        	int						容器中实际使用元素所占空间大小
+func (bt *BPlusTree) Size() (num int) {
+	if bt == nil {
+		return 0
+	}
+	return bt.size
+}
+
+//@title    Clear
+//@description
+//		以bPlusTree B+树做接收者
+//		将该容器中所承载的元素清空
+//		将该容器的size置0
+//@receiver		bt			*BPlusTree				接受者bPlusTree的指针
+//@param    	nil
+//@return    	nil
+func (bt *BPlusTree) Clear() {
+	if bt == nil {
+		return
+	}
+	bt.mutex.Lock()
+	bt.root = nil
+	bt.size = 0
+	bt.mutex.Unlock()
+}
+
+//@title    Empty
+//@description
+//		以bPlusTree B+树做接收者
+//		判断该树是否含有元素
+//		如果含有元素则不为空,返回false
+//		如果不含有元素则说明为空,返回true
+//		如果容器不存在,返回true
+//@receiver		bt			*BPlusTree				接受者bPlusTree的指针
+//@param    	nil
+//@return    	b			bool					该容器是空的吗?
+func (bt *BPlusTree) Empty() (b bool) {
+	if bt == nil {
+		return true
+	}
+	return bt.size == 0
+}
+
+//@title    Insert
+//@description
+//		以bPlusTree B+树做接收者
+//		向树中插入元素e,若不允许重复则对相等元素进行覆盖
+//		如果树为空则新建一个叶子节点作为根节点
+//		节点溢出时向上逐层分裂,根节点分裂时树高加一
+//@receiver		bt			*BPlusTree				接受者bPlusTree的指针
+//@param    	e			interface{}				待插入元素
+//@return    	b			bool					添加成功?
+func (bt *BPlusTree) Insert(e interface{}) (b bool) {
+	if bt == nil {
+		return false
+	}
+	bt.mutex.Lock()
+	if bt.cmp == nil {
+		bt.cmp = comparator.GetCmp(e)
+	}
+	if bt.cmp == nil {
+		bt.mutex.Unlock()
+		return false
+	}
+	if bt.root == nil {
+		bt.root = newLeaf(bt.degree)
+	}
+	b, right, key := bt.root.insert(e, bt.isMulti, bt.degree, bt.cmp)
+	if right != nil {
+		//根节点分裂,新建根节点
+		root := newInternal(bt.degree)
+		root.keys = append(root.keys, key)
+		root.children = append(root.children, bt.root, right)
+		bt.root = root
+	}
+	if b {
+		//插入成功,数量+1
+		bt.size++
+	}
+	bt.mutex.Unlock()
+	return b
+}
+
+//@title    Erase
+//@description
+//		以bPlusTree B+树做接收者
+//		从树中删除元素e
+//		若允许重复记录则对元素e的数量记录减一即可
+//		节点低于最小容量时向兄弟节点借用或与之合并
+//		根节点仅剩一个子节点时树高减一,树为空时根节点置为nil
+//@receiver		bt			*BPlusTree				接受者bPlusTree的指针
+//@param    	e			interface{}				待删除元素
+//@return    	b			bool					删除成功?
+func (bt *BPlusTree) Erase(e interface{}) (b bool) {
+	if bt == nil {
+		return false
+	}
+	bt.mutex.Lock()
+	if bt.root == nil {
+		bt.mutex.Unlock()
+		return false
+	}
+	if b = bt.root.erase(e, bt.degree, bt.cmp); !b {
+		bt.mutex.Unlock()
+		return false
+	}
+	if !bt.root.isLeaf && len(bt.root.children) == 1 {
+		bt.root = bt.root.children[0]
+	}
+	if bt.root.isLeaf && len(bt.root.keys) == 0 {
+		bt.root = nil
+	}
+	//删除成功,数量-1
+	bt.size--
+	bt.mutex.Unlock()
+	return true
+}
+
+//@title    Count
+//@description
+//		以bPlusTree B+树做接收者
+//		从树中查找元素e的个数
+//		如果找到则返回该树中和元素e相同元素的个数
+//		如果不允许重复则最多返回1
+//		如果未找到则返回0
+//@receiver		bt			*BPlusTree				接受者bPlusTree的指针
+//@param    	e			interface{}				待查找元素
+//@return    	num			int						待查找元素在树中存储的个数
+func (bt *BPlusTree) Count(e interface{}) (num int) {
+	if bt == nil {
+		return 0
+	}
+	bt.mutex.Lock()
+	if bt.root == nil {
+		bt.mutex.Unlock()
+		return 0
+	}
+	n := bt.root.findLeaf(e, bt.cmp)
+	if idx := n.lowerIdx(e, bt.cmp); idx < len(n.keys) && bt.cmp(n.keys[idx], e) == 0 {
+		num = n.nums[idx]
+		bt.mutex.Unlock()
+		return num
+	}
+	bt.mutex.Unlock()
+	return 0
+}
+
+//@title    Find
+//@description
+//		以bPlusTree B+树做接收者
+//		从树中查找与元素e相等的元素并返回
+//		当比较器仅比较键时,可用于获取键对应的完整元素
+//		如果未找到则返回nil
+//@receiver		bt			*BPlusTree				接受者bPlusTree的指针
+//@param    	e			interface{}				待查找元素
+//@return    	ans			interface{}				树中与e相等的元素
+func (bt *BPlusTree) Find(e interface{}) (ans interface{}) {
+	if bt == nil {
+		return nil
+	}
+	bt.mutex.Lock()
+	if bt.root == nil {
+		bt.mutex.Unlock()
+		return nil
+	}
+	n := bt.root.findLeaf(e, bt.cmp)
+	if idx := n.lowerIdx(e, bt.cmp); idx < len(n.keys) && bt.cmp(n.keys[idx], e) == 0 {
+		ans = n.keys[idx]
+		bt.mutex.Unlock()
+		return ans
+	}
+	bt.mutex.Unlock()
+	return nil
+}
+
+//@title    LowerBound
+//@description
+//		以bPlusTree B+树做接收者
+//		返回指向树中不小于元素e的最小元素的游标
+//		若不存在则返回一个无效游标
+//		查找的时间复杂度为O(log n),创建游标后对树进行增删将使游标失效
+//@receiver		bt			*BPlusTree				接受者bPlusTree的指针
+//@param    	e			interface{}				待查找元素
+//@return    	c			*Cursor					指向下界的游标
+func (bt *BPlusTree) LowerBound(e interface{}) (c *Cursor) {
+	if bt == nil {
+		return nil
+	}
+	bt.mutex.Lock()
+	c = &Cursor{tree: bt}
+	if bt.root != nil {
+		c.leaf = bt.root.findLeaf(e, bt.cmp)
+		c.idx = c.leaf.lowerIdx(e, bt.cmp)
+		if c.idx == len(c.leaf.keys) {
+			c.leaf, c.idx = c.leaf.next, 0
+		}
+	}
+	bt.mutex.Unlock()
+	return c
+}
+
+//@title    UpperBound
+//@description
+//		以bPlusTree B+树做接收者
+//		返回指向树中大于元素e的最小元素的游标
+//		LowerBound(lo)到UpperBound(hi)之前的元素即为[lo,hi]区间内的元素
+//		若不存在则返回一个无效游标
+//		查找的时间复杂度为O(log n),创建游标后对树进行增删将使游标失效
+//@receiver		bt			*BPlusTree				接受者bPlusTree的指针
+//@param    	e			interface{}				待查找元素
+//@return    	c			*Cursor					指向上界的游标
+func (bt *BPlusTree) UpperBound(e interface{}) (c *Cursor) {
+	if bt == nil {
+		return nil
+	}
+	bt.mutex.Lock()
+	c = &Cursor{tree: bt}
+	if bt.root != nil {
+		c.leaf = bt.root.findLeaf(e, bt.cmp)
+		c.idx = c.leaf.upperIdx(e, bt.cmp)
+		if c.idx == len(c.leaf.keys) {
+			c.leaf, c.idx = c.leaf.next, 0
+		}
+	}
+	bt.mutex.Unlock()
+	return c
+}
+
+//@title    RangeIterator
+//@description
+//		以bPlusTree B+树做接收者
+//		将树中处于lo和hi之间的元素按从小到大的顺序放入迭代器中
+//		isLoIn和isHiIn分别表示区间是否包含lo和hi,lo或hi为nil时表示该侧无界
+//		定位到lo所在的叶子节点后沿叶子链表遍历,遇到超出hi的元素即停止
+//		若允许重复存储则对于重复元素进行多次放入
+//@receiver		bt			*BPlusTree				接受者bPlusTree的指针
+//@param    	lo			interface{}				区间下界
+//@param    	hi			interface{}				区间上界
+//@param    	isLoIn		bool					区间是否包含lo?
+//@param    	isHiIn		bool					区间是否包含hi?
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (bt *BPlusTree) RangeIterator(lo, hi interface{}, isLoIn, isHiIn bool) (i *Iterator.Iterator) {
+	if bt == nil {
+		return nil
+	}
+	bt.mutex.Lock()
+	es := make([]interface{}, 0)
+	if bt.root != nil {
+		n, idx := bt.first(), 0
+		if lo != nil {
+			n = bt.root.findLeaf(lo, bt.cmp)
+			if isLoIn {
+				idx = n.lowerIdx(lo, bt.cmp)
+			} else {
+				idx = n.upperIdx(lo, bt.cmp)
+			}
+		}
+	loop:
+		for ; n != nil; n, idx = n.next, 0 {
+			for ; idx < len(n.keys); idx++ {
+				if hi != nil {
+					if c := bt.cmp(n.keys[idx], hi); c > 0 || (c == 0 && !isHiIn) {
+						break loop
+					}
+				}
+				for k := 0; k < n.nums[idx]; k++ {
+					es = append(es, n.keys[idx])
+				}
+			}
+		}
+	}
+	bt.mutex.Unlock()
+	return Iterator.New(&es)
+}
+
+//@title    BulkLoad
+//@description
+//		以bPlusTree B+树做接收者
+//		以从小到大排列的序列es重建该树,原有元素将被清空
+//		若允许重复则相等元素累计数量,否则保留相等元素中的最后一个
+//		先将元素均匀地装入叶子节点,再自底向上逐层均匀地构建内部节点,时间复杂度为O(n)
+//		若es未按比较器有序或无法获取比较器,则不修改该树并返回false
+//@receiver		bt			*BPlusTree				接受者bPlusTree的指针
+//@param    	es			[]interface{}			有序的元素序列
+//@return    	b			bool					构建成功?
+func (bt *BPlusTree) BulkLoad(es []interface{}) (b bool) {
+	if bt == nil {
+		return false
+	}
+	bt.mutex.Lock()
+	if len(es) == 0 {
+		bt.root, bt.size = nil, 0
+		bt.mutex.Unlock()
+		return true
+	}
+	cmp := bt.cmp
+	if cmp == nil {
+		cmp = comparator.GetCmp(es[0])
+	}
+	if cmp == nil {
+		bt.mutex.Unlock()
+		return false
+	}
+	//合并相等元素并检查有序性
+	keys, nums := make([]interface{}, 0, len(es)), make([]int, 0, len(es))
+	for _, e := range es {
+		if len(keys) > 0 {
+			c := cmp(keys[len(keys)-1], e)
+			if c > 0 {
+				bt.mutex.Unlock()
+				return false
+			}
+			if c == 0 {
+				if bt.isMulti {
+					nums[len(nums)-1]++
+				} else {
+					keys[len(keys)-1] = e
+				}
+				continue
+			}
+		}
+		keys = append(keys, e)
+		nums = append(nums, 1)
+	}
+	bt.cmp = cmp
+	bt.root = build(keys, nums, bt.degree)
+	bt.size = 0
+	for _, num := range nums {
+		bt.size += num
+	}
+	bt.mutex.Unlock()
+	return true
+}
+
+//@title    build
+//@description
+//		以有序且互不相等的元素及其数量自底向上构建一棵B+树并返回根节点
+//		每一层都将元素或子节点均匀地分配到最少数量的节点中,使每个节点都不低于最小容量
+//@receiver		nil
+//@param    	keys		[]interface{}			有序且互不相等的元素
+//@param    	nums		[]int					各元素的数量
+//@param    	degree		int						节点容量
+//@return    	root        *node					构建出的根节点
+func build(keys []interface{}, nums []int, degree int) (root *node) {
+	cnt := len(keys)
+	parts := (cnt + degree - 1) / degree
+	level, mins := make([]*node, 0, parts), make([]interface{}, 0, parts)
+	var prev *node
+	for i := 0; i < parts; i++ {
+		l, r := i*cnt/parts, (i+1)*cnt/parts
+		n := newLeaf(degree)
+		n.keys = append(n.keys, keys[l:r]...)
+		n.nums = append(n.nums, nums[l:r]...)
+		n.prev = prev
+		if prev != nil {
+			prev.next = n
+		}
+		prev = n
+		level, mins = append(level, n), append(mins, keys[l])
+	}
+	for len(level) > 1 {
+		cnt = len(level)
+		parts = (cnt + degree - 1) / degree
+		up, upMins := make([]*node, 0, parts), make([]interface{}, 0, parts)
+		for i := 0; i < parts; i++ {
+			l, r := i*cnt/parts, (i+1)*cnt/parts
+			n := newInternal(degree)
+			n.keys = append(n.keys, mins[l+1:r]...)
+			n.children = append(n.children, level[l:r]...)
+			up, upMins = append(up, n), append(upMins, mins[l])
+		}
+		level, mins = up, upMins
+	}
+	return level[0]
+}
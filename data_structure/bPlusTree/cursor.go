@@ -0,0 +1,121 @@
+package bPlusTree
+
+//@Title		bPlusTree
+//@Description
+//		B+树的游标
+//		游标保存当前所在的叶子节点和其中的下标,借助叶子链表双向移动
+//		从任意位置开始遍历k个元素的总时间复杂度为O(log n + k)
+//		游标不持有元素的副本,在创建游标后对B+树进行增删将使游标失效
+
+//Cursor游标结构体
+//该游标指向B+树中的某一个元素
+//若元素存在多个重复值,则游标依次经过每一个重复元素
+//当游标移出B+树的范围后即失效,此后无法再移动
+type Cursor struct {
+	tree *BPlusTree //游标所属的B+树
+	leaf *node      //当前所在的叶子节点
+	idx  int        //当前元素在叶子节点中的下标
+	dup  int        //当前元素在重复元素中的序号
+}
+
+//Cursor游标接口
+//存放了Cursor游标可使用的函数
+//对应函数介绍见下方
+type cursorer interface {
+	Valid() (b bool)        //判断该游标是否指向有效元素
+	Value() (e interface{}) //返回该游标指向的元素
+	Next() (b bool)         //将该游标后移一位
+	Pre() (b bool)          //将该游标前移一位
+}
+
+//@title    Valid
+//@description
+//		以Cursor游标做接收者
+//		判断该游标是否指向B+树中的有效元素
+//		游标不存在或已移出范围时返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					该游标有效吗?
+func (c *Cursor) Valid() (b bool) {
+	if c == nil {
+		return false
+	}
+	return c.leaf != nil
+}
+
+//@title    Value
+//@description
+//		以Cursor游标做接收者
+//		返回该游标当前指向的元素
+//		若游标无效则返回nil
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	e			interface{}				游标指向的元素
+func (c *Cursor) Value() (e interface{}) {
+	if !c.Valid() {
+		return nil
+	}
+	return c.leaf.keys[c.idx]
+}
+
+//@title    Next
+//@description
+//		以Cursor游标做接收者
+//		将该游标移动到下一个元素
+//		若当前元素仍有未经过的重复值则只移动序号
+//		否则移动到叶子节点中的下一个元素,到达叶子节点末尾时沿链表进入下一个叶子节点
+//		移出范围后游标失效并返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					移动后游标仍有效吗?
+func (c *Cursor) Next() (b bool) {
+	if !c.Valid() {
+		return false
+	}
+	c.tree.mutex.Lock()
+	if c.dup+1 < c.leaf.nums[c.idx] {
+		//当前元素仍有重复值
+		c.dup++
+	} else {
+		c.idx, c.dup = c.idx+1, 0
+		if c.idx == len(c.leaf.keys) {
+			c.leaf, c.idx = c.leaf.next, 0
+		}
+	}
+	c.tree.mutex.Unlock()
+	return c.leaf != nil
+}
+
+//@title    Pre
+//@description
+//		以Cursor游标做接收者
+//		将该游标移动到上一个元素
+//		若当前元素仍有未经过的重复值则只移动序号
+//		否则移动到叶子节点中的上一个元素,到达叶子节点开头时沿链表进入上一个叶子节点
+//		移出范围后游标失效并返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					移动后游标仍有效吗?
+func (c *Cursor) Pre() (b bool) {
+	if !c.Valid() {
+		return false
+	}
+	c.tree.mutex.Lock()
+	if c.dup > 0 {
+		//当前元素仍有重复值
+		c.dup--
+	} else {
+		c.idx--
+		if c.idx < 0 {
+			c.leaf = c.leaf.prev
+			if c.leaf != nil {
+				c.idx = len(c.leaf.keys) - 1
+			}
+		}
+		if c.leaf != nil {
+			c.dup = c.leaf.nums[c.idx] - 1
+		}
+	}
+	c.tree.mutex.Unlock()
+	return c.leaf != nil
+}
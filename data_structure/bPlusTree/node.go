@@ -0,0 +1,328 @@
+package bPlusTree
+
+//@Title		bPlusTree
+//@Description
+//		B+树的节点
+//		叶子节点连续存放元素及其数量,并通过前后指针与相邻叶子节点相连
+//		内部节点连续存放子节点及子节点之间的分隔元素,keys[i]不大于children[i+1]中的所有元素
+//		除根节点外,每个节点中的元素或子节点数量均在[degree/2,degree]之间
+import (
+	"github.com/hlccd/goSTL/utils/comparator"
+)
+
+//node树节点结构体
+//该节点是B+树的树节点
+//叶子节点使用keys、nums、prev和next,内部节点使用keys和children
+//若该树允许重复则对元素对应的num+1即可,否则对元素进行覆盖
+type node struct {
+	isLeaf   bool          //是否为叶子节点
+	keys     []interface{} //叶子节点存储的元素或内部节点的分隔元素
+	nums     []int         //叶子节点中各元素的数量
+	children []*node       //内部节点的子节点
+	prev     *node         //前一个叶子节点
+	next     *node         //后一个叶子节点
+}
+
+//@title    newLeaf
+//@description
+//		新建一个容量为degree的空叶子节点并返回
+//		多预留一个位置以容纳分裂前的溢出元素
+//@receiver		nil
+//@param    	degree		int						节点的最大容量
+//@return    	n        	*node					新建的节点的指针
+func newLeaf(degree int) (n *node) {
+	return &node{
+		isLeaf: true,
+		keys:   make([]interface{}, 0, degree+1),
+		nums:   make([]int, 0, degree+1),
+	}
+}
+
+//@title    newInternal
+//@description
+//		新建一个容量为degree的空内部节点并返回
+//		多预留一个位置以容纳分裂前的溢出子节点
+//@receiver		nil
+//@param    	degree		int						节点的最大容量
+//@return    	n        	*node					新建的节点的指针
+func newInternal(degree int) (n *node) {
+	return &node{
+		isLeaf:   false,
+		keys:     make([]interface{}, 0, degree),
+		children: make([]*node, 0, degree+1),
+	}
+}
+
+//@title    getSize
+//@description
+//		以node B+树节点做接收者
+//		返回该节点的大小,叶子节点为元素个数,内部节点为子节点个数
+//@receiver		n			*node					接受者node的指针
+//@param    	nil
+//@return    	size        int						该节点的大小
+func (n *node) getSize() (size int) {
+	if n.isLeaf {
+		return len(n.keys)
+	}
+	return len(n.children)
+}
+
+//@title    lowerIdx
+//@description
+//		以node B+树节点做接收者
+//		二分查找该节点keys中第一个不小于e的下标,不存在时返回len(keys)
+//@receiver		n			*node					接受者node的指针
+//@param    	e			interface{}				待查找元素
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	idx        	int						第一个不小于e的下标
+func (n *node) lowerIdx(e interface{}, cmp comparator.Comparator) (idx int) {
+	l, r := 0, len(n.keys)
+	for l < r {
+		m := (l + r) / 2
+		if cmp(n.keys[m], e) < 0 {
+			l = m + 1
+		} else {
+			r = m
+		}
+	}
+	return l
+}
+
+//@title    upperIdx
+//@description
+//		以node B+树节点做接收者
+//		二分查找该节点keys中第一个大于e的下标,不存在时返回len(keys)
+//		对于内部节点,该下标即为e所在的子节点下标
+//@receiver		n			*node					接受者node的指针
+//@param    	e			interface{}				待查找元素
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	idx        	int						第一个大于e的下标
+func (n *node) upperIdx(e interface{}, cmp comparator.Comparator) (idx int) {
+	l, r := 0, len(n.keys)
+	for l < r {
+		m := (l + r) / 2
+		if cmp(n.keys[m], e) <= 0 {
+			l = m + 1
+		} else {
+			r = m
+		}
+	}
+	return l
+}
+
+//@title    findLeaf
+//@description
+//		以node B+树节点做接收者
+//		从n节点开始向下查找元素e所在的叶子节点
+//@receiver		n			*node					接受者node的指针
+//@param    	e			interface{}				待查找元素
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	m        	*node					e所在的叶子节点
+func (n *node) findLeaf(e interface{}, cmp comparator.Comparator) (m *node) {
+	for !n.isLeaf {
+		n = n.children[n.upperIdx(e, cmp)]
+	}
+	return n
+}
+
+//@title    insert
+//@description
+//		以node B+树节点做接收者
+//		从n节点中插入元素e
+//		如果叶子节点中存在与该元素相等的元素,且允许重复值,则将num+1否则对元素进行覆盖
+//		若插入后节点超出容量则将其分裂为两半,并返回分裂出的右半节点及其最小元素供上层插入
+//		插入成功返回true,不允许重复时进行覆盖返回false
+//@receiver		n			*node					接受者node的指针
+//@param    	e			interface{}				待插入元素
+//@param    	isMulti		bool					是否允许重复?
+//@param    	degree		int						节点的最大容量
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	b        	bool					是否插入成功?
+//@return    	right      	*node					分裂出的右半节点,未分裂时为nil
+//@return    	key      	interface{}				右半节点中的最小元素
+func (n *node) insert(e interface{}, isMulti bool, degree int, cmp comparator.Comparator) (b bool, right *node, key interface{}) {
+	if n.isLeaf {
+		idx := n.lowerIdx(e, cmp)
+		if idx < len(n.keys) && cmp(n.keys[idx], e) == 0 {
+			if isMulti {
+				//允许重复,数目+1
+				n.nums[idx]++
+				return true, nil, nil
+			}
+			//不允许重复,对值进行覆盖
+			n.keys[idx] = e
+			return false, nil, nil
+		}
+		n.keys = append(n.keys, nil)
+		copy(n.keys[idx+1:], n.keys[idx:])
+		n.keys[idx] = e
+		n.nums = append(n.nums, 0)
+		copy(n.nums[idx+1:], n.nums[idx:])
+		n.nums[idx] = 1
+		if len(n.keys) <= degree {
+			return true, nil, nil
+		}
+		//叶子节点溢出,将后一半移入新的叶子节点并连接到链表中
+		mid := len(n.keys) / 2
+		right = newLeaf(degree)
+		right.keys = append(right.keys, n.keys[mid:]...)
+		right.nums = append(right.nums, n.nums[mid:]...)
+		for i := mid; i < len(n.keys); i++ {
+			n.keys[i] = nil
+		}
+		n.keys, n.nums = n.keys[:mid], n.nums[:mid]
+		right.prev, right.next = n, n.next
+		if n.next != nil {
+			n.next.prev = right
+		}
+		n.next = right
+		return true, right, right.keys[0]
+	}
+	idx := n.upperIdx(e, cmp)
+	b, son, sonKey := n.children[idx].insert(e, isMulti, degree, cmp)
+	if son == nil {
+		return b, nil, nil
+	}
+	//子节点发生分裂,将分裂出的节点插入到idx之后
+	n.keys = append(n.keys, nil)
+	copy(n.keys[idx+1:], n.keys[idx:])
+	n.keys[idx] = sonKey
+	n.children = append(n.children, nil)
+	copy(n.children[idx+2:], n.children[idx+1:])
+	n.children[idx+1] = son
+	if len(n.children) <= degree {
+		return b, nil, nil
+	}
+	//内部节点溢出,中间的分隔元素上移,其后的子节点移入新的内部节点
+	mid := len(n.children) / 2
+	right = newInternal(degree)
+	key = n.keys[mid-1]
+	right.keys = append(right.keys, n.keys[mid:]...)
+	right.children = append(right.children, n.children[mid:]...)
+	for i := mid - 1; i < len(n.keys); i++ {
+		n.keys[i] = nil
+	}
+	for i := mid; i < len(n.children); i++ {
+		n.children[i] = nil
+	}
+	n.keys, n.children = n.keys[:mid-1], n.children[:mid]
+	return b, right, key
+}
+
+//@title    erase
+//@description
+//		以node B+树节点做接收者
+//		从n节点中删除元素e
+//		如果叶子节点中该元素存在重复值,则将num-1,否则将其从叶子节点中移除
+//		若删除后子节点低于最小容量,则向相邻兄弟节点借用或与之合并
+//@receiver		n			*node					接受者node的指针
+//@param    	e			interface{}				待删除元素
+//@param    	degree		int						节点的最大容量
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	b        	bool					是否删除成功?
+func (n *node) erase(e interface{}, degree int, cmp comparator.Comparator) (b bool) {
+	if n.isLeaf {
+		idx := n.lowerIdx(e, cmp)
+		if idx == len(n.keys) || cmp(n.keys[idx], e) != 0 {
+			return false
+		}
+		if n.nums[idx] > 1 {
+			//有重复值,直接-1即可
+			n.nums[idx]--
+			return true
+		}
+		copy(n.keys[idx:], n.keys[idx+1:])
+		n.keys[len(n.keys)-1] = nil
+		n.keys = n.keys[:len(n.keys)-1]
+		copy(n.nums[idx:], n.nums[idx+1:])
+		n.nums = n.nums[:len(n.nums)-1]
+		return true
+	}
+	idx := n.upperIdx(e, cmp)
+	if b = n.children[idx].erase(e, degree, cmp); b && n.children[idx].getSize() < degree/2 {
+		n.rebalance(idx, degree)
+	}
+	return b
+}
+
+//@title    rebalance
+//@description
+//		以node B+树内部节点做接收者
+//		对低于最小容量的第idx个子节点进行调整
+//		若相邻兄弟节点有富余则从中借用一个元素或子节点,并更新对应的分隔元素
+//		否则将该子节点与一个相邻兄弟节点合并,并移除二者之间的分隔元素
+//@receiver		n			*node					接受者node的指针
+//@param    	idx			int						需要调整的子节点下标
+//@param    	degree		int						节点的最大容量
+//@return    	nil
+func (n *node) rebalance(idx int, degree int) {
+	son := n.children[idx]
+	if idx > 0 && n.children[idx-1].getSize() > degree/2 {
+		//从左兄弟借用其最后一个元素或子节点
+		left := n.children[idx-1]
+		if son.isLeaf {
+			last := len(left.keys) - 1
+			son.keys = append([]interface{}{left.keys[last]}, son.keys...)
+			son.nums = append([]int{left.nums[last]}, son.nums...)
+			left.keys[last] = nil
+			left.keys, left.nums = left.keys[:last], left.nums[:last]
+			n.keys[idx-1] = son.keys[0]
+		} else {
+			last := len(left.children) - 1
+			son.keys = append([]interface{}{n.keys[idx-1]}, son.keys...)
+			son.children = append([]*node{left.children[last]}, son.children...)
+			n.keys[idx-1] = left.keys[last-1]
+			left.keys[last-1], left.children[last] = nil, nil
+			left.keys, left.children = left.keys[:last-1], left.children[:last]
+		}
+		return
+	}
+	if idx+1 < len(n.children) && n.children[idx+1].getSize() > degree/2 {
+		//从右兄弟借用其第一个元素或子节点
+		right := n.children[idx+1]
+		if son.isLeaf {
+			son.keys = append(son.keys, right.keys[0])
+			son.nums = append(son.nums, right.nums[0])
+			copy(right.keys, right.keys[1:])
+			right.keys[len(right.keys)-1] = nil
+			right.keys = right.keys[:len(right.keys)-1]
+			copy(right.nums, right.nums[1:])
+			right.nums = right.nums[:len(right.nums)-1]
+			n.keys[idx] = right.keys[0]
+		} else {
+			son.keys = append(son.keys, n.keys[idx])
+			son.children = append(son.children, right.children[0])
+			n.keys[idx] = right.keys[0]
+			copy(right.keys, right.keys[1:])
+			right.keys[len(right.keys)-1] = nil
+			right.keys = right.keys[:len(right.keys)-1]
+			copy(right.children, right.children[1:])
+			right.children[len(right.children)-1] = nil
+			right.children = right.children[:len(right.children)-1]
+		}
+		return
+	}
+	//兄弟节点均无富余,与相邻兄弟合并
+	if idx > 0 {
+		idx--
+	}
+	left, right := n.children[idx], n.children[idx+1]
+	if left.isLeaf {
+		left.keys = append(left.keys, right.keys...)
+		left.nums = append(left.nums, right.nums...)
+		left.next = right.next
+		if right.next != nil {
+			right.next.prev = left
+		}
+	} else {
+		left.keys = append(left.keys, n.keys[idx])
+		left.keys = append(left.keys, right.keys...)
+		left.children = append(left.children, right.children...)
+	}
+	copy(n.keys[idx:], n.keys[idx+1:])
+	n.keys[len(n.keys)-1] = nil
+	n.keys = n.keys[:len(n.keys)-1]
+	copy(n.children[idx+1:], n.children[idx+2:])
+	n.children[len(n.children)-1] = nil
+	n.children = n.children[:len(n.children)-1]
+}
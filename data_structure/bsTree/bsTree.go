@@ -12,6 +12,7 @@ package bsTree
 
 import (
 	"github.com/hlccd/goSTL/utils/comparator"
+	"github.com/hlccd/goSTL/utils/cursor"
 	"github.com/hlccd/goSTL/utils/iterator"
 	"sync"
 )
@@ -33,13 +34,16 @@ type BsTree struct {
 //存放了bsTree二叉搜索树可使用的函数
 //对应函数介绍见下方
 type bsTreeer interface {
-	Iterator() (i *Iterator.Iterator) //返回包含该二叉树的所有元素,重复则返回多个
-	Size() (num uint64)               //返回该二叉树中保存的元素个数
-	Clear()                           //清空该二叉树
-	Empty() (b bool)                  //判断该二叉树是否为空
-	Insert(e interface{})             //向二叉树中插入元素e
-	Erase(e interface{})              //从二叉树中删除元素e
-	Count(e interface{}) (num uint64) //从二叉树中寻找元素e并返回其个数
+	Iterator() (i *Iterator.Iterator)                                             //返回包含该二叉树的所有元素,重复则返回多个
+	Size() (num uint64)                                                           //返回该二叉树中保存的元素个数
+	Clear()                                                                       //清空该二叉树
+	Empty() (b bool)                                                              //判断该二叉树是否为空
+	Insert(e interface{})                                                         //向二叉树中插入元素e
+	Erase(e interface{})                                                          //从二叉树中删除元素e
+	Count(e interface{}) (num uint64)                                             //从二叉树中寻找元素e并返回其个数
+	LowerBound(e interface{}) (c *Cursor)                                         //返回指向不小于e的最小元素的游标
+	UpperBound(e interface{}) (c *Cursor)                                         //返回指向大于e的最小元素的游标
+	RangeIterator(lo, hi interface{}, isLoIn, isHiIn bool) (i *Iterator.Iterator) //返回包含区间内所有元素的迭代器
 }
 
 //@title    New
@@ -237,3 +241,78 @@ func (bs *BsTree) Count(e interface{}) (num uint64) {
 	bs.mutex.Unlock()
 	return num
 }
+
+//@title    LowerBound
+//@description
+//		以bsTree二叉搜索树做接收者
+//		返回指向二叉搜索树中不小于元素e的最小元素的游标
+//		若存在重复元素则指向其中第一个
+//		若不存在则返回一个无效游标
+//		查找的时间复杂度为O(h),h为树高,创建游标后对二叉搜索树进行增删将使游标失效
+//@receiver		bs			*BsTree					接受者bsTree的指针
+//@param    	e			interface{}				待查找元素
+//@return    	c			*Cursor					指向下界的游标
+func (bs *BsTree) LowerBound(e interface{}) (c *Cursor) {
+	if bs == nil {
+		return nil
+	}
+	bs.mutex.Lock()
+	var path []cursor.Node
+	if bs.root != nil && bs.cmp != nil {
+		path = bs.root.lowerBound(e, bs.cmp)
+	}
+	c = cursor.New(&bs.mutex, path)
+	bs.mutex.Unlock()
+	return c
+}
+
+//@title    UpperBound
+//@description
+//		以bsTree二叉搜索树做接收者
+//		返回指向二叉搜索树中大于元素e的最小元素的游标
+//		若存在重复元素则指向其中第一个,LowerBound(lo)到UpperBound(hi)之前的元素即为[lo,hi]区间内的元素
+//		若不存在则返回一个无效游标
+//		查找的时间复杂度为O(h),h为树高,创建游标后对二叉搜索树进行增删将使游标失效
+//@receiver		bs			*BsTree					接受者bsTree的指针
+//@param    	e			interface{}				待查找元素
+//@return    	c			*Cursor					指向上界的游标
+func (bs *BsTree) UpperBound(e interface{}) (c *Cursor) {
+	if bs == nil {
+		return nil
+	}
+	bs.mutex.Lock()
+	var path []cursor.Node
+	if bs.root != nil && bs.cmp != nil {
+		path = bs.root.upperBound(e, bs.cmp)
+	}
+	c = cursor.New(&bs.mutex, path)
+	bs.mutex.Unlock()
+	return c
+}
+
+//@title    RangeIterator
+//@description
+//		以bsTree二叉搜索树做接收者
+//		将二叉搜索树中处于区间内的元素以中缀序列的形式放入迭代器中
+//		lo或hi为nil时表示该侧无界,isLoIn和isHiIn表示区间是否包含对应端点
+//		若允许重复存储则对于重复元素进行多次放入
+//		仅访问区间内的节点及其查找路径,时间复杂度为O(h + k),h为树高,迭代器可双向移动
+//@receiver		bs			*BsTree					接受者bsTree的指针
+//@param    	lo			interface{}				区间下界
+//@param    	hi			interface{}				区间上界
+//@param    	isLoIn		bool					是否包含下界?
+//@param    	isHiIn		bool					是否包含上界?
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (bs *BsTree) RangeIterator(lo, hi interface{}, isLoIn, isHiIn bool) (i *Iterator.Iterator) {
+	if bs == nil {
+		return nil
+	}
+	bs.mutex.Lock()
+	es := make([]interface{}, 0)
+	if bs.root != nil && bs.cmp != nil {
+		bs.root.rangeOrder(lo, hi, isLoIn, isHiIn, bs.cmp, &es)
+	}
+	i = Iterator.New(&es)
+	bs.mutex.Unlock()
+	return i
+}
@@ -6,7 +6,10 @@ package bsTree
 //		可通过节点实现二叉搜索树的添加删除
 //		也可通过节点返回整个二叉搜索树的所有元素
 
-import "github.com/hlccd/goSTL/utils/comparator"
+import (
+	"github.com/hlccd/goSTL/utils/comparator"
+	"github.com/hlccd/goSTL/utils/cursor"
+)
 
 //node树节点结构体
 //该节点是二叉搜索树的树节点
@@ -228,3 +231,91 @@ func (n *node) search(e interface{}, isMulti bool, cmp comparator.Comparator) (n
 	//n中承载元素等于e,直接返回结果
 	return n.num
 }
+
+//@title    lowerBound
+//@description
+//		以node二叉搜索树节点做接收者
+//		从n节点开始寻找承载不小于元素e的最小元素的节点
+//		返回从n节点到该节点的路径,若不存在则返回空路径
+//@receiver		n			*node					接受者node的指针
+//@param    	e			interface{}				待查找元素
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	path        []cursor.Node				从n节点到目标节点的路径
+func (n *node) lowerBound(e interface{}, cmp comparator.Comparator) (path []cursor.Node) {
+	depth := 0
+	for n != nil {
+		path = append(path, n)
+		if cmp(n.value, e) >= 0 {
+			//n满足条件,继续从左子树寻找更小的满足条件的节点
+			depth = len(path)
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return path[:depth]
+}
+
+//@title    upperBound
+//@description
+//		以node二叉搜索树节点做接收者
+//		从n节点开始寻找承载大于元素e的最小元素的节点
+//		返回从n节点到该节点的路径,若不存在则返回空路径
+//@receiver		n			*node					接受者node的指针
+//@param    	e			interface{}				待查找元素
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	path        []cursor.Node				从n节点到目标节点的路径
+func (n *node) upperBound(e interface{}, cmp comparator.Comparator) (path []cursor.Node) {
+	depth := 0
+	for n != nil {
+		path = append(path, n)
+		if cmp(n.value, e) > 0 {
+			//n满足条件,继续从左子树寻找更小的满足条件的节点
+			depth = len(path)
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return path[:depth]
+}
+
+//@title    rangeOrder
+//@description
+//		以node二叉搜索树节点做接收者
+//		以中缀序列将处于区间内的元素放入es中
+//		lo或hi为nil时表示该侧无界,isLoIn和isHiIn表示区间是否包含对应端点
+//		仅当子树中可能存在区间内的元素时才进入该子树,时间复杂度为O(h + k),h为树高
+//@receiver		n			*node					接受者node的指针
+//@param    	lo			interface{}				区间下界
+//@param    	hi			interface{}				区间上界
+//@param    	isLoIn		bool					是否包含下界?
+//@param    	isHiIn		bool					是否包含上界?
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@param    	es			*[]interface{}			承载结果的切片指针
+//@return    	nil
+func (n *node) rangeOrder(lo, hi interface{}, isLoIn, isHiIn bool, cmp comparator.Comparator, es *[]interface{}) {
+	if n == nil {
+		return
+	}
+	cl, ch := 1, -1
+	if lo != nil {
+		cl = cmp(n.value, lo)
+	}
+	if hi != nil {
+		ch = cmp(n.value, hi)
+	}
+	if cl > 0 {
+		//n大于下界,左子树中可能存在区间内的元素
+		n.left.rangeOrder(lo, hi, isLoIn, isHiIn, cmp, es)
+	}
+	if (cl > 0 || (cl == 0 && isLoIn)) && (ch < 0 || (ch == 0 && isHiIn)) {
+		for i := uint64(0); i < n.num; i++ {
+			*es = append(*es, n.value)
+		}
+	}
+	if ch < 0 {
+		//n小于上界,右子树中可能存在区间内的元素
+		n.right.rangeOrder(lo, hi, isLoIn, isHiIn, cmp, es)
+	}
+}
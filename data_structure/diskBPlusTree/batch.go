@@ -0,0 +1,491 @@
+package diskBPlusTree
+
+//@Title		diskBPlusTree
+//@Description
+//		磁盘B+树的批量修改
+//		一个Batch即一次写事务,事务内的修改以写时复制的方式进行
+//		已提交的页在首次修改时复制到新的页号上,原页号在提交后才会被回收
+//		事务内新建的页可直接修改,事务内新建又被释放的页可在事务内立即复用
+//		提交时先写入全部新页并落盘,再写入元信息,任一步骤失败都不会影响已提交的版本
+import (
+	"sort"
+	"strconv"
+)
+
+//Batch批量修改结构体
+//该实例保存事务号、事务内的根页号和键数量以及事务内新建的页
+//同时记录事务中从空闲页取出的页号和释放的页号,以便提交或回滚时进行归还
+type Batch struct {
+	tree      *DiskBPlusTree   //所属的磁盘B+树
+	txid      uint64           //事务号
+	root      uint64           //事务内的根页号
+	count     uint64           //事务内的键数量
+	pageCount uint64           //事务内使用的页数
+	dirty     map[uint64]*page //事务内新建的页
+	reuse     []uint64         //事务内新建后又被释放的页号
+	taken     []uint64         //从空闲页中取出的页号
+	released  []uint64         //事务中释放的已提交页号
+	err       error            //事务中出现的错误
+	done      bool             //事务是否已结束
+}
+
+//Batch批量修改接口
+//存放了Batch批量修改可使用的函数
+//对应函数介绍见下方
+type batcher interface {
+	Get(key []byte) (value []byte, err error) //从事务内的版本中获取key对应的值
+	Put(key, value []byte) (err error)        //在事务内写入键值对
+	Delete(key []byte) (err error)            //在事务内删除key
+}
+
+//@title    newBatch
+//@description
+//		以磁盘B+树当前已提交的版本为基础新建一个事务并返回
+//		事务号为已提交版本的事务号+1
+//@receiver		nil
+//@param    	t			*DiskBPlusTree			所属的磁盘B+树
+//@return    	b        	*Batch					新建的事务指针
+func newBatch(t *DiskBPlusTree) (b *Batch) {
+	return &Batch{
+		tree:      t,
+		txid:      t.meta.txid + 1,
+		root:      t.meta.root,
+		count:     t.meta.count,
+		pageCount: t.pager.pageCount,
+		dirty:     make(map[uint64]*page),
+	}
+}
+
+//@title    get
+//@description
+//		以Batch批量修改做接收者
+//		读取事务内版本中页号为id的页,事务内新建的页优先
+//@receiver		b			*Batch					接受者Batch的指针
+//@param    	id			uint64					页号
+//@return    	pg        	*page					读取到的页
+//@return    	err        	error					读取失败时的错误
+func (b *Batch) get(id uint64) (pg *page, err error) {
+	if pg, ok := b.dirty[id]; ok {
+		return pg, nil
+	}
+	return b.tree.pager.read(id)
+}
+
+//@title    alloc
+//@description
+//		以Batch批量修改做接收者
+//		分配一个可供事务写入的页号
+//		依次从事务内释放的页、空闲页中获取,均没有时在文件末尾追加
+//@receiver		b			*Batch					接受者Batch的指针
+//@param    	nil
+//@return    	id        	uint64					分配的页号
+func (b *Batch) alloc() (id uint64) {
+	if n := len(b.reuse); n > 0 {
+		id, b.reuse = b.reuse[n-1], b.reuse[:n-1]
+		return id
+	}
+	p := b.tree.pager
+	if n := len(p.free); n > 0 {
+		id, p.free = p.free[n-1], p.free[:n-1]
+		b.taken = append(b.taken, id)
+		return id
+	}
+	id = b.pageCount
+	b.pageCount++
+	return id
+}
+
+//@title    newPage
+//@description
+//		以Batch批量修改做接收者
+//		分配页号并新建一个属于该事务的空页
+//@receiver		b			*Batch					接受者Batch的指针
+//@param    	isLeaf		bool					是否为叶子页?
+//@return    	pg        	*page					新建的页
+func (b *Batch) newPage(isLeaf bool) (pg *page) {
+	pg = &page{id: b.alloc(), txid: b.txid, isLeaf: isLeaf}
+	b.dirty[pg.id] = pg
+	return pg
+}
+
+//@title    release
+//@description
+//		以Batch批量修改做接收者
+//		释放一个页
+//		事务内新建的页可立即复用,已提交的页需在事务提交后才可复用
+//@receiver		b			*Batch					接受者Batch的指针
+//@param    	pg			*page					待释放的页
+//@return    	nil
+func (b *Batch) release(pg *page) {
+	if pg.txid == b.txid {
+		delete(b.dirty, pg.id)
+		b.reuse = append(b.reuse, pg.id)
+		return
+	}
+	b.released = append(b.released, pg.id)
+}
+
+//@title    writable
+//@description
+//		以Batch批量修改做接收者
+//		返回一个可在事务内直接修改的页
+//		若页由该事务新建则直接返回,否则将其复制到新的页号上并释放原页
+//@receiver		b			*Batch					接受者Batch的指针
+//@param    	pg			*page					待修改的页
+//@return    	cp        	*page					可直接修改的页
+func (b *Batch) writable(pg *page) (cp *page) {
+	if pg.txid == b.txid {
+		return pg
+	}
+	cp = pg.clone(b.alloc(), b.txid)
+	b.dirty[cp.id] = cp
+	b.release(pg)
+	return cp
+}
+
+//@title    check
+//@description
+//		以Batch批量修改做接收者
+//		判断该事务是否仍可使用
+//		事务已结束时返回ErrClosed,事务中出现过错误时返回该错误
+//@receiver		b			*Batch					接受者Batch的指针
+//@param    	nil
+//@return    	err        	error					事务不可用的原因
+func (b *Batch) check() (err error) {
+	if b == nil || b.done {
+		return ErrClosed
+	}
+	return b.err
+}
+
+//@title    Get
+//@description
+//		以Batch批量修改做接收者
+//		从事务内的版本中获取key对应的值,可以读到该事务中尚未提交的修改
+//		key不存在时返回ErrNotFound
+//@receiver		b			*Batch					接受者Batch的指针
+//@param    	key			[]byte					待查找的键
+//@return    	value		[]byte					key对应的值
+//@return    	err			error					查找失败时的错误
+func (b *Batch) Get(key []byte) (value []byte, err error) {
+	if err = b.check(); err != nil {
+		return nil, err
+	}
+	return b.tree.search(b.root, key, b.get)
+}
+
+//@title    Put
+//@description
+//		以Batch批量修改做接收者
+//		在事务内写入键值对,key已存在时覆盖其值
+//		键和值会被复制,调用后修改传入的切片不影响树中的内容
+//		键值对过大以至于无法保证页的分裂时返回ErrTooLarge
+//@receiver		b			*Batch					接受者Batch的指针
+//@param    	key			[]byte					待写入的键
+//@param    	value		[]byte					待写入的值
+//@return    	err			error					写入失败时的错误
+func (b *Batch) Put(key, value []byte) (err error) {
+	if err = b.check(); err != nil {
+		return err
+	}
+	if 4+len(key)+len(value) > b.tree.pager.pageSize/4 {
+		return ErrTooLarge
+	}
+	key, value = append([]byte{}, key...), append([]byte{}, value...)
+	if b.root == 0 {
+		b.root = b.newPage(true).id
+	}
+	root, sep, right, ok, err := b.insert(b.root, key, value)
+	if err != nil {
+		b.err = err
+		return err
+	}
+	if right != nil {
+		//根页分裂,新建根页
+		pg := b.newPage(false)
+		pg.keys = append(pg.keys, sep)
+		pg.children = append(pg.children, root, right.id)
+		root = pg.id
+	}
+	b.root = root
+	if ok {
+		b.count++
+	}
+	return nil
+}
+
+//@title    insert
+//@description
+//		以Batch批量修改做接收者
+//		向页号为id的子树中写入键值对,并返回修改后的子树根页号
+//		沿查找路径复制页,若页写入后超出页大小则按字节数分裂,并返回分隔键和分裂出的页供上层插入
+//@receiver		b			*Batch					接受者Batch的指针
+//@param    	id			uint64					子树根页号
+//@param    	key			[]byte					待写入的键
+//@param    	value		[]byte					待写入的值
+//@return    	nid			uint64					修改后的子树根页号
+//@return    	sep			[]byte					分裂时上移的分隔键
+//@return    	right		*page					分裂出的页,未分裂时为nil
+//@return    	ok			bool					是否新增了键?
+//@return    	err			error					读取失败时的错误
+func (b *Batch) insert(id uint64, key, value []byte) (nid uint64, sep []byte, right *page, ok bool, err error) {
+	pg, err := b.get(id)
+	if err != nil {
+		return 0, nil, nil, false, err
+	}
+	cmp := b.tree.cmp
+	if pg.isLeaf {
+		idx := pg.lowerIdx(key, cmp)
+		pg = b.writable(pg)
+		if idx < len(pg.keys) && cmp(pg.keys[idx], key) == 0 {
+			pg.values[idx] = value
+		} else {
+			pg.keys = append(pg.keys, nil)
+			copy(pg.keys[idx+1:], pg.keys[idx:])
+			pg.keys[idx] = key
+			pg.values = append(pg.values, nil)
+			copy(pg.values[idx+1:], pg.values[idx:])
+			pg.values[idx] = value
+			ok = true
+		}
+	} else {
+		idx := pg.upperIdx(key, cmp)
+		cid, csep, cright, cok, err := b.insert(pg.children[idx], key, value)
+		if err != nil {
+			return 0, nil, nil, false, err
+		}
+		ok = cok
+		pg = b.writable(pg)
+		pg.children[idx] = cid
+		if cright != nil {
+			//子页分裂,将分裂出的页插入到idx之后
+			pg.keys = append(pg.keys, nil)
+			copy(pg.keys[idx+1:], pg.keys[idx:])
+			pg.keys[idx] = csep
+			pg.children = append(pg.children, 0)
+			copy(pg.children[idx+2:], pg.children[idx+1:])
+			pg.children[idx+1] = cright.id
+		}
+	}
+	if pg.Len() > b.tree.pager.pageSize {
+		sep, right = pg.split(b.alloc(), b.txid)
+		b.dirty[right.id] = right
+	}
+	return pg.id, sep, right, ok, nil
+}
+
+//@title    Delete
+//@description
+//		以Batch批量修改做接收者
+//		在事务内删除key
+//		根页仅剩一个子页时以子页作为新的根页,树为空时根页号置为0
+//		key不存在时返回ErrNotFound
+//@receiver		b			*Batch					接受者Batch的指针
+//@param    	key			[]byte					待删除的键
+//@return    	err			error					删除失败时的错误
+func (b *Batch) Delete(key []byte) (err error) {
+	if err = b.check(); err != nil {
+		return err
+	}
+	if b.root == 0 {
+		return ErrNotFound
+	}
+	root, ok, err := b.remove(b.root, key)
+	if err != nil {
+		b.err = err
+		return err
+	}
+	if !ok {
+		return ErrNotFound
+	}
+	for root != 0 {
+		pg, err := b.get(root)
+		if err != nil {
+			b.err = err
+			return err
+		}
+		if !pg.isLeaf && len(pg.children) == 1 {
+			b.release(pg)
+			root = pg.children[0]
+		} else if pg.isLeaf && len(pg.keys) == 0 {
+			b.release(pg)
+			root = 0
+		} else {
+			break
+		}
+	}
+	b.root = root
+	b.count--
+	return nil
+}
+
+//@title    remove
+//@description
+//		以Batch批量修改做接收者
+//		从页号为id的子树中删除key,并返回修改后的子树根页号
+//		key不存在时不复制任何页
+//		子页删除后字节数低于页大小的四分之一时,与相邻兄弟页进行合并或重新分配
+//@receiver		b			*Batch					接受者Batch的指针
+//@param    	id			uint64					子树根页号
+//@param    	key			[]byte					待删除的键
+//@return    	nid			uint64					修改后的子树根页号
+//@return    	ok			bool					是否删除成功?
+//@return    	err			error					读取失败时的错误
+func (b *Batch) remove(id uint64, key []byte) (nid uint64, ok bool, err error) {
+	pg, err := b.get(id)
+	if err != nil {
+		return 0, false, err
+	}
+	cmp := b.tree.cmp
+	if pg.isLeaf {
+		idx := pg.lowerIdx(key, cmp)
+		if idx == len(pg.keys) || cmp(pg.keys[idx], key) != 0 {
+			return id, false, nil
+		}
+		pg = b.writable(pg)
+		copy(pg.keys[idx:], pg.keys[idx+1:])
+		pg.keys = pg.keys[:len(pg.keys)-1]
+		copy(pg.values[idx:], pg.values[idx+1:])
+		pg.values = pg.values[:len(pg.values)-1]
+		return pg.id, true, nil
+	}
+	idx := pg.upperIdx(key, cmp)
+	cid, ok, err := b.remove(pg.children[idx], key)
+	if err != nil || !ok {
+		return id, false, err
+	}
+	pg = b.writable(pg)
+	pg.children[idx] = cid
+	son, err := b.get(cid)
+	if err != nil {
+		return 0, false, err
+	}
+	if son.Len() < b.tree.pager.pageSize/4 && len(pg.children) > 1 {
+		if err = b.rebalance(pg, idx); err != nil {
+			return 0, false, err
+		}
+	}
+	return pg.id, true, nil
+}
+
+//@title    rebalance
+//@description
+//		以Batch批量修改做接收者
+//		将内部页pg的第idx个子页与其相邻兄弟页合并
+//		若合并后超出页大小,则重新按字节数分裂为两页并更新分隔键
+//		调用前需保证pg可在事务内直接修改
+//@receiver		b			*Batch					接受者Batch的指针
+//@param    	pg			*page					父页
+//@param    	idx			int						需要调整的子页下标
+//@return    	err			error					读取失败时的错误
+func (b *Batch) rebalance(pg *page, idx int) (err error) {
+	if idx == len(pg.children)-1 {
+		idx--
+	}
+	left, err := b.get(pg.children[idx])
+	if err != nil {
+		return err
+	}
+	right, err := b.get(pg.children[idx+1])
+	if err != nil {
+		return err
+	}
+	left = b.writable(left)
+	pg.children[idx] = left.id
+	if left.isLeaf {
+		left.keys = append(left.keys, right.keys...)
+		left.values = append(left.values, right.values...)
+	} else {
+		left.keys = append(left.keys, pg.keys[idx])
+		left.keys = append(left.keys, right.keys...)
+		left.children = append(left.children, right.children...)
+	}
+	b.release(right)
+	if left.Len() <= b.tree.pager.pageSize {
+		//合并后未超出页大小,移除右页及分隔键
+		copy(pg.keys[idx:], pg.keys[idx+1:])
+		pg.keys = pg.keys[:len(pg.keys)-1]
+		copy(pg.children[idx+1:], pg.children[idx+2:])
+		pg.children = pg.children[:len(pg.children)-1]
+		return nil
+	}
+	sep, nr := left.split(b.alloc(), b.txid)
+	b.dirty[nr.id] = nr
+	pg.keys[idx] = sep
+	pg.children[idx+1] = nr.id
+	return nil
+}
+
+//@title    commit
+//@description
+//		以Batch批量修改做接收者
+//		提交该事务
+//		按页号顺序写入事务内新建的页并落盘,随后写入另一份元信息并落盘
+//		提交成功后事务中释放的页成为空闲页,新写入的页放入页缓存
+//		任一步骤失败时回滚该事务,已提交的版本不受影响
+//@receiver		b			*Batch					接受者Batch的指针
+//@param    	nil
+//@return    	err			error					提交失败时的错误
+func (b *Batch) commit() (err error) {
+	if err = b.check(); err != nil {
+		b.rollback()
+		return err
+	}
+	t, p := b.tree, b.tree.pager
+	if len(b.dirty) == 0 && b.root == t.meta.root {
+		//没有任何修改,归还取出的空闲页即可
+		b.rollback()
+		return nil
+	}
+	b.done = true
+	ids := make([]uint64, 0, len(b.dirty))
+	for id := range b.dirty {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		if err = p.write(b.dirty[id]); err != nil {
+			b.rollback()
+			return err
+		}
+	}
+	if err = p.file.Sync(); err != nil {
+		b.rollback()
+		return err
+	}
+	m := &meta{
+		pageSize:  uint32(p.pageSize),
+		root:      b.root,
+		pageCount: b.pageCount,
+		txid:      b.txid,
+		count:     b.count,
+	}
+	if err = p.writeMeta(m); err != nil {
+		b.rollback()
+		return err
+	}
+	t.meta, p.pageCount = m, b.pageCount
+	for _, id := range b.released {
+		p.cache.Erase(strconv.FormatUint(id, 10))
+	}
+	p.free = append(p.free, b.reuse...)
+	p.free = append(p.free, b.released...)
+	for _, id := range ids {
+		p.cache.Insert(strconv.FormatUint(id, 10), b.dirty[id])
+	}
+	return nil
+}
+
+//@title    rollback
+//@description
+//		以Batch批量修改做接收者
+//		回滚该事务,丢弃事务内新建的页并归还从空闲页中取出的页号
+//@receiver		b			*Batch					接受者Batch的指针
+//@param    	nil
+//@return    	nil
+func (b *Batch) rollback() {
+	b.done = true
+	p := b.tree.pager
+	p.free = append(p.free, b.taken...)
+	b.dirty, b.taken, b.reuse, b.released = nil, nil, nil, nil
+}
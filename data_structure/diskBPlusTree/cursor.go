@@ -0,0 +1,372 @@
+package diskBPlusTree
+
+//@Title		diskBPlusTree
+//@Description
+//		磁盘B+树的游标
+//		由于页以写时复制的方式修改,叶子页之间不保存相互的链接
+//		游标保存从根页到当前叶子页的路径,从而可以双向移动
+//		游标创建后若有新的提交,原版本的页可能被复用,此时游标失效并通过Err返回ErrCursorInvalid
+
+//frame游标路径中的一层
+//pg为该层的页,idx为叶子页中的键下标或内部页中的子页下标
+type frame struct {
+	pg  *page //该层的页
+	idx int   //该层的下标
+}
+
+//Cursor游标结构体
+//该游标指向磁盘B+树中的某一个键值对
+//当游标移出范围或出现错误后即失效,此后无法再移动
+type Cursor struct {
+	tree  *DiskBPlusTree //游标所属的磁盘B+树
+	txid  uint64         //创建游标时已提交版本的事务号
+	stack []frame        //从根页到当前叶子页的路径
+	err   error          //游标出现的错误
+}
+
+//Cursor游标接口
+//存放了Cursor游标可使用的函数
+//对应函数介绍见下方
+type cursorer interface {
+	Valid() (b bool)       //判断该游标是否指向有效的键值对
+	Key() (key []byte)     //返回该游标指向的键
+	Value() (value []byte) //返回该游标指向的值
+	Next() (b bool)        //将该游标后移一位
+	Pre() (b bool)         //将该游标前移一位
+	Err() (err error)      //返回该游标出现的错误
+}
+
+//@title    newCursor
+//@description
+//		以diskBPlusTree磁盘B+树做接收者
+//		新建一个基于已提交版本的空游标
+//		树已关闭时游标的错误为ErrClosed
+//		调用前需持有该树的锁
+//@receiver		t			*DiskBPlusTree			接受者diskBPlusTree的指针
+//@param    	nil
+//@return    	c        	*Cursor					新建的游标
+func (t *DiskBPlusTree) newCursor() (c *Cursor) {
+	c = &Cursor{tree: t, txid: t.meta.txid}
+	if t.closed {
+		c.err = ErrClosed
+	}
+	return c
+}
+
+//@title    First
+//@description
+//		以diskBPlusTree磁盘B+树做接收者
+//		返回指向最小键的游标,树为空时返回一个无效游标
+//@receiver		t			*DiskBPlusTree			接受者diskBPlusTree的指针
+//@param    	nil
+//@return    	c        	*Cursor					指向最小键的游标
+func (t *DiskBPlusTree) First() (c *Cursor) {
+	if t == nil {
+		return nil
+	}
+	t.mutex.Lock()
+	c = t.newCursor()
+	if c.err == nil && t.meta.root != 0 {
+		c.descend(t.meta.root, true)
+	}
+	t.mutex.Unlock()
+	return c
+}
+
+//@title    Last
+//@description
+//		以diskBPlusTree磁盘B+树做接收者
+//		返回指向最大键的游标,树为空时返回一个无效游标
+//@receiver		t			*DiskBPlusTree			接受者diskBPlusTree的指针
+//@param    	nil
+//@return    	c        	*Cursor					指向最大键的游标
+func (t *DiskBPlusTree) Last() (c *Cursor) {
+	if t == nil {
+		return nil
+	}
+	t.mutex.Lock()
+	c = t.newCursor()
+	if c.err == nil && t.meta.root != 0 {
+		c.descend(t.meta.root, false)
+	}
+	t.mutex.Unlock()
+	return c
+}
+
+//@title    LowerBound
+//@description
+//		以diskBPlusTree磁盘B+树做接收者
+//		返回指向不小于key的最小键的游标
+//		若不存在则返回一个无效游标
+//@receiver		t			*DiskBPlusTree			接受者diskBPlusTree的指针
+//@param    	key			[]byte					待查找的键
+//@return    	c        	*Cursor					指向下界的游标
+func (t *DiskBPlusTree) LowerBound(key []byte) (c *Cursor) {
+	if t == nil {
+		return nil
+	}
+	t.mutex.Lock()
+	c = t.newCursor()
+	if c.err != nil || t.meta.root == 0 || !c.seek(key) {
+		t.mutex.Unlock()
+		return c
+	}
+	f := &c.stack[len(c.stack)-1]
+	if f.idx = f.pg.lowerIdx(key, t.cmp); f.idx == len(f.pg.keys) {
+		f.idx--
+		c.next()
+	}
+	t.mutex.Unlock()
+	return c
+}
+
+//@title    UpperBound
+//@description
+//		以diskBPlusTree磁盘B+树做接收者
+//		返回指向大于key的最小键的游标
+//		LowerBound(lo)到UpperBound(hi)之前的键即为[lo,hi]区间内的键
+//		若不存在则返回一个无效游标
+//@receiver		t			*DiskBPlusTree			接受者diskBPlusTree的指针
+//@param    	key			[]byte					待查找的键
+//@return    	c        	*Cursor					指向上界的游标
+func (t *DiskBPlusTree) UpperBound(key []byte) (c *Cursor) {
+	if t == nil {
+		return nil
+	}
+	t.mutex.Lock()
+	c = t.newCursor()
+	if c.err != nil || t.meta.root == 0 || !c.seek(key) {
+		t.mutex.Unlock()
+		return c
+	}
+	f := &c.stack[len(c.stack)-1]
+	if f.idx = f.pg.upperIdx(key, t.cmp); f.idx == len(f.pg.keys) {
+		f.idx--
+		c.next()
+	}
+	t.mutex.Unlock()
+	return c
+}
+
+//@title    seek
+//@description
+//		以Cursor游标做接收者
+//		从根页开始向下查找key所在的叶子页,并将路径放入游标
+//		叶子页中的下标由调用者设置
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	key			[]byte					待查找的键
+//@return    	b			bool					查找成功?
+func (c *Cursor) seek(key []byte) (b bool) {
+	for id := c.tree.meta.root; ; {
+		pg, err := c.tree.pager.read(id)
+		if err != nil {
+			c.fail(err)
+			return false
+		}
+		if pg.isLeaf {
+			c.stack = append(c.stack, frame{pg: pg})
+			return true
+		}
+		idx := pg.upperIdx(key, c.tree.cmp)
+		c.stack = append(c.stack, frame{pg: pg, idx: idx})
+		id = pg.children[idx]
+	}
+}
+
+//@title    descend
+//@description
+//		以Cursor游标做接收者
+//		从页号为id的页开始一直向最左侧或最右侧下降到叶子页,并将路径放入游标
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	id			uint64					开始下降的页号
+//@param    	isLeft		bool					是否向最左侧下降?
+//@return    	b			bool					下降成功?
+func (c *Cursor) descend(id uint64, isLeft bool) (b bool) {
+	for {
+		pg, err := c.tree.pager.read(id)
+		if err != nil {
+			c.fail(err)
+			return false
+		}
+		f := frame{pg: pg}
+		if !isLeft {
+			f.idx = len(pg.keys) - 1
+			if !pg.isLeaf {
+				f.idx = len(pg.children) - 1
+			}
+		}
+		c.stack = append(c.stack, f)
+		if pg.isLeaf {
+			return true
+		}
+		id = pg.children[f.idx]
+	}
+}
+
+//@title    fail
+//@description
+//		以Cursor游标做接收者
+//		记录错误并使游标失效
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	err			error					出现的错误
+//@return    	nil
+func (c *Cursor) fail(err error) {
+	c.err, c.stack = err, nil
+}
+
+//@title    next
+//@description
+//		以Cursor游标做接收者
+//		将游标移动到下一个键,到达叶子页末尾时回溯到有后续子页的祖先页后再向最左侧下降
+//		调用前需持有所属树的锁
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					移动后游标仍有效吗?
+func (c *Cursor) next() (b bool) {
+	f := &c.stack[len(c.stack)-1]
+	if f.idx++; f.idx < len(f.pg.keys) {
+		return true
+	}
+	for c.stack = c.stack[:len(c.stack)-1]; len(c.stack) > 0; c.stack = c.stack[:len(c.stack)-1] {
+		f = &c.stack[len(c.stack)-1]
+		if f.idx++; f.idx < len(f.pg.children) {
+			return c.descend(f.pg.children[f.idx], true)
+		}
+	}
+	return false
+}
+
+//@title    pre
+//@description
+//		以Cursor游标做接收者
+//		将游标移动到上一个键,到达叶子页开头时回溯到有前序子页的祖先页后再向最右侧下降
+//		调用前需持有所属树的锁
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					移动后游标仍有效吗?
+func (c *Cursor) pre() (b bool) {
+	f := &c.stack[len(c.stack)-1]
+	if f.idx--; f.idx >= 0 {
+		return true
+	}
+	for c.stack = c.stack[:len(c.stack)-1]; len(c.stack) > 0; c.stack = c.stack[:len(c.stack)-1] {
+		f = &c.stack[len(c.stack)-1]
+		if f.idx--; f.idx >= 0 {
+			return c.descend(f.pg.children[f.idx], false)
+		}
+	}
+	return false
+}
+
+//@title    move
+//@description
+//		以Cursor游标做接收者
+//		加锁后校验游标所基于的版本仍为已提交版本,随后执行移动
+//		版本已改变时游标失效,错误为ErrCursorInvalid
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	step		func() bool				移动函数
+//@return    	b			bool					移动后游标仍有效吗?
+func (c *Cursor) move(step func() bool) (b bool) {
+	if !c.Valid() {
+		return false
+	}
+	c.tree.mutex.Lock()
+	if c.tree.closed {
+		c.fail(ErrClosed)
+		c.tree.mutex.Unlock()
+		return false
+	}
+	if c.tree.meta.txid != c.txid {
+		c.fail(ErrCursorInvalid)
+		c.tree.mutex.Unlock()
+		return false
+	}
+	b = step()
+	c.tree.mutex.Unlock()
+	return b
+}
+
+//@title    Valid
+//@description
+//		以Cursor游标做接收者
+//		判断该游标是否指向有效的键值对
+//		游标不存在、已移出范围或出现错误时返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					该游标有效吗?
+func (c *Cursor) Valid() (b bool) {
+	if c == nil {
+		return false
+	}
+	return len(c.stack) > 0
+}
+
+//@title    Key
+//@description
+//		以Cursor游标做接收者
+//		返回该游标当前指向的键的副本
+//		若游标无效则返回nil
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	key			[]byte					游标指向的键
+func (c *Cursor) Key() (key []byte) {
+	if !c.Valid() {
+		return nil
+	}
+	f := c.stack[len(c.stack)-1]
+	return append([]byte{}, f.pg.keys[f.idx]...)
+}
+
+//@title    Value
+//@description
+//		以Cursor游标做接收者
+//		返回该游标当前指向的值的副本
+//		若游标无效则返回nil
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	value		[]byte					游标指向的值
+func (c *Cursor) Value() (value []byte) {
+	if !c.Valid() {
+		return nil
+	}
+	f := c.stack[len(c.stack)-1]
+	return append([]byte{}, f.pg.values[f.idx]...)
+}
+
+//@title    Next
+//@description
+//		以Cursor游标做接收者
+//		将该游标移动到下一个键
+//		移出范围后游标失效并返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					移动后游标仍有效吗?
+func (c *Cursor) Next() (b bool) {
+	return c.move(c.next)
+}
+
+//@title    Pre
+//@description
+//		以Cursor游标做接收者
+//		将该游标移动到上一个键
+//		移出范围后游标失效并返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					移动后游标仍有效吗?
+func (c *Cursor) Pre() (b bool) {
+	return c.move(c.pre)
+}
+
+//@title    Err
+//@description
+//		以Cursor游标做接收者
+//		返回该游标出现的错误,正常移出范围时返回nil
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	err			error					游标出现的错误
+func (c *Cursor) Err() (err error) {
+	if c == nil {
+		return nil
+	}
+	return c.err
+}
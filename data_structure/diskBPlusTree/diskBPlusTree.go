@@ -0,0 +1,364 @@
+package diskBPlusTree
+
+//@Title		diskBPlusTree
+//@Description
+//		磁盘B+树-Disk-backed B Plus Tree
+//		以单个文件存储的有序键值索引,文件由固定大小的页组成
+//		第0页和第1页为元信息页,其余页为B+树的节点
+//		键和值均为字节切片,键的顺序由比较器决定,比较器的参数为[]byte,默认按字节序比较
+//		修改以写时复制的方式进行,新版本的页写入空闲位置后再切换元信息,因此崩溃后总能恢复到最后一次成功提交的版本
+//		已提交的页通过基于lru的页缓存读取,缓存中的页不会被修改,淘汰时无需写回
+//		每次Put和Delete都是一次独立提交,批量修改可通过Update在一次提交中完成
+//		使用互斥锁实现并发控制
+import (
+	"bytes"
+	"errors"
+	"github.com/hlccd/goSTL/utils/comparator"
+	"os"
+	"sync"
+)
+
+const (
+	defaultPageSize   = 4096    //默认页大小
+	minPageSize       = 256     //最小页大小
+	maxPageSize       = 65536   //最大页大小
+	defaultCacheBytes = 4 << 20 //默认页缓存容量
+)
+
+var (
+	ErrNotFound      = errors.New("diskBPlusTree: key not found")                   //键不存在
+	ErrTooLarge      = errors.New("diskBPlusTree: key/value too large for page")    //键值对过大
+	ErrCorrupted     = errors.New("diskBPlusTree: file corrupted")                  //文件已损坏
+	ErrClosed        = errors.New("diskBPlusTree: tree or batch closed")            //树或事务已关闭
+	ErrPageSize      = errors.New("diskBPlusTree: invalid or mismatched page size") //页大小不合法或与文件不符
+	ErrCursorInvalid = errors.New("diskBPlusTree: cursor invalidated by a commit")  //游标因提交而失效
+)
+
+//diskBPlusTree磁盘B+树结构体
+//该实例保存页管理器和已提交版本的元信息
+//树中排序使用的比较器在打开时传入,不传入时按字节序比较
+//同一文件每次打开时应使用相同的比较器
+type DiskBPlusTree struct {
+	pager  *pager                //页管理器
+	meta   *meta                 //已提交版本的元信息
+	cmp    comparator.Comparator //比较器
+	closed bool                  //是否已关闭
+	mutex  sync.Mutex            //并发控制锁
+}
+
+//diskBPlusTree磁盘B+树容器接口
+//存放了diskBPlusTree磁盘B+树可使用的函数
+//对应函数介绍见下方
+type diskBPlusTreer interface {
+	Close() (err error)                         //关闭该树
+	Size() (num int)                            //返回该树中保存的键的个数
+	Empty() (b bool)                            //判断该树是否为空
+	Get(key []byte) (value []byte, err error)   //获取key对应的值
+	Put(key, value []byte) (err error)          //写入键值对并提交
+	Delete(key []byte) (err error)              //删除key并提交
+	Update(fn func(b *Batch) error) (err error) //在一次提交中完成fn中的全部修改
+	First() (c *Cursor)                         //返回指向最小键的游标
+	Last() (c *Cursor)                          //返回指向最大键的游标
+	LowerBound(key []byte) (c *Cursor)          //返回指向不小于key的最小键的游标
+	UpperBound(key []byte) (c *Cursor)          //返回指向大于key的最小键的游标
+}
+
+//@title    bytesCmp
+//@description
+//		默认比较器,按字节序比较两个[]byte
+//@receiver		nil
+//@param    	a			interface{}				待比较的键
+//@param    	b			interface{}				待比较的键
+//@return    	num        	int						a<b返回-1,a=b返回0,a>b返回1
+func bytesCmp(a, b interface{}) (num int) {
+	return bytes.Compare(a.([]byte), b.([]byte))
+}
+
+//@title    Open
+//@description
+//		打开path处的磁盘B+树文件并返回,文件不存在时新建
+//		新建文件时以pageSize作为页大小,为0时使用默认值4096,需为[256,65536]内的2的幂
+//		打开已有文件时使用文件记录的页大小,pageSize不为0且与之不同时返回ErrPageSize
+//		两份元信息中选用校验通过且事务号最大的一份,并据此重建空闲页
+//		cacheBytes为页缓存的容量,为0时使用默认值4MB
+//		若有传入的比较器,则将传入的第一个比较器设为该树的比较器
+//@receiver		nil
+//@param    	path		string						文件路径
+//@param    	pageSize	int							页大小
+//@param    	cacheBytes	int64						页缓存容量
+//@param    	Cmp			 ...comparator.Comparator	diskBPlusTree比较器集
+//@return    	t        	*DiskBPlusTree				打开的diskBPlusTree指针
+//@return    	err        	error						打开失败时的错误
+func Open(path string, pageSize int, cacheBytes int64, cmps ...comparator.Comparator) (t *DiskBPlusTree, err error) {
+	if pageSize != 0 && (pageSize < minPageSize || pageSize > maxPageSize || pageSize&(pageSize-1) != 0) {
+		return nil, ErrPageSize
+	}
+	if cacheBytes <= 0 {
+		cacheBytes = defaultCacheBytes
+	}
+	//判断是否有传入比较器,若有则设为该树默认比较器
+	var cmp comparator.Comparator
+	if len(cmps) == 0 || cmps[0] == nil {
+		cmp = bytesCmp
+	} else {
+		cmp = cmps[0]
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	t = &DiskBPlusTree{cmp: cmp}
+	if err = t.load(file, pageSize, cacheBytes); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return t, nil
+}
+
+//@title    load
+//@description
+//		以diskBPlusTree磁盘B+树做接收者
+//		从文件中加载元信息并初始化页管理器
+//		空文件视为新建,写入两份初始元信息
+//@receiver		t			*DiskBPlusTree			接受者diskBPlusTree的指针
+//@param    	file		*os.File				文件句柄
+//@param    	pageSize	int						页大小
+//@param    	cacheBytes	int64					页缓存容量
+//@return    	err        	error					加载失败时的错误
+func (t *DiskBPlusTree) load(file *os.File, pageSize int, cacheBytes int64) (err error) {
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() == 0 {
+		if pageSize == 0 {
+			pageSize = defaultPageSize
+		}
+		t.pager = newPager(file, pageSize, cacheBytes)
+		t.meta = &meta{pageSize: uint32(pageSize), pageCount: metaPages}
+		if err = file.Truncate(int64(metaPages) * int64(pageSize)); err != nil {
+			return err
+		}
+		for i := 0; i < metaPages; i++ {
+			//两份元信息均写入初始版本
+			m := *t.meta
+			m.txid = uint64(i)
+			if err = t.pager.writeMeta(&m); err != nil {
+				return err
+			}
+		}
+		t.meta.txid = 1
+		t.pager.pageCount = metaPages
+		return nil
+	}
+	//第0份元信息位于文件开头,从中得知页大小后再读取第1份
+	size := pageSize
+	p := newPager(file, minPageSize, cacheBytes)
+	m0, err0 := p.readMeta(0)
+	if err0 == nil {
+		if pageSize != 0 && int(m0.pageSize) != pageSize {
+			return ErrPageSize
+		}
+		size = int(m0.pageSize)
+	}
+	var m1 *meta
+	err1 := ErrCorrupted
+	if size != 0 {
+		p = newPager(file, size, cacheBytes)
+		m1, err1 = p.readMeta(1)
+		if err1 == nil && int(m1.pageSize) != size {
+			err1 = ErrCorrupted
+		}
+	} else {
+		//第0份元信息损坏且未指定页大小,依次按每个合法的页大小尝试读取第1份
+		for sz := minPageSize; sz <= maxPageSize && err1 != nil; sz <<= 1 {
+			p = newPager(file, sz, cacheBytes)
+			if m1, err1 = p.readMeta(1); err1 == nil && int(m1.pageSize) != sz {
+				err1 = ErrCorrupted
+			}
+			size = sz
+		}
+	}
+	switch {
+	case err0 != nil && err1 != nil:
+		return ErrCorrupted
+	case err0 != nil:
+		t.meta = m1
+	case err1 != nil:
+		t.meta = m0
+	case m0.txid > m1.txid:
+		t.meta = m0
+	default:
+		t.meta = m1
+	}
+	if t.meta.pageCount < metaPages || t.meta.pageCount*uint64(size) > uint64(info.Size()) {
+		return ErrCorrupted
+	}
+	p.pageCount = t.meta.pageCount
+	t.pager = p
+	return p.collect(t.meta.root)
+}
+
+//@title    Close
+//@description
+//		以diskBPlusTree磁盘B+树做接收者
+//		关闭该树及其文件,关闭后的操作均返回ErrClosed
+//@receiver		t			*DiskBPlusTree			接受者diskBPlusTree的指针
+//@param    	nil
+//@return    	err        	error					关闭失败时的错误
+func (t *DiskBPlusTree) Close() (err error) {
+	if t == nil {
+		return ErrClosed
+	}
+	t.mutex.Lock()
+	if t.closed {
+		t.mutex.Unlock()
+		return ErrClosed
+	}
+	t.closed = true
+	t.pager.cache.Clear()
+	err = t.pager.file.Close()
+	t.mutex.Unlock()
+	return err
+}
+
+//@title    Size
+//@description
+//		以diskBPlusTree磁盘B+树做接收者
+//		返回已提交版本中键的数量
+//		如果容器为nil返回0
+//@receiver		t			*DiskBPlusTree			接受者diskBPlusTree的指针
+//@param    	nil
+//@return    	num        	int						已提交版本中键的数量
+func (t *DiskBPlusTree) Size() (num int) {
+	if t == nil {
+		return 0
+	}
+	t.mutex.Lock()
+	num = int(t.meta.count)
+	t.mutex.Unlock()
+	return num
+}
+
+//@title    Empty
+//@description
+//		以diskBPlusTree磁盘B+树做接收者
+//		判断已提交版本是否含有键
+//		如果容器不存在,返回true
+//@receiver		t			*DiskBPlusTree			接受者diskBPlusTree的指针
+//@param    	nil
+//@return    	b			bool					该容器是空的吗?
+func (t *DiskBPlusTree) Empty() (b bool) {
+	return t.Size() == 0
+}
+
+//@title    search
+//@description
+//		以diskBPlusTree磁盘B+树做接收者
+//		从根页号为root的版本中查找key对应的值
+//		通过get读取页,以便同时用于已提交版本和事务内的版本
+//@receiver		t			*DiskBPlusTree			接受者diskBPlusTree的指针
+//@param    	root		uint64					根页号
+//@param    	key			[]byte					待查找的键
+//@param    	get			func(uint64) (*page, error)	读取页的函数
+//@return    	value		[]byte					key对应的值
+//@return    	err			error					查找失败时的错误
+func (t *DiskBPlusTree) search(root uint64, key []byte, get func(uint64) (*page, error)) (value []byte, err error) {
+	if root == 0 {
+		return nil, ErrNotFound
+	}
+	for id := root; ; {
+		pg, err := get(id)
+		if err != nil {
+			return nil, err
+		}
+		if !pg.isLeaf {
+			id = pg.children[pg.upperIdx(key, t.cmp)]
+			continue
+		}
+		if idx := pg.lowerIdx(key, t.cmp); idx < len(pg.keys) && t.cmp(pg.keys[idx], key) == 0 {
+			return append([]byte{}, pg.values[idx]...), nil
+		}
+		return nil, ErrNotFound
+	}
+}
+
+//@title    Get
+//@description
+//		以diskBPlusTree磁盘B+树做接收者
+//		从已提交版本中获取key对应的值,返回的值为副本
+//		key不存在时返回ErrNotFound
+//@receiver		t			*DiskBPlusTree			接受者diskBPlusTree的指针
+//@param    	key			[]byte					待查找的键
+//@return    	value		[]byte					key对应的值
+//@return    	err			error					查找失败时的错误
+func (t *DiskBPlusTree) Get(key []byte) (value []byte, err error) {
+	if t == nil {
+		return nil, ErrClosed
+	}
+	t.mutex.Lock()
+	if t.closed {
+		t.mutex.Unlock()
+		return nil, ErrClosed
+	}
+	value, err = t.search(t.meta.root, key, t.pager.read)
+	t.mutex.Unlock()
+	return value, err
+}
+
+//@title    Put
+//@description
+//		以diskBPlusTree磁盘B+树做接收者
+//		写入键值对并立即提交,key已存在时覆盖其值
+//@receiver		t			*DiskBPlusTree			接受者diskBPlusTree的指针
+//@param    	key			[]byte					待写入的键
+//@param    	value		[]byte					待写入的值
+//@return    	err			error					写入失败时的错误
+func (t *DiskBPlusTree) Put(key, value []byte) (err error) {
+	return t.Update(func(b *Batch) error {
+		return b.Put(key, value)
+	})
+}
+
+//@title    Delete
+//@description
+//		以diskBPlusTree磁盘B+树做接收者
+//		删除key并立即提交
+//		key不存在时返回ErrNotFound
+//@receiver		t			*DiskBPlusTree			接受者diskBPlusTree的指针
+//@param    	key			[]byte					待删除的键
+//@return    	err			error					删除失败时的错误
+func (t *DiskBPlusTree) Delete(key []byte) (err error) {
+	return t.Update(func(b *Batch) error {
+		return b.Delete(key)
+	})
+}
+
+//@title    Update
+//@description
+//		以diskBPlusTree磁盘B+树做接收者
+//		新建一个事务并交由fn进行修改,fn返回nil时提交该事务,否则回滚并返回fn的错误
+//		事务中的全部修改在一次提交中完成,要么全部生效要么全部不生效
+//		fn执行期间持有该树的锁,fn中不可调用该树的其他函数,也不可在fn返回后继续使用该事务
+//@receiver		t			*DiskBPlusTree			接受者diskBPlusTree的指针
+//@param    	fn			func(b *Batch) error	进行修改的函数
+//@return    	err			error					修改或提交失败时的错误
+func (t *DiskBPlusTree) Update(fn func(b *Batch) error) (err error) {
+	if t == nil {
+		return ErrClosed
+	}
+	t.mutex.Lock()
+	if t.closed {
+		t.mutex.Unlock()
+		return ErrClosed
+	}
+	b := newBatch(t)
+	if err = fn(b); err != nil {
+		b.rollback()
+		t.mutex.Unlock()
+		return err
+	}
+	err = b.commit()
+	t.mutex.Unlock()
+	return err
+}
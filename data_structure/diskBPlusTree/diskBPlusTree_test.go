@@ -0,0 +1,224 @@
+package diskBPlusTree
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+//key将整数编码为按字节序有序的键
+func key(i int) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(i))
+	return b
+}
+
+//open打开临时目录中的树文件,失败时终止测试
+func open(t *testing.T, path string) *DiskBPlusTree {
+	tree, err := Open(path, minPageSize, 4*minPageSize)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	return tree
+}
+
+//verify校验树中的内容与want一致,并分别以正序和逆序游标遍历
+func verify(t *testing.T, tree *DiskBPlusTree, want map[int]int) {
+	keys := make([]int, 0, len(want))
+	for k := range want {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	if tree.Size() != len(keys) {
+		t.Fatalf("size = %d, want %d", tree.Size(), len(keys))
+	}
+	i := 0
+	for c := tree.First(); c.Valid(); c.Next() {
+		if string(c.Key()) != string(key(keys[i])) || string(c.Value()) != string(key(want[keys[i]])) {
+			t.Fatalf("forward cursor mismatch at %d", i)
+		}
+		i++
+	}
+	if i != len(keys) {
+		t.Fatalf("forward cursor visited %d keys, want %d", i, len(keys))
+	}
+	for c := tree.Last(); c.Valid(); c.Pre() {
+		i--
+		if string(c.Key()) != string(key(keys[i])) {
+			t.Fatalf("backward cursor mismatch at %d", i)
+		}
+	}
+	if i != 0 {
+		t.Fatalf("backward cursor stopped at %d", i)
+	}
+	tree.pager.free = tree.pager.free[:0]
+	if err := tree.pager.collect(tree.meta.root); err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+}
+
+func TestDiskBPlusTree_PutGetDelete(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree.db")
+	tree := open(t, path)
+	want := make(map[int]int)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 6000; i++ {
+		k := r.Intn(2000)
+		if r.Intn(3) == 0 {
+			err := tree.Delete(key(k))
+			if _, ok := want[k]; ok != (err == nil) {
+				t.Fatalf("Delete(%d) = %v", k, err)
+			}
+			delete(want, k)
+		} else {
+			if err := tree.Put(key(k), key(i)); err != nil {
+				t.Fatalf("Put(%d): %v", k, err)
+			}
+			want[k] = i
+		}
+		v, err := tree.Get(key(k))
+		if w, ok := want[k]; ok != (err == nil) || ok && string(v) != string(key(w)) {
+			t.Fatalf("Get(%d) = %v, %v", k, v, err)
+		}
+		if i%1500 == 0 {
+			//关闭后重新打开,内容应保持不变
+			verify(t, tree, want)
+			tree.Close()
+			tree = open(t, path)
+			verify(t, tree, want)
+		}
+	}
+	verify(t, tree, want)
+	for k := range want {
+		if err := tree.Delete(key(k)); err != nil {
+			t.Fatalf("Delete(%d): %v", k, err)
+		}
+	}
+	verify(t, tree, map[int]int{})
+	tree.Close()
+}
+
+func TestDiskBPlusTree_Bound(t *testing.T) {
+	tree := open(t, filepath.Join(t.TempDir(), "tree.db"))
+	defer tree.Close()
+	tree.Update(func(b *Batch) error {
+		for i := 0; i < 1000; i += 2 {
+			b.Put(key(i), nil)
+		}
+		return nil
+	})
+	for _, q := range []int{-1, 0, 1, 500, 501, 998, 999} {
+		c := tree.LowerBound(key(q))
+		if want := q + q&1; q < 0 || want > 998 {
+			if q >= 0 && c.Valid() {
+				t.Fatalf("LowerBound(%d) should be invalid", q)
+			}
+		} else if string(c.Key()) != string(key(want)) {
+			t.Fatalf("LowerBound(%d) = %v", q, c.Key())
+		}
+		c = tree.UpperBound(key(q))
+		if want := q + 2 - q&1; q < 0 || want > 998 {
+			if q >= 0 && c.Valid() {
+				t.Fatalf("UpperBound(%d) should be invalid", q)
+			}
+		} else if string(c.Key()) != string(key(want)) {
+			t.Fatalf("UpperBound(%d) = %v", q, c.Key())
+		}
+	}
+}
+
+func TestDiskBPlusTree_Update(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree.db")
+	tree := open(t, path)
+	fail := errors.New("fail")
+	err := tree.Update(func(b *Batch) error {
+		for i := 0; i < 500; i++ {
+			b.Put(key(i), key(i))
+		}
+		if _, err := b.Get(key(7)); err != nil {
+			t.Fatalf("batch Get: %v", err)
+		}
+		return fail
+	})
+	if err != fail || tree.Size() != 0 {
+		t.Fatalf("rolled back batch left size %d, err %v", tree.Size(), err)
+	}
+	c := tree.First()
+	if err = tree.Put(key(1), key(1)); err != nil {
+		t.Fatal(err)
+	}
+	if c.Next() || c.Err() != nil {
+		t.Fatalf("cursor on empty tree should stay invalid without error")
+	}
+	c = tree.First()
+	tree.Put(key(2), key(2))
+	if c.Next() || c.Err() != ErrCursorInvalid {
+		t.Fatalf("cursor should be invalidated by commit, got %v", c.Err())
+	}
+	if err = tree.Put(key(3), make([]byte, minPageSize)); err != ErrTooLarge {
+		t.Fatalf("oversized value: %v", err)
+	}
+	tree.Close()
+	if _, err = Open(path, 2*minPageSize, 0); err != ErrPageSize {
+		t.Fatalf("mismatched page size: %v", err)
+	}
+}
+
+func TestDiskBPlusTree_Recovery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree.db")
+	tree := open(t, path)
+	want := make(map[int]int)
+	for i := 0; i < 300; i++ {
+		tree.Put(key(i), key(i))
+		want[i] = i
+	}
+	txid := tree.meta.txid
+	tree.Put(key(1000), key(1000))
+	tree.Close()
+	//模拟写入最新元信息时崩溃:最新的元信息页被破坏,应恢复到上一次提交的版本
+	file, err := os.OpenFile(path, os.O_RDWR, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteAt([]byte("torn write"), int64((txid+1)%metaPages)*minPageSize)
+	file.Close()
+	tree = open(t, path)
+	if tree.meta.txid != txid {
+		t.Fatalf("recovered txid %d, want %d", tree.meta.txid, txid)
+	}
+	verify(t, tree, want)
+	//恢复后继续写入,复用的页不应破坏已提交的内容
+	for i := 300; i < 600; i++ {
+		tree.Put(key(i), key(i))
+		want[i] = i
+	}
+	verify(t, tree, want)
+	tree.Close()
+	tree = open(t, path)
+	verify(t, tree, want)
+	//使最新的元信息写入第0份后将其破坏,不指定页大小时也应能从第1份恢复
+	if tree.meta.txid%metaPages != 1 {
+		tree.Put(key(600), key(600))
+		want[600] = 600
+	}
+	txid = tree.meta.txid
+	tree.Put(key(601), key(601))
+	tree.Close()
+	if file, err = os.OpenFile(path, os.O_RDWR, 0644); err != nil {
+		t.Fatal(err)
+	}
+	file.WriteAt([]byte("torn write"), 0)
+	file.Close()
+	if tree, err = Open(path, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if tree.meta.txid != txid {
+		t.Fatalf("recovered txid %d, want %d", tree.meta.txid, txid)
+	}
+	verify(t, tree, want)
+	tree.Close()
+}
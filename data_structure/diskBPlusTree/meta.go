@@ -0,0 +1,78 @@
+package diskBPlusTree
+
+//@Title		diskBPlusTree
+//@Description
+//		磁盘B+树的元信息页
+//		文件的第0页和第1页为两份元信息页,事务号为奇数的提交写入第1页,偶数写入第0页
+//		每份元信息带有校验和,打开时选用校验通过且事务号最大的一份
+//		提交时先将新的页全部落盘,再写入另一份元信息,写入过程中断时旧的元信息仍然完整可用
+//		元信息页的编码格式:
+//			魔数(8B) 页大小(4B) 根页号(8B) 页数(8B) 事务号(8B) 键数量(8B) 校验和(4B)
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+)
+
+const (
+	metaSize  = 48 //元信息编码后的长度
+	metaPages = 2  //元信息所占的页数
+)
+
+//magic文件魔数
+var magic = []byte("goSTLbpt")
+
+//meta元信息结构体
+//root为0时表示树为空,页号0为元信息页,不会被节点占用
+//pageCount为已提交版本中文件使用的页数,超出该范围的页视为未使用
+type meta struct {
+	pageSize  uint32 //页大小
+	root      uint64 //根页号
+	pageCount uint64 //已使用的页数
+	txid      uint64 //事务号
+	count     uint64 //键的数量
+}
+
+//@title    encode
+//@description
+//		以meta元信息做接收者
+//		将元信息按编码格式写入buf中并在末尾写入校验和
+//@receiver		m			*meta					接受者meta的指针
+//@param    	buf			[]byte					承载编码结果的缓冲区
+//@return    	nil
+func (m *meta) encode(buf []byte) {
+	for i := range buf {
+		buf[i] = 0
+	}
+	copy(buf, magic)
+	binary.BigEndian.PutUint32(buf[8:], m.pageSize)
+	binary.BigEndian.PutUint64(buf[12:], m.root)
+	binary.BigEndian.PutUint64(buf[20:], m.pageCount)
+	binary.BigEndian.PutUint64(buf[28:], m.txid)
+	binary.BigEndian.PutUint64(buf[36:], m.count)
+	binary.BigEndian.PutUint32(buf[44:], crc32.ChecksumIEEE(buf[:44]))
+}
+
+//@title    decodeMeta
+//@description
+//		将buf中按编码格式存放的元信息解码并返回
+//		魔数或校验和不匹配时返回ErrCorrupted
+//@receiver		nil
+//@param    	buf			[]byte					存放元信息的缓冲区
+//@return    	m        	*meta					解码得到的元信息
+//@return    	err        	error					解码失败时的错误
+func decodeMeta(buf []byte) (m *meta, err error) {
+	if len(buf) < metaSize || !bytes.Equal(buf[:8], magic) {
+		return nil, ErrCorrupted
+	}
+	if crc32.ChecksumIEEE(buf[:44]) != binary.BigEndian.Uint32(buf[44:]) {
+		return nil, ErrCorrupted
+	}
+	return &meta{
+		pageSize:  binary.BigEndian.Uint32(buf[8:]),
+		root:      binary.BigEndian.Uint64(buf[12:]),
+		pageCount: binary.BigEndian.Uint64(buf[20:]),
+		txid:      binary.BigEndian.Uint64(buf[28:]),
+		count:     binary.BigEndian.Uint64(buf[36:]),
+	}, nil
+}
@@ -0,0 +1,281 @@
+package diskBPlusTree
+
+//@Title		diskBPlusTree
+//@Description
+//		磁盘B+树的页
+//		每个节点占用文件中一个固定大小的页,页号即其在文件中的偏移除以页大小
+//		叶子页依次存放键值对,内部页依次存放子页号及子页之间的分隔键
+//		页一经提交便不再修改,修改时复制到新的页号上,因此缓存中的页可以直接丢弃而无需写回
+//		页的编码格式:
+//			叶子页:类型(1B) 数量(2B) {键长(2B) 值长(2B) 键 值}...
+//			内部页:类型(1B) 数量(2B) 首个子页号(8B) {键长(2B) 键 子页号(8B)}...
+import (
+	"encoding/binary"
+	"github.com/hlccd/goSTL/utils/comparator"
+)
+
+const (
+	leafPage     = 1 //叶子页类型
+	internalPage = 2 //内部页类型
+	pageHeader   = 3 //页头长度
+)
+
+//page页结构体
+//该结构体是磁盘B+树中一个节点解码后的内存形式
+//叶子页使用keys和values,内部页使用keys和children,keys[i]不大于children[i+1]中的所有键
+//txid记录创建该页的事务,只有创建它的事务可以直接修改该页
+type page struct {
+	id       uint64   //页号
+	txid     uint64   //创建该页的事务号
+	isLeaf   bool     //是否为叶子页
+	keys     [][]byte //叶子页的键或内部页的分隔键
+	values   [][]byte //叶子页的值
+	children []uint64 //内部页的子页号
+}
+
+//@title    Len
+//@description
+//		以page页做接收者
+//		返回该页编码后占用的字节数,作为页缓存计算容量的依据
+//@receiver		pg			*page					接受者page的指针
+//@param    	nil
+//@return    	num        	int						该页编码后的字节数
+func (pg *page) Len() (num int) {
+	num = pageHeader
+	if pg.isLeaf {
+		for i := range pg.keys {
+			num += 4 + len(pg.keys[i]) + len(pg.values[i])
+		}
+		return num
+	}
+	num += 8
+	for i := range pg.keys {
+		num += 10 + len(pg.keys[i])
+	}
+	return num
+}
+
+//@title    clone
+//@description
+//		以page页做接收者
+//		复制该页的内容到一个新的页并返回
+//		键和值的字节切片一经写入便不再修改,因此只复制切片本身
+//@receiver		pg			*page					接受者page的指针
+//@param    	id			uint64					新页的页号
+//@param    	txid		uint64					创建新页的事务号
+//@return    	cp        	*page					复制得到的新页
+func (pg *page) clone(id, txid uint64) (cp *page) {
+	cp = &page{
+		id:     id,
+		txid:   txid,
+		isLeaf: pg.isLeaf,
+		keys:   append([][]byte{}, pg.keys...),
+	}
+	if pg.isLeaf {
+		cp.values = append([][]byte{}, pg.values...)
+	} else {
+		cp.children = append([]uint64{}, pg.children...)
+	}
+	return cp
+}
+
+//@title    encode
+//@description
+//		以page页做接收者
+//		将该页按编码格式写入buf中,buf的长度需为页大小
+//@receiver		pg			*page					接受者page的指针
+//@param    	buf			[]byte					承载编码结果的缓冲区
+//@return    	nil
+func (pg *page) encode(buf []byte) {
+	for i := range buf {
+		buf[i] = 0
+	}
+	if pg.isLeaf {
+		buf[0] = leafPage
+		binary.BigEndian.PutUint16(buf[1:], uint16(len(pg.keys)))
+	} else {
+		buf[0] = internalPage
+		binary.BigEndian.PutUint16(buf[1:], uint16(len(pg.children)))
+	}
+	p := pageHeader
+	if pg.isLeaf {
+		for i := range pg.keys {
+			binary.BigEndian.PutUint16(buf[p:], uint16(len(pg.keys[i])))
+			binary.BigEndian.PutUint16(buf[p+2:], uint16(len(pg.values[i])))
+			p += 4
+			p += copy(buf[p:], pg.keys[i])
+			p += copy(buf[p:], pg.values[i])
+		}
+		return
+	}
+	binary.BigEndian.PutUint64(buf[p:], pg.children[0])
+	p += 8
+	for i := range pg.keys {
+		binary.BigEndian.PutUint16(buf[p:], uint16(len(pg.keys[i])))
+		p += 2
+		p += copy(buf[p:], pg.keys[i])
+		binary.BigEndian.PutUint64(buf[p:], pg.children[i+1])
+		p += 8
+	}
+}
+
+//@title    decode
+//@description
+//		将buf中按编码格式存放的页解码并返回
+//		若页类型未知或长度越界则说明页已损坏,返回ErrCorrupted
+//@receiver		nil
+//@param    	id			uint64					页号
+//@param    	buf			[]byte					存放页内容的缓冲区
+//@return    	pg        	*page					解码得到的页
+//@return    	err        	error					解码失败时的错误
+func decode(id uint64, buf []byte) (pg *page, err error) {
+	if len(buf) < pageHeader || (buf[0] != leafPage && buf[0] != internalPage) {
+		return nil, ErrCorrupted
+	}
+	pg = &page{id: id, isLeaf: buf[0] == leafPage}
+	num := int(binary.BigEndian.Uint16(buf[1:]))
+	p := pageHeader
+	//读取长度为l的字节切片,越界时返回nil
+	read := func(l int) (b []byte) {
+		if p+l > len(buf) {
+			return nil
+		}
+		b = make([]byte, l)
+		p += copy(b, buf[p:p+l])
+		return b
+	}
+	if pg.isLeaf {
+		pg.keys, pg.values = make([][]byte, 0, num), make([][]byte, 0, num)
+		for i := 0; i < num; i++ {
+			if p+4 > len(buf) {
+				return nil, ErrCorrupted
+			}
+			kl, vl := int(binary.BigEndian.Uint16(buf[p:])), int(binary.BigEndian.Uint16(buf[p+2:]))
+			p += 4
+			k, v := read(kl), read(vl)
+			if k == nil || v == nil {
+				return nil, ErrCorrupted
+			}
+			pg.keys, pg.values = append(pg.keys, k), append(pg.values, v)
+		}
+		return pg, nil
+	}
+	if num == 0 || p+8 > len(buf) {
+		return nil, ErrCorrupted
+	}
+	pg.keys, pg.children = make([][]byte, 0, num-1), make([]uint64, 0, num)
+	pg.children = append(pg.children, binary.BigEndian.Uint64(buf[p:]))
+	p += 8
+	for i := 1; i < num; i++ {
+		if p+2 > len(buf) {
+			return nil, ErrCorrupted
+		}
+		kl := int(binary.BigEndian.Uint16(buf[p:]))
+		p += 2
+		k := read(kl)
+		if k == nil || p+8 > len(buf) {
+			return nil, ErrCorrupted
+		}
+		pg.keys = append(pg.keys, k)
+		pg.children = append(pg.children, binary.BigEndian.Uint64(buf[p:]))
+		p += 8
+	}
+	return pg, nil
+}
+
+//@title    lowerIdx
+//@description
+//		以page页做接收者
+//		二分查找该页keys中第一个不小于key的下标,不存在时返回len(keys)
+//@receiver		pg			*page					接受者page的指针
+//@param    	key			[]byte					待查找的键
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	idx        	int						第一个不小于key的下标
+func (pg *page) lowerIdx(key []byte, cmp comparator.Comparator) (idx int) {
+	l, r := 0, len(pg.keys)
+	for l < r {
+		m := (l + r) / 2
+		if cmp(pg.keys[m], key) < 0 {
+			l = m + 1
+		} else {
+			r = m
+		}
+	}
+	return l
+}
+
+//@title    upperIdx
+//@description
+//		以page页做接收者
+//		二分查找该页keys中第一个大于key的下标,不存在时返回len(keys)
+//		对于内部页,该下标即为key所在的子页下标
+//@receiver		pg			*page					接受者page的指针
+//@param    	key			[]byte					待查找的键
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	idx        	int						第一个大于key的下标
+func (pg *page) upperIdx(key []byte, cmp comparator.Comparator) (idx int) {
+	l, r := 0, len(pg.keys)
+	for l < r {
+		m := (l + r) / 2
+		if cmp(pg.keys[m], key) <= 0 {
+			l = m + 1
+		} else {
+			r = m
+		}
+	}
+	return l
+}
+
+//@title    splitIdx
+//@description
+//		以page页做接收者
+//		按编码后的字节数寻找分裂位置,使左半部分的字节数刚好超过总数的一半
+//		叶子页返回右半部分首个键的下标,内部页返回上移的分隔键的下标
+//		返回的下标保证左右两部分均不为空
+//@receiver		pg			*page					接受者page的指针
+//@param    	nil
+//@return    	idx        	int						分裂位置
+func (pg *page) splitIdx() (idx int) {
+	half, sum := pg.Len()/2, pageHeader
+	for idx = 0; idx < len(pg.keys)-1; idx++ {
+		if pg.isLeaf {
+			sum += 4 + len(pg.keys[idx]) + len(pg.values[idx])
+		} else {
+			sum += 10 + len(pg.keys[idx])
+		}
+		if sum > half {
+			break
+		}
+	}
+	if pg.isLeaf {
+		idx++
+	}
+	return idx
+}
+
+//@title    split
+//@description
+//		以page页做接收者
+//		将该页按字节数分裂为两半,后一半移入页号为id的新页
+//		叶子页以新页的首个键作为分隔键,内部页将中间的分隔键上移
+//		调用前需保证该页可被当前事务直接修改
+//@receiver		pg			*page					接受者page的指针
+//@param    	id			uint64					新页的页号
+//@param    	txid		uint64					当前事务号
+//@return    	sep        	[]byte					上移到父页的分隔键
+//@return    	right       *page					分裂出的新页
+func (pg *page) split(id, txid uint64) (sep []byte, right *page) {
+	idx := pg.splitIdx()
+	right = &page{id: id, txid: txid, isLeaf: pg.isLeaf}
+	if pg.isLeaf {
+		right.keys = append([][]byte{}, pg.keys[idx:]...)
+		right.values = append([][]byte{}, pg.values[idx:]...)
+		pg.keys, pg.values = pg.keys[:idx:idx], pg.values[:idx:idx]
+		return right.keys[0], right
+	}
+	sep = pg.keys[idx]
+	right.keys = append([][]byte{}, pg.keys[idx+1:]...)
+	right.children = append([]uint64{}, pg.children[idx+1:]...)
+	pg.keys, pg.children = pg.keys[:idx:idx], pg.children[:idx+1:idx+1]
+	return sep, right
+}
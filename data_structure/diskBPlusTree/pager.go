@@ -0,0 +1,177 @@
+package diskBPlusTree
+
+//@Title		diskBPlusTree
+//@Description
+//		磁盘B+树的页管理器
+//		负责页的读写、空闲页的分配与回收,以及已提交页的缓存
+//		页缓存基于lru实现,缓存中的页均已提交且不会再被修改,淘汰时直接丢弃即可
+//		空闲页在打开文件时由根页出发遍历得到,未被已提交版本引用的页均可复用
+import (
+	"github.com/hlccd/goSTL/data_structure/lru"
+	"os"
+	"strconv"
+)
+
+//pager页管理器结构体
+//该实例保存文件句柄、页大小和页缓存
+//同时保存已提交版本的页数和可复用的空闲页号
+type pager struct {
+	file      *os.File //文件句柄
+	pageSize  int      //页大小
+	cache     *lru.LRU //已提交页的缓存
+	pageCount uint64   //已使用的页数
+	free      []uint64 //可复用的空闲页号
+	buf       []byte   //读写页时使用的缓冲区
+}
+
+//@title    newPager
+//@description
+//		新建一个页管理器并返回
+//		页缓存的容量为cacheBytes字节,按页编码后的字节数计算
+//@receiver		nil
+//@param    	file		*os.File				文件句柄
+//@param    	pageSize	int						页大小
+//@param    	cacheBytes	int64					页缓存容量
+//@return    	p        	*pager					新建的页管理器指针
+func newPager(file *os.File, pageSize int, cacheBytes int64) (p *pager) {
+	return &pager{
+		file:     file,
+		pageSize: pageSize,
+		cache:    lru.New(cacheBytes, nil),
+		free:     make([]uint64, 0),
+		buf:      make([]byte, pageSize),
+	}
+}
+
+//@title    read
+//@description
+//		以pager页管理器做接收者
+//		读取页号为id的页,优先从页缓存中获取
+//		缓存未命中时从文件读取并解码,随后放入页缓存
+//@receiver		p			*pager					接受者pager的指针
+//@param    	id			uint64					页号
+//@return    	pg        	*page					读取到的页
+//@return    	err        	error					读取失败时的错误
+func (p *pager) read(id uint64) (pg *page, err error) {
+	if id < metaPages || id >= p.pageCount {
+		return nil, ErrCorrupted
+	}
+	key := strconv.FormatUint(id, 10)
+	if v, ok := p.cache.Get(key); ok {
+		return v.(*page), nil
+	}
+	if _, err = p.file.ReadAt(p.buf, int64(id)*int64(p.pageSize)); err != nil {
+		return nil, err
+	}
+	if pg, err = decode(id, p.buf); err != nil {
+		return nil, err
+	}
+	p.cache.Insert(key, pg)
+	return pg, nil
+}
+
+//@title    write
+//@description
+//		以pager页管理器做接收者
+//		将页编码后写入文件中对应的位置
+//@receiver		p			*pager					接受者pager的指针
+//@param    	pg			*page					待写入的页
+//@return    	err        	error					写入失败时的错误
+func (p *pager) write(pg *page) (err error) {
+	pg.encode(p.buf)
+	_, err = p.file.WriteAt(p.buf, int64(pg.id)*int64(p.pageSize))
+	return err
+}
+
+//@title    readMeta
+//@description
+//		以pager页管理器做接收者
+//		读取第idx份元信息
+//@receiver		p			*pager					接受者pager的指针
+//@param    	idx			uint64					元信息序号
+//@return    	m        	*meta					读取到的元信息
+//@return    	err        	error					读取失败时的错误
+func (p *pager) readMeta(idx uint64) (m *meta, err error) {
+	buf := make([]byte, metaSize)
+	if _, err = p.file.ReadAt(buf, int64(idx)*int64(p.pageSize)); err != nil {
+		return nil, err
+	}
+	return decodeMeta(buf)
+}
+
+//@title    writeMeta
+//@description
+//		以pager页管理器做接收者
+//		将元信息写入事务号对应的元信息页并落盘
+//@receiver		p			*pager					接受者pager的指针
+//@param    	m			*meta					待写入的元信息
+//@return    	err        	error					写入失败时的错误
+func (p *pager) writeMeta(m *meta) (err error) {
+	buf := make([]byte, metaSize)
+	m.encode(buf)
+	if _, err = p.file.WriteAt(buf, int64(m.txid%metaPages)*int64(p.pageSize)); err != nil {
+		return err
+	}
+	return p.file.Sync()
+}
+
+//@title    collect
+//@description
+//		以pager页管理器做接收者
+//		从根页出发遍历所有内部页,标记被引用的页
+//		将[metaPages,pageCount)中未被引用的页作为空闲页
+//		叶子页的页号已由其父页给出,无需读取叶子页本身
+//@receiver		p			*pager					接受者pager的指针
+//@param    	root		uint64					根页号
+//@return    	err        	error					读取失败时的错误
+func (p *pager) collect(root uint64) (err error) {
+	used := make([]bool, p.pageCount)
+	p.free = p.free[:0]
+	if root != 0 {
+		//沿最左侧路径向下得到树高,深度等于树高的页即为叶子页
+		height := 0
+		for id := root; ; height++ {
+			pg, err := p.read(id)
+			if err != nil {
+				return err
+			}
+			if pg.isLeaf {
+				break
+			}
+			id = pg.children[0]
+		}
+		type frame struct {
+			id    uint64
+			depth int
+		}
+		stack := []frame{{root, 0}}
+		used[root] = true
+		for len(stack) > 0 {
+			f := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if f.depth == height {
+				continue
+			}
+			pg, err := p.read(f.id)
+			if err != nil {
+				return err
+			}
+			if pg.isLeaf {
+				return ErrCorrupted
+			}
+			for _, c := range pg.children {
+				if c < metaPages || c >= p.pageCount || used[c] {
+					return ErrCorrupted
+				}
+				used[c] = true
+				stack = append(stack, frame{c, f.depth + 1})
+			}
+		}
+	}
+	for id := uint64(metaPages); id < p.pageCount; id++ {
+		if !used[id] {
+			p.free = append(p.free, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,407 @@
+package fenwick
+
+//@Title		fenwick
+//@Description
+//		树状数组-Fenwick Tree,也称二进制索引树-Binary Indexed Tree
+//		以数组形式存储,下标i处保存以i结尾、长度为lowbit(i)的区间的聚合值
+//		可在O(n)时间内从切片或vector中建树
+//		支持单点增加、前缀和、区间和以及按前缀和查找下标,时间复杂度均为O(log n)
+//		聚合运算由创建时传入的群决定,若不传入则根据元素类型使用默认的加法群
+//		以空序列建立且未传入群时无法得知元素类型,此时得到一个群为nil的空树状数组,其聚合值均为nil
+//		下标从0开始,区间均为左闭右开区间[l,r)
+//		使用互斥锁实现并发控制
+
+import (
+	"github.com/hlccd/goSTL/data_structure/vector"
+	"github.com/hlccd/goSTL/utils/comparator"
+	"github.com/hlccd/goSTL/utils/iterator"
+	"sync"
+)
+
+//fenwick树状数组结构体
+//tree为从1开始编号的树状数组
+//values保存序列中每个元素的当前值,用于单点查询和单点赋值
+//聚合所用的群在创建时传入
+type Fenwick struct {
+	tree   []interface{} //树状数组,下标从1开始
+	values []interface{} //序列元素
+	group  *Group        //聚合所用的群
+	mutex  sync.Mutex    //并发控制锁
+}
+
+//fenwick树状数组容器接口
+//存放了fenwick树状数组可使用的函数
+//对应函数介绍见下方
+type fenwicker interface {
+	Iterator() (i *Iterator.Iterator)                                               //返回包含该序列所有元素的迭代器
+	Size() (num int)                                                                //返回该序列的长度
+	Empty() (b bool)                                                                //判断该序列是否为空
+	At(idx int) (e interface{})                                                     //返回下标idx处的元素
+	Add(idx int, delta interface{}) (b bool)                                        //将下标idx处的元素增加delta
+	Set(idx int, e interface{}) (b bool)                                            //将下标idx处的元素修改为e
+	PrefixSum(idx int) (sum interface{})                                            //返回[0,idx)区间内元素的聚合值
+	Sum(l, r int) (sum interface{})                                                 //返回[l,r)区间内元素的聚合值
+	FindPrefix(target interface{}, cmps ...comparator.Comparator) (idx int, b bool) //返回前缀和首次不小于target的下标
+}
+
+//@title    lowbit
+//@description
+//		返回x的二进制表示中最低位的1所对应的值
+//@receiver		nil
+//@param    	x			int						待计算的数
+//@return    	num			int						最低位的1所对应的值
+func lowbit(x int) (num int) {
+	return x & (-x)
+}
+
+//@title    build
+//@description
+//		以群g对序列es在O(n)时间内建立树状数组
+//		每个位置在自身计算完成后将结果累加到其父节点上
+//@receiver		nil
+//@param    	es			[]interface{}			初始序列
+//@param    	g			*Group					聚合所用的群
+//@return    	tree		[]interface{}			下标从1开始的树状数组
+func build(es []interface{}, g *Group) (tree []interface{}) {
+	n := len(es)
+	tree = make([]interface{}, n+1)
+	for i := 1; i <= n; i++ {
+		tree[i] = es[i-1]
+	}
+	for i := 1; i <= n; i++ {
+		if j := i + lowbit(i); j <= n {
+			tree[j] = g.Op(tree[j], tree[i])
+		}
+	}
+	return tree
+}
+
+//@title    add
+//@description
+//		将树状数组中第i个位置增加delta,i从1开始
+//@receiver		nil
+//@param    	tree		[]interface{}			树状数组
+//@param    	i			int						位置
+//@param    	delta		interface{}				增加的值
+//@param    	g			*Group					聚合所用的群
+//@return    	nil
+func add(tree []interface{}, i int, delta interface{}, g *Group) {
+	for ; i < len(tree); i += lowbit(i) {
+		tree[i] = g.Op(tree[i], delta)
+	}
+}
+
+//@title    prefix
+//@description
+//		返回树状数组中前i个位置的聚合值
+//@receiver		nil
+//@param    	tree		[]interface{}			树状数组
+//@param    	i			int						位置个数
+//@param    	g			*Group					聚合所用的群
+//@return    	sum			interface{}				聚合值
+func prefix(tree []interface{}, i int, g *Group) (sum interface{}) {
+	sum = g.Identity
+	for ; i > 0; i -= lowbit(i) {
+		sum = g.Op(sum, tree[i])
+	}
+	return sum
+}
+
+//@title    getGroup
+//@description
+//		从传入的群中选出第一个作为聚合所用的群
+//		若没有传入则根据元素e的类型选取默认的加法群
+//		若运算或逆元为nil则返回nil
+//@receiver		nil
+//@param    	e			interface{}				序列中的元素
+//@param    	gs			[]Group					传入的群集
+//@return    	g			*Group					聚合所用的群
+func getGroup(e interface{}, gs []Group) (g *Group) {
+	var group Group
+	if len(gs) > 0 {
+		group = gs[0]
+	} else {
+		group = GetGroup(e)
+	}
+	if group.Op == nil || group.Inverse == nil {
+		return nil
+	}
+	return &group
+}
+
+//@title    toSlice
+//@description
+//		将vector中的元素按顺序放入切片中
+//@receiver		nil
+//@param    	v			*vector.Vector			待转换的vector
+//@return    	es			[]interface{}			转换得到的切片
+func toSlice(v *vector.Vector) (es []interface{}) {
+	es = make([]interface{}, 0, v.Size())
+	if !v.Empty() {
+		for i := v.Iterator().Begin(); i.HasNext(); i.Next() {
+			es = append(es, i.Value())
+		}
+	}
+	return es
+}
+
+//@title    New
+//@description
+//		新建一个fenwick树状数组容器并返回
+//		以切片es中的元素作为初始序列,在O(n)时间内建树
+//		若有传入的群,则将传入的第一个群设为聚合方式,否则根据首个元素的类型使用默认的加法群
+//		es为空且未传入群时不需要聚合,返回群为nil的空树状数组,其前缀和与区间和均为nil
+//		除此之外若无法获得可用的群则返回nil
+//@receiver		nil
+//@param    	es			[]interface{}			初始序列
+//@param    	gs			...Group				聚合所用的群集
+//@return    	f        	*Fenwick				新建的fenwick指针
+func New(es []interface{}, gs ...Group) (f *Fenwick) {
+	var e interface{}
+	if len(es) > 0 {
+		e = es[0]
+	}
+	g := getGroup(e, gs)
+	if g == nil && (len(es) > 0 || len(gs) > 0) {
+		return nil
+	}
+	values := make([]interface{}, len(es))
+	copy(values, es)
+	return &Fenwick{
+		tree:   build(es, g),
+		values: values,
+		group:  g,
+		mutex:  sync.Mutex{},
+	}
+}
+
+//@title    NewFromVector
+//@description
+//		新建一个fenwick树状数组容器并返回
+//		以vector中的元素作为初始序列,在O(n)时间内建树
+//		群的选取方式同New
+//@receiver		nil
+//@param    	v			*vector.Vector			初始序列
+//@param    	gs			...Group				聚合所用的群集
+//@return    	f        	*Fenwick				新建的fenwick指针
+func NewFromVector(v *vector.Vector, gs ...Group) (f *Fenwick) {
+	return New(toSlice(v), gs...)
+}
+
+//@title    Iterator
+//@description
+//		以fenwick树状数组做接收者
+//		将该树状数组所表示的序列按顺序放入迭代器中
+//@receiver		f			*Fenwick				接受者fenwick的指针
+//@param    	nil
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (f *Fenwick) Iterator() (i *Iterator.Iterator) {
+	if f == nil {
+		return nil
+	}
+	f.mutex.Lock()
+	es := make([]interface{}, len(f.values))
+	copy(es, f.values)
+	f.mutex.Unlock()
+	return Iterator.New(&es)
+}
+
+//@title    Size
+//@description
+//		以fenwick树状数组做接收者
+//		返回该树状数组所表示序列的长度
+//		如果容器为nil返回0
+//@receiver		f			*Fenwick				接受者fenwick的指针
+//@param    	nil
+//@return    	num        	int						序列长度
+func (f *Fenwick) Size() (num int) {
+	if f == nil {
+		return 0
+	}
+	return len(f.values)
+}
+
+//@title    Empty
+//@description
+//		以fenwick树状数组做接收者
+//		判断该树状数组所表示的序列是否为空
+//		如果容器不存在,返回true
+//@receiver		f			*Fenwick				接受者fenwick的指针
+//@param    	nil
+//@return    	b			bool					该容器是空的吗?
+func (f *Fenwick) Empty() (b bool) {
+	if f == nil {
+		return true
+	}
+	return len(f.values) == 0
+}
+
+//@title    At
+//@description
+//		以fenwick树状数组做接收者
+//		返回序列中下标idx处的元素
+//		若下标越界则返回nil
+//@receiver		f			*Fenwick				接受者fenwick的指针
+//@param    	idx			int						下标
+//@return    	e			interface{}				该下标处的元素
+func (f *Fenwick) At(idx int) (e interface{}) {
+	if f == nil {
+		return nil
+	}
+	f.mutex.Lock()
+	if idx < 0 || idx >= len(f.values) {
+		f.mutex.Unlock()
+		return nil
+	}
+	e = f.values[idx]
+	f.mutex.Unlock()
+	return e
+}
+
+//@title    Add
+//@description
+//		以fenwick树状数组做接收者
+//		将序列中下标idx处的元素增加delta
+//		若下标越界则增加失败
+//@receiver		f			*Fenwick				接受者fenwick的指针
+//@param    	idx			int						下标
+//@param    	delta		interface{}				增加的值
+//@return    	b			bool					增加成功?
+func (f *Fenwick) Add(idx int, delta interface{}) (b bool) {
+	if f == nil {
+		return false
+	}
+	f.mutex.Lock()
+	if idx < 0 || idx >= len(f.values) {
+		f.mutex.Unlock()
+		return false
+	}
+	f.values[idx] = f.group.Op(f.values[idx], delta)
+	add(f.tree, idx+1, delta, f.group)
+	f.mutex.Unlock()
+	return true
+}
+
+//@title    Set
+//@description
+//		以fenwick树状数组做接收者
+//		将序列中下标idx处的元素修改为e
+//		即增加e与原值之差
+//		若下标越界则修改失败
+//@receiver		f			*Fenwick				接受者fenwick的指针
+//@param    	idx			int						下标
+//@param    	e			interface{}				修改后的元素
+//@return    	b			bool					修改成功?
+func (f *Fenwick) Set(idx int, e interface{}) (b bool) {
+	if f == nil {
+		return false
+	}
+	f.mutex.Lock()
+	if idx < 0 || idx >= len(f.values) {
+		f.mutex.Unlock()
+		return false
+	}
+	add(f.tree, idx+1, f.group.Op(e, f.group.Inverse(f.values[idx])), f.group)
+	f.values[idx] = e
+	f.mutex.Unlock()
+	return true
+}
+
+//@title    PrefixSum
+//@description
+//		以fenwick树状数组做接收者
+//		返回序列中[0,idx)区间内元素的聚合值
+//		若idx越界则返回nil
+//@receiver		f			*Fenwick				接受者fenwick的指针
+//@param    	idx			int						前缀长度
+//@return    	sum			interface{}				前缀聚合值
+func (f *Fenwick) PrefixSum(idx int) (sum interface{}) {
+	if f == nil {
+		return nil
+	}
+	f.mutex.Lock()
+	if idx < 0 || idx > len(f.values) || f.group == nil {
+		f.mutex.Unlock()
+		return nil
+	}
+	sum = prefix(f.tree, idx, f.group)
+	f.mutex.Unlock()
+	return sum
+}
+
+//@title    Sum
+//@description
+//		以fenwick树状数组做接收者
+//		返回序列中[l,r)区间内元素的聚合值
+//		通过两个前缀和做差得到
+//		若区间不合法则返回nil
+//@receiver		f			*Fenwick				接受者fenwick的指针
+//@param    	l			int						区间左端点,包含
+//@param    	r			int						区间右端点,不包含
+//@return    	sum			interface{}				区间聚合值
+func (f *Fenwick) Sum(l, r int) (sum interface{}) {
+	if f == nil {
+		return nil
+	}
+	f.mutex.Lock()
+	if l < 0 || r > len(f.values) || l > r || f.group == nil {
+		f.mutex.Unlock()
+		return nil
+	}
+	sum = f.group.Op(prefix(f.tree, r, f.group), f.group.Inverse(prefix(f.tree, l, f.group)))
+	f.mutex.Unlock()
+	return sum
+}
+
+//@title    FindPrefix
+//@description
+//		以fenwick树状数组做接收者
+//		返回最小的下标idx,使得[0,idx]区间内元素的聚合值不小于target
+//		要求序列中的元素均不小于单位元,即前缀和单调不减
+//		利用树状数组的结构进行倍增查找,时间复杂度为O(log n)
+//		若有传入的比较器则使用第一个比较器,否则根据target的类型使用默认比较器
+//		若所有前缀和均小于target或无法获得比较器则返回false
+//@receiver		f			*Fenwick				接受者fenwick的指针
+//@param    	target		interface{}				目标前缀和
+//@param    	cmps		...comparator.Comparator	比较器集
+//@return    	idx			int						找到的下标
+//@return    	b			bool					找到了吗?
+func (f *Fenwick) FindPrefix(target interface{}, cmps ...comparator.Comparator) (idx int, b bool) {
+	if f == nil {
+		return -1, false
+	}
+	var cmp comparator.Comparator
+	if len(cmps) > 0 {
+		cmp = cmps[0]
+	} else {
+		cmp = comparator.GetCmp(target)
+	}
+	if cmp == nil {
+		return -1, false
+	}
+	f.mutex.Lock()
+	n := len(f.values)
+	if f.group == nil {
+		f.mutex.Unlock()
+		return -1, false
+	}
+	step := 1
+	for step*2 <= n {
+		step *= 2
+	}
+	//pos为当前已确定的前缀和小于target的最长前缀长度
+	pos, sum := 0, f.group.Identity
+	for ; step > 0; step /= 2 {
+		if pos+step <= n {
+			if s := f.group.Op(sum, f.tree[pos+step]); cmp(s, target) < 0 {
+				pos, sum = pos+step, s
+			}
+		}
+	}
+	if pos == n {
+		f.mutex.Unlock()
+		return -1, false
+	}
+	f.mutex.Unlock()
+	return pos, true
+}
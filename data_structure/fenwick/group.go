@@ -0,0 +1,109 @@
+package fenwick
+
+//@Title		fenwick
+//@Description
+//		树状数组所使用的群
+//		树状数组需要对前缀和做差来得到区间和,因此聚合运算除结合律外还需要满足交换律并存在逆元
+//		对于系统自带的数值类型提供了以加法为运算的默认群
+
+//Group阿贝尔群结构体
+//包含一个满足结合律和交换律的二元运算、该运算的单位元以及求逆元的函数
+//Scale为可选的数乘函数,返回k个a通过运算得到的结果,仅在区间修改区间查询时使用
+//若Scale为nil,则可以使用Fenwick但无法创建RangeFenwick
+type Group struct {
+	Op       func(a, b interface{}) interface{}     //满足结合律和交换律的二元运算
+	Inverse  func(a interface{}) interface{}        //求逆元
+	Identity interface{}                            //该运算的单位元
+	Scale    func(a interface{}, k int) interface{} //数乘
+}
+
+//@title    GetGroup
+//@description
+//		传入一个数据并根据该数据类型返回一个以加法为运算的默认群
+//		若该类型并非系统自带的数值类型,则返回的群中运算为nil
+//		无符号类型的逆元按补码回绕计算,只要最终结果非负即可得到正确的区间和
+//@receiver		nil
+//@param    	e			interface{}
+//@return    	g        	Group			该类型对应的默认群
+func GetGroup(e interface{}) (g Group) {
+	switch e.(type) {
+	case int:
+		return Group{
+			Op:       func(a, b interface{}) interface{} { return a.(int) + b.(int) },
+			Inverse:  func(a interface{}) interface{} { return -a.(int) },
+			Identity: 0,
+			Scale:    func(a interface{}, k int) interface{} { return a.(int) * k },
+		}
+	case int8:
+		return Group{
+			Op:       func(a, b interface{}) interface{} { return a.(int8) + b.(int8) },
+			Inverse:  func(a interface{}) interface{} { return -a.(int8) },
+			Identity: int8(0),
+			Scale:    func(a interface{}, k int) interface{} { return a.(int8) * int8(k) },
+		}
+	case uint8:
+		return Group{
+			Op:       func(a, b interface{}) interface{} { return a.(uint8) + b.(uint8) },
+			Inverse:  func(a interface{}) interface{} { return -a.(uint8) },
+			Identity: uint8(0),
+			Scale:    func(a interface{}, k int) interface{} { return a.(uint8) * uint8(k) },
+		}
+	case int16:
+		return Group{
+			Op:       func(a, b interface{}) interface{} { return a.(int16) + b.(int16) },
+			Inverse:  func(a interface{}) interface{} { return -a.(int16) },
+			Identity: int16(0),
+			Scale:    func(a interface{}, k int) interface{} { return a.(int16) * int16(k) },
+		}
+	case uint16:
+		return Group{
+			Op:       func(a, b interface{}) interface{} { return a.(uint16) + b.(uint16) },
+			Inverse:  func(a interface{}) interface{} { return -a.(uint16) },
+			Identity: uint16(0),
+			Scale:    func(a interface{}, k int) interface{} { return a.(uint16) * uint16(k) },
+		}
+	case int32:
+		return Group{
+			Op:       func(a, b interface{}) interface{} { return a.(int32) + b.(int32) },
+			Inverse:  func(a interface{}) interface{} { return -a.(int32) },
+			Identity: int32(0),
+			Scale:    func(a interface{}, k int) interface{} { return a.(int32) * int32(k) },
+		}
+	case uint32:
+		return Group{
+			Op:       func(a, b interface{}) interface{} { return a.(uint32) + b.(uint32) },
+			Inverse:  func(a interface{}) interface{} { return -a.(uint32) },
+			Identity: uint32(0),
+			Scale:    func(a interface{}, k int) interface{} { return a.(uint32) * uint32(k) },
+		}
+	case int64:
+		return Group{
+			Op:       func(a, b interface{}) interface{} { return a.(int64) + b.(int64) },
+			Inverse:  func(a interface{}) interface{} { return -a.(int64) },
+			Identity: int64(0),
+			Scale:    func(a interface{}, k int) interface{} { return a.(int64) * int64(k) },
+		}
+	case uint64:
+		return Group{
+			Op:       func(a, b interface{}) interface{} { return a.(uint64) + b.(uint64) },
+			Inverse:  func(a interface{}) interface{} { return -a.(uint64) },
+			Identity: uint64(0),
+			Scale:    func(a interface{}, k int) interface{} { return a.(uint64) * uint64(k) },
+		}
+	case float32:
+		return Group{
+			Op:       func(a, b interface{}) interface{} { return a.(float32) + b.(float32) },
+			Inverse:  func(a interface{}) interface{} { return -a.(float32) },
+			Identity: float32(0),
+			Scale:    func(a interface{}, k int) interface{} { return a.(float32) * float32(k) },
+		}
+	case float64:
+		return Group{
+			Op:       func(a, b interface{}) interface{} { return a.(float64) + b.(float64) },
+			Inverse:  func(a interface{}) interface{} { return -a.(float64) },
+			Identity: float64(0),
+			Scale:    func(a interface{}, k int) interface{} { return a.(float64) * float64(k) },
+		}
+	}
+	return g
+}
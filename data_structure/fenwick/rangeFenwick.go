@@ -0,0 +1,279 @@
+package fenwick
+
+//@Title		fenwick
+//@Description
+//		区间修改树状数组
+//		对序列的差分数组d维护两个树状数组,分别保存d[i]和i*d[i]
+//		则前p个元素的和为(p+1)*sum(d[1..p])-sum(i*d[i])
+//		从而在O(log n)时间内支持区间增加、单点查询和区间查询
+//		所用的群必须提供数乘函数Scale
+//		下标从0开始,区间均为左闭右开区间[l,r)
+//		使用互斥锁实现并发控制
+
+import (
+	"github.com/hlccd/goSTL/data_structure/vector"
+	"github.com/hlccd/goSTL/utils/iterator"
+	"sync"
+)
+
+//rangeFenwick区间修改树状数组结构体
+//d保存差分数组的树状数组,id保存i*d[i]的树状数组,下标均从1开始
+//同时保存该树状数组所表示序列的长度
+//聚合所用的群在创建时传入
+type RangeFenwick struct {
+	d     []interface{} //差分数组的树状数组
+	id    []interface{} //i*d[i]的树状数组
+	size  int           //序列长度
+	group *Group        //聚合所用的群
+	mutex sync.Mutex    //并发控制锁
+}
+
+//rangeFenwick区间修改树状数组容器接口
+//存放了rangeFenwick区间修改树状数组可使用的函数
+//对应函数介绍见下方
+type rangeFenwicker interface {
+	Iterator() (i *Iterator.Iterator)         //返回包含该序列所有元素的迭代器
+	Size() (num int)                          //返回该序列的长度
+	Empty() (b bool)                          //判断该序列是否为空
+	At(idx int) (e interface{})               //返回下标idx处的元素
+	Add(l, r int, delta interface{}) (b bool) //将[l,r)区间内的元素全部增加delta
+	PrefixSum(idx int) (sum interface{})      //返回[0,idx)区间内元素的聚合值
+	Sum(l, r int) (sum interface{})           //返回[l,r)区间内元素的聚合值
+}
+
+//@title    NewRange
+//@description
+//		新建一个rangeFenwick区间修改树状数组容器并返回
+//		以切片es中的元素作为初始序列,在O(n)时间内建树
+//		群的选取方式同New,但所用的群必须提供数乘函数,否则返回nil
+//		es为空且未传入群时同New,返回群为nil的空树状数组
+//@receiver		nil
+//@param    	es			[]interface{}			初始序列
+//@param    	gs			...Group				聚合所用的群集
+//@return    	rf        	*RangeFenwick			新建的rangeFenwick指针
+func NewRange(es []interface{}, gs ...Group) (rf *RangeFenwick) {
+	var e interface{}
+	if len(es) > 0 {
+		e = es[0]
+	}
+	g := getGroup(e, gs)
+	if g == nil && len(es) == 0 && len(gs) == 0 {
+		return &RangeFenwick{
+			d:     build(nil, nil),
+			id:    build(nil, nil),
+			size:  0,
+			group: nil,
+			mutex: sync.Mutex{},
+		}
+	}
+	if g == nil || g.Scale == nil {
+		return nil
+	}
+	//求差分数组及i*d[i]
+	ds := make([]interface{}, len(es))
+	ids := make([]interface{}, len(es))
+	pre := g.Identity
+	for i := range es {
+		ds[i] = g.Op(es[i], g.Inverse(pre))
+		ids[i] = g.Scale(ds[i], i+1)
+		pre = es[i]
+	}
+	return &RangeFenwick{
+		d:     build(ds, g),
+		id:    build(ids, g),
+		size:  len(es),
+		group: g,
+		mutex: sync.Mutex{},
+	}
+}
+
+//@title    NewRangeFromVector
+//@description
+//		新建一个rangeFenwick区间修改树状数组容器并返回
+//		以vector中的元素作为初始序列,在O(n)时间内建树
+//		群的选取方式同NewRange
+//@receiver		nil
+//@param    	v			*vector.Vector			初始序列
+//@param    	gs			...Group				聚合所用的群集
+//@return    	rf        	*RangeFenwick			新建的rangeFenwick指针
+func NewRangeFromVector(v *vector.Vector, gs ...Group) (rf *RangeFenwick) {
+	return NewRange(toSlice(v), gs...)
+}
+
+//@title    prefix
+//@description
+//		以rangeFenwick区间修改树状数组做接收者
+//		返回序列中前p个元素的聚合值
+//@receiver		rf			*RangeFenwick			接受者rangeFenwick的指针
+//@param    	p			int						前缀长度
+//@return    	sum			interface{}				前缀聚合值
+func (rf *RangeFenwick) prefix(p int) (sum interface{}) {
+	g := rf.group
+	return g.Op(g.Scale(prefix(rf.d, p, g), p+1), g.Inverse(prefix(rf.id, p, g)))
+}
+
+//@title    Iterator
+//@description
+//		以rangeFenwick区间修改树状数组做接收者
+//		将该树状数组所表示的序列按顺序放入迭代器中
+//		先逆序撤销建树过程还原出差分数组,再求前缀和,时间复杂度为O(n)
+//@receiver		rf			*RangeFenwick			接受者rangeFenwick的指针
+//@param    	nil
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (rf *RangeFenwick) Iterator() (i *Iterator.Iterator) {
+	if rf == nil {
+		return nil
+	}
+	rf.mutex.Lock()
+	g := rf.group
+	if g == nil {
+		rf.mutex.Unlock()
+		return Iterator.New(&[]interface{}{})
+	}
+	ds := make([]interface{}, len(rf.d))
+	copy(ds, rf.d)
+	for i := rf.size; i >= 1; i-- {
+		if j := i + lowbit(i); j <= rf.size {
+			ds[j] = g.Op(ds[j], g.Inverse(ds[i]))
+		}
+	}
+	es := make([]interface{}, rf.size)
+	pre := g.Identity
+	for i := 1; i <= rf.size; i++ {
+		pre = g.Op(pre, ds[i])
+		es[i-1] = pre
+	}
+	rf.mutex.Unlock()
+	return Iterator.New(&es)
+}
+
+//@title    Size
+//@description
+//		以rangeFenwick区间修改树状数组做接收者
+//		返回该树状数组所表示序列的长度
+//		如果容器为nil返回0
+//@receiver		rf			*RangeFenwick			接受者rangeFenwick的指针
+//@param    	nil
+//@return    	num        	int						序列长度
+func (rf *RangeFenwick) Size() (num int) {
+	if rf == nil {
+		return 0
+	}
+	return rf.size
+}
+
+//@title    Empty
+//@description
+//		以rangeFenwick区间修改树状数组做接收者
+//		判断该树状数组所表示的序列是否为空
+//		如果容器不存在,返回true
+//@receiver		rf			*RangeFenwick			接受者rangeFenwick的指针
+//@param    	nil
+//@return    	b			bool					该容器是空的吗?
+func (rf *RangeFenwick) Empty() (b bool) {
+	if rf == nil {
+		return true
+	}
+	return rf.size == 0
+}
+
+//@title    At
+//@description
+//		以rangeFenwick区间修改树状数组做接收者
+//		返回序列中下标idx处的元素,即差分数组的前缀和
+//		若下标越界则返回nil
+//@receiver		rf			*RangeFenwick			接受者rangeFenwick的指针
+//@param    	idx			int						下标
+//@return    	e			interface{}				该下标处的元素
+func (rf *RangeFenwick) At(idx int) (e interface{}) {
+	if rf == nil {
+		return nil
+	}
+	rf.mutex.Lock()
+	if idx < 0 || idx >= rf.size {
+		rf.mutex.Unlock()
+		return nil
+	}
+	e = prefix(rf.d, idx+1, rf.group)
+	rf.mutex.Unlock()
+	return e
+}
+
+//@title    Add
+//@description
+//		以rangeFenwick区间修改树状数组做接收者
+//		将序列中[l,r)区间内的元素全部增加delta
+//		即差分数组在l+1处增加delta,在r+1处减少delta
+//		若区间不合法则增加失败
+//@receiver		rf			*RangeFenwick			接受者rangeFenwick的指针
+//@param    	l			int						区间左端点,包含
+//@param    	r			int						区间右端点,不包含
+//@param    	delta		interface{}				增加的值
+//@return    	b			bool					增加成功?
+func (rf *RangeFenwick) Add(l, r int, delta interface{}) (b bool) {
+	if rf == nil {
+		return false
+	}
+	rf.mutex.Lock()
+	if l < 0 || r > rf.size || l > r {
+		rf.mutex.Unlock()
+		return false
+	}
+	if l == r {
+		rf.mutex.Unlock()
+		return true
+	}
+	g := rf.group
+	neg := g.Inverse(delta)
+	add(rf.d, l+1, delta, g)
+	add(rf.id, l+1, g.Scale(delta, l+1), g)
+	add(rf.d, r+1, neg, g)
+	add(rf.id, r+1, g.Scale(neg, r+1), g)
+	rf.mutex.Unlock()
+	return true
+}
+
+//@title    PrefixSum
+//@description
+//		以rangeFenwick区间修改树状数组做接收者
+//		返回序列中[0,idx)区间内元素的聚合值
+//		若idx越界则返回nil
+//@receiver		rf			*RangeFenwick			接受者rangeFenwick的指针
+//@param    	idx			int						前缀长度
+//@return    	sum			interface{}				前缀聚合值
+func (rf *RangeFenwick) PrefixSum(idx int) (sum interface{}) {
+	if rf == nil {
+		return nil
+	}
+	rf.mutex.Lock()
+	if idx < 0 || idx > rf.size || rf.group == nil {
+		rf.mutex.Unlock()
+		return nil
+	}
+	sum = rf.prefix(idx)
+	rf.mutex.Unlock()
+	return sum
+}
+
+//@title    Sum
+//@description
+//		以rangeFenwick区间修改树状数组做接收者
+//		返回序列中[l,r)区间内元素的聚合值
+//		若区间不合法则返回nil
+//@receiver		rf			*RangeFenwick			接受者rangeFenwick的指针
+//@param    	l			int						区间左端点,包含
+//@param    	r			int						区间右端点,不包含
+//@return    	sum			interface{}				区间聚合值
+func (rf *RangeFenwick) Sum(l, r int) (sum interface{}) {
+	if rf == nil {
+		return nil
+	}
+	rf.mutex.Lock()
+	if l < 0 || r > rf.size || l > r || rf.group == nil {
+		rf.mutex.Unlock()
+		return nil
+	}
+	sum = rf.group.Op(rf.prefix(r), rf.group.Inverse(rf.prefix(l)))
+	rf.mutex.Unlock()
+	return sum
+}
@@ -0,0 +1,493 @@
+package graph
+
+//@Title		graph
+//@Description
+//		流网络-Flow Network
+//		以残量网络的邻接表形式实现,每条边同时保存其反向边的下标
+//		顶点的映射方式与graph一致
+//		提供Dinic最大流、最小割以及基于SPFA的最小费用最大流
+//		每次求解前会清空上一次求解得到的流量,求解后可通过Flow查询每条边上的流量
+//		容量和费用均为float64,比较时使用eps消除浮点误差
+//		使用互斥锁实现并发控制
+
+import (
+	"github.com/hlccd/goSTL/algorithm"
+	"github.com/hlccd/goSTL/data_structure/hashMap"
+	"github.com/hlccd/goSTL/data_structure/queue"
+	"math"
+	"sync"
+)
+
+//浮点误差容限
+const eps = 1e-9
+
+//flowEdge残量网络中的边结构体
+//rev为反向边在终点邻接表中的下标
+//isRev表示该边是否为求解时使用的反向边
+type flowEdge struct {
+	to    int     //指向的顶点编号
+	rev   int     //反向边的下标
+	cap   float64 //容量
+	flow  float64 //当前流量
+	cost  float64 //单位流量的费用
+	isRev bool    //是否为反向边
+}
+
+//flowNetwork流网络结构体
+//index保存顶点到编号的映射,vertices保存编号到顶点的映射
+//adj为残量网络的邻接表
+type FlowNetwork struct {
+	index    indexer            //顶点到编号的映射
+	vertices []interface{}      //编号到顶点的映射
+	adj      [][]flowEdge       //残量网络的邻接表
+	hash     []algorithm.Hasher //创建时传入的hash函数集
+	mutex    sync.Mutex         //并发控制锁
+}
+
+//flowNetwork流网络容器接口
+//存放了flowNetwork流网络可使用的函数
+//对应函数介绍见下方
+type flowNetworker interface {
+	Size() (num int)                                               //返回顶点的数量
+	Clear()                                                        //清空流网络
+	Empty() (b bool)                                               //判断流网络中是否有顶点
+	AddVertex(v interface{}) (b bool)                              //增加顶点v
+	AddEdge(u, v interface{}, capacity float64) (b bool)           //增加一条从u到v容量为capacity的边
+	AddCostEdge(u, v interface{}, capacity, cost float64) (b bool) //增加一条从u到v容量为capacity费用为cost的边
+	MaxFlow(s, t interface{}) (flow float64)                       //返回从s到t的最大流
+	MinCut(s, t interface{}) (flow float64, cut []Edge)            //返回从s到t的最小割
+	MinCostMaxFlow(s, t interface{}) (flow, cost float64, b bool)  //返回从s到t的最小费用最大流
+	Flow(u, v interface{}) (flow float64)                          //返回上一次求解后从u到v的边上的流量
+}
+
+//@title    NewFlowNetwork
+//@description
+//		新建一个flowNetwork流网络容器并返回
+//		初始时不含有顶点和边
+//		若有传入的hash函数,则将传入的第一个hash函数设为顶点的hash函数
+//@receiver		nil
+//@param    	hash		...algorithm.Hasher		顶点的hash函数集
+//@return    	fn        	*FlowNetwork			新建的flowNetwork指针
+func NewFlowNetwork(hash ...algorithm.Hasher) (fn *FlowNetwork) {
+	return &FlowNetwork{
+		index:    hashMap.New(hash...),
+		vertices: make([]interface{}, 0),
+		adj:      make([][]flowEdge, 0),
+		hash:     hash,
+		mutex:    sync.Mutex{},
+	}
+}
+
+//@title    id
+//@description
+//		以flowNetwork流网络做接收者
+//		返回顶点v的编号
+//		若v不存在且isInsert为真则为其分配新的编号,否则返回-1
+//@receiver		fn			*FlowNetwork			接受者flowNetwork的指针
+//@param    	v			interface{}				顶点
+//@param    	isInsert	bool					不存在时是否插入?
+//@return    	x			int						顶点编号
+func (fn *FlowNetwork) id(v interface{}, isInsert bool) (x int) {
+	if v == nil {
+		return -1
+	}
+	if x, ok := fn.index.Get(v).(int); ok {
+		return x
+	}
+	if !isInsert || !fn.index.Insert(v, len(fn.vertices)) {
+		return -1
+	}
+	fn.vertices = append(fn.vertices, v)
+	fn.adj = append(fn.adj, make([]flowEdge, 0))
+	return len(fn.vertices) - 1
+}
+
+//@title    reset
+//@description
+//		以flowNetwork流网络做接收者
+//		清空所有边上的流量
+//@receiver		fn			*FlowNetwork			接受者flowNetwork的指针
+//@param    	nil
+//@return    	nil
+func (fn *FlowNetwork) reset() {
+	for u := range fn.adj {
+		for i := range fn.adj[u] {
+			fn.adj[u][i].flow = 0
+		}
+	}
+}
+
+//@title    push
+//@description
+//		以flowNetwork流网络做接收者
+//		沿顶点u的第i条边推送f单位的流量,同时更新其反向边
+//@receiver		fn			*FlowNetwork			接受者flowNetwork的指针
+//@param    	u			int						起点编号
+//@param    	i			int						边在邻接表中的下标
+//@param    	f			float64					推送的流量
+//@return    	nil
+func (fn *FlowNetwork) push(u, i int, f float64) {
+	e := &fn.adj[u][i]
+	e.flow += f
+	fn.adj[e.to][e.rev].flow -= f
+}
+
+//@title    Size
+//@description
+//		以flowNetwork流网络做接收者
+//		返回流网络中顶点的数量
+//		如果容器为nil返回0
+//@receiver		fn			*FlowNetwork			接受者flowNetwork的指针
+//@param    	nil
+//@return    	num        	int						顶点数量
+func (fn *FlowNetwork) Size() (num int) {
+	if fn == nil {
+		return 0
+	}
+	return len(fn.vertices)
+}
+
+//@title    Clear
+//@description
+//		以flowNetwork流网络做接收者
+//		将流网络中的所有顶点和边清空
+//@receiver		fn			*FlowNetwork			接受者flowNetwork的指针
+//@param    	nil
+//@return    	nil
+func (fn *FlowNetwork) Clear() {
+	if fn == nil {
+		return
+	}
+	fn.mutex.Lock()
+	fn.index = hashMap.New(fn.hash...)
+	fn.vertices = make([]interface{}, 0)
+	fn.adj = make([][]flowEdge, 0)
+	fn.mutex.Unlock()
+}
+
+//@title    Empty
+//@description
+//		以flowNetwork流网络做接收者
+//		判断流网络中是否含有顶点
+//		如果容器不存在,返回true
+//@receiver		fn			*FlowNetwork			接受者flowNetwork的指针
+//@param    	nil
+//@return    	b			bool					该容器是空的吗?
+func (fn *FlowNetwork) Empty() (b bool) {
+	if fn == nil {
+		return true
+	}
+	return len(fn.vertices) == 0
+}
+
+//@title    AddVertex
+//@description
+//		以flowNetwork流网络做接收者
+//		向流网络中增加顶点v
+//		若v已存在或无法对v进行hash则返回false
+//@receiver		fn			*FlowNetwork			接受者flowNetwork的指针
+//@param    	v			interface{}				待增加的顶点
+//@return    	b			bool					增加成功?
+func (fn *FlowNetwork) AddVertex(v interface{}) (b bool) {
+	if fn == nil {
+		return false
+	}
+	fn.mutex.Lock()
+	if fn.id(v, false) != -1 {
+		fn.mutex.Unlock()
+		return false
+	}
+	b = fn.id(v, true) != -1
+	fn.mutex.Unlock()
+	return b
+}
+
+//@title    AddEdge
+//@description
+//		以flowNetwork流网络做接收者
+//		向流网络中增加一条从u到v容量为capacity费用为0的边
+//		若顶点不存在则先将其加入流网络中
+//@receiver		fn			*FlowNetwork			接受者flowNetwork的指针
+//@param    	u			interface{}				起点
+//@param    	v			interface{}				终点
+//@param    	capacity	float64					容量
+//@return    	b			bool					增加成功?
+func (fn *FlowNetwork) AddEdge(u, v interface{}, capacity float64) (b bool) {
+	return fn.AddCostEdge(u, v, capacity, 0)
+}
+
+//@title    AddCostEdge
+//@description
+//		以flowNetwork流网络做接收者
+//		向流网络中增加一条从u到v容量为capacity、单位流量费用为cost的边
+//		同时增加一条容量为0、费用为-cost的反向边
+//		若顶点不存在则先将其加入流网络中,若容量为负则返回false
+//@receiver		fn			*FlowNetwork			接受者flowNetwork的指针
+//@param    	u			interface{}				起点
+//@param    	v			interface{}				终点
+//@param    	capacity	float64					容量
+//@param    	cost		float64					单位流量的费用
+//@return    	b			bool					增加成功?
+func (fn *FlowNetwork) AddCostEdge(u, v interface{}, capacity, cost float64) (b bool) {
+	if fn == nil || capacity < 0 {
+		return false
+	}
+	fn.mutex.Lock()
+	x, y := fn.id(u, true), fn.id(v, true)
+	if x == -1 || y == -1 {
+		fn.mutex.Unlock()
+		return false
+	}
+	//自环时反向边的下标需要计入正向边本身
+	rx, ry := len(fn.adj[y]), len(fn.adj[x])
+	if x == y {
+		rx++
+	}
+	fn.adj[x] = append(fn.adj[x], flowEdge{to: y, rev: rx, cap: capacity, cost: cost})
+	fn.adj[y] = append(fn.adj[y], flowEdge{to: x, rev: ry, cap: 0, cost: -cost, isRev: true})
+	fn.mutex.Unlock()
+	return true
+}
+
+//@title    dinic
+//@description
+//		以flowNetwork流网络做接收者
+//		使用Dinic算法求从编号s到编号t的最大流
+//		每轮先用广度优先遍历对残量网络分层,再用深度优先遍历在分层图上寻找阻塞流
+//		时间复杂度为O(V^2 E)
+//@receiver		fn			*FlowNetwork			接受者flowNetwork的指针
+//@param    	s			int						源点编号
+//@param    	t			int						汇点编号
+//@return    	flow		float64					最大流
+func (fn *FlowNetwork) dinic(s, t int) (flow float64) {
+	fn.reset()
+	n := len(fn.vertices)
+	level := make([]int, n)
+	iter := make([]int, n)
+	//bfs对残量网络分层,返回汇点是否可达
+	bfs := func() bool {
+		for i := range level {
+			level[i] = -1
+		}
+		level[s] = 0
+		q := queue.New()
+		q.Push(s)
+		for !q.Empty() {
+			u := q.Pop().(int)
+			for _, e := range fn.adj[u] {
+				if level[e.to] == -1 && e.cap-e.flow > eps {
+					level[e.to] = level[u] + 1
+					q.Push(e.to)
+				}
+			}
+		}
+		return level[t] != -1
+	}
+	//dfs在分层图上从u出发向汇点推送至多f单位的流量
+	var dfs func(u int, f float64) float64
+	dfs = func(u int, f float64) float64 {
+		if u == t {
+			return f
+		}
+		for ; iter[u] < len(fn.adj[u]); iter[u]++ {
+			e := fn.adj[u][iter[u]]
+			if level[e.to] == level[u]+1 && e.cap-e.flow > eps {
+				if d := dfs(e.to, math.Min(f, e.cap-e.flow)); d > eps {
+					fn.push(u, iter[u], d)
+					return d
+				}
+			}
+		}
+		return 0
+	}
+	for bfs() {
+		for i := range iter {
+			iter[i] = 0
+		}
+		for f := dfs(s, math.Inf(1)); f > eps; f = dfs(s, math.Inf(1)) {
+			flow += f
+		}
+	}
+	return flow
+}
+
+//@title    MaxFlow
+//@description
+//		以flowNetwork流网络做接收者
+//		使用Dinic算法求从s到t的最大流
+//		若顶点不存在或s与t相同则返回0
+//@receiver		fn			*FlowNetwork			接受者flowNetwork的指针
+//@param    	s			interface{}				源点
+//@param    	t			interface{}				汇点
+//@return    	flow		float64					最大流
+func (fn *FlowNetwork) MaxFlow(s, t interface{}) (flow float64) {
+	if fn == nil {
+		return 0
+	}
+	fn.mutex.Lock()
+	x, y := fn.id(s, false), fn.id(t, false)
+	if x == -1 || y == -1 || x == y {
+		fn.mutex.Unlock()
+		return 0
+	}
+	flow = fn.dinic(x, y)
+	fn.mutex.Unlock()
+	return flow
+}
+
+//@title    MinCut
+//@description
+//		以flowNetwork流网络做接收者
+//		求从s到t的最小割
+//		先求最大流,再从s出发在残量网络中遍历,所有从可达顶点指向不可达顶点的边即构成最小割
+//		割中每条边的Weight为其容量,所有割边容量之和等于最大流
+//		若顶点不存在或s与t相同则返回0和nil
+//@receiver		fn			*FlowNetwork			接受者flowNetwork的指针
+//@param    	s			interface{}				源点
+//@param    	t			interface{}				汇点
+//@return    	flow		float64					最大流
+//@return    	cut			[]Edge					最小割中的边
+func (fn *FlowNetwork) MinCut(s, t interface{}) (flow float64, cut []Edge) {
+	if fn == nil {
+		return 0, nil
+	}
+	fn.mutex.Lock()
+	x, y := fn.id(s, false), fn.id(t, false)
+	if x == -1 || y == -1 || x == y {
+		fn.mutex.Unlock()
+		return 0, nil
+	}
+	flow = fn.dinic(x, y)
+	reach := make([]bool, len(fn.vertices))
+	reach[x] = true
+	q := queue.New()
+	q.Push(x)
+	for !q.Empty() {
+		u := q.Pop().(int)
+		for _, e := range fn.adj[u] {
+			if !reach[e.to] && e.cap-e.flow > eps {
+				reach[e.to] = true
+				q.Push(e.to)
+			}
+		}
+	}
+	cut = make([]Edge, 0)
+	for u := range fn.adj {
+		if !reach[u] {
+			continue
+		}
+		for _, e := range fn.adj[u] {
+			if !e.isRev && !reach[e.to] {
+				cut = append(cut, Edge{From: fn.vertices[u], To: fn.vertices[e.to], Weight: e.cap})
+			}
+		}
+	}
+	fn.mutex.Unlock()
+	return flow, cut
+}
+
+//@title    MinCostMaxFlow
+//@description
+//		以flowNetwork流网络做接收者
+//		求从s到t的最小费用最大流
+//		使用连续最短路算法,每轮用SPFA在残量网络中以费用为边权求最短路并沿其增广
+//		允许存在负费用的边,但若残量网络中出现负费用环则返回false
+//		若顶点不存在或s与t相同则返回false
+//@receiver		fn			*FlowNetwork			接受者flowNetwork的指针
+//@param    	s			interface{}				源点
+//@param    	t			interface{}				汇点
+//@return    	flow		float64					最大流
+//@return    	cost		float64					该最大流的最小费用
+//@return    	b			bool					求解成功?
+func (fn *FlowNetwork) MinCostMaxFlow(s, t interface{}) (flow, cost float64, b bool) {
+	if fn == nil {
+		return 0, 0, false
+	}
+	fn.mutex.Lock()
+	x, y := fn.id(s, false), fn.id(t, false)
+	if x == -1 || y == -1 || x == y {
+		fn.mutex.Unlock()
+		return 0, 0, false
+	}
+	fn.reset()
+	n := len(fn.vertices)
+	dist := make([]float64, n)
+	inQueue := make([]bool, n)
+	times := make([]int, n) //每个顶点入队的次数,超过n次说明存在负环
+	prevV := make([]int, n) //最短路上的前驱顶点
+	prevE := make([]int, n) //最短路上前驱顶点的出边下标
+	for {
+		for i := 0; i < n; i++ {
+			dist[i], inQueue[i], times[i], prevV[i] = math.Inf(1), false, 0, -1
+		}
+		dist[x] = 0
+		q := queue.New()
+		q.Push(x)
+		inQueue[x] = true
+		for !q.Empty() {
+			u := q.Pop().(int)
+			inQueue[u] = false
+			for i, e := range fn.adj[u] {
+				if e.cap-e.flow > eps && dist[u]+e.cost < dist[e.to]-eps {
+					dist[e.to], prevV[e.to], prevE[e.to] = dist[u]+e.cost, u, i
+					if !inQueue[e.to] {
+						if times[e.to]++; times[e.to] > n {
+							fn.mutex.Unlock()
+							return 0, 0, false
+						}
+						inQueue[e.to] = true
+						q.Push(e.to)
+					}
+				}
+			}
+		}
+		if math.IsInf(dist[y], 1) {
+			fn.mutex.Unlock()
+			return flow, cost, true
+		}
+		//沿最短路求可增广的流量
+		f := math.Inf(1)
+		for v := y; v != x; v = prevV[v] {
+			e := fn.adj[prevV[v]][prevE[v]]
+			f = math.Min(f, e.cap-e.flow)
+		}
+		if math.IsInf(f, 1) {
+			//存在无限容量且费用为负的增广路
+			fn.mutex.Unlock()
+			return 0, 0, false
+		}
+		for v := y; v != x; v = prevV[v] {
+			fn.push(prevV[v], prevE[v], f)
+		}
+		flow += f
+		cost += f * dist[y]
+	}
+}
+
+//@title    Flow
+//@description
+//		以flowNetwork流网络做接收者
+//		返回上一次求解后所有从u到v的边上的流量之和
+//		若顶点不存在则返回0
+//@receiver		fn			*FlowNetwork			接受者flowNetwork的指针
+//@param    	u			interface{}				起点
+//@param    	v			interface{}				终点
+//@return    	flow		float64					流量
+func (fn *FlowNetwork) Flow(u, v interface{}) (flow float64) {
+	if fn == nil {
+		return 0
+	}
+	fn.mutex.Lock()
+	x, y := fn.id(u, false), fn.id(v, false)
+	if x == -1 || y == -1 {
+		fn.mutex.Unlock()
+		return 0
+	}
+	for _, e := range fn.adj[x] {
+		if !e.isRev && e.to == y {
+			flow += e.flow
+		}
+	}
+	fn.mutex.Unlock()
+	return flow
+}
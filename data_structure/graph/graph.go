@@ -0,0 +1,472 @@
+package graph
+
+//@Title		graph
+//@Description
+//		图-Graph
+//		以邻接表的形式实现,可以是有向图或无向图,边上可以带有权重,不带权重的边视为权重为1
+//		顶点可以是任意key,通过hashMap将每个顶点映射为一个从0开始的编号
+//		顶点的hash方式与hashMap一致,若为基本数据类型可不用传入hash函数,否则需要传入自定义hash函数
+//		与hashMap相同,同一个图中的顶点应为同一类型
+//		允许存在重边和自环
+//		在此基础上提供遍历、最短路、拓扑排序和连通分量等算法
+//		使用互斥锁实现并发控制
+
+import (
+	"github.com/hlccd/goSTL/algorithm"
+	"github.com/hlccd/goSTL/data_structure/hashMap"
+	"github.com/hlccd/goSTL/utils/iterator"
+	"sync"
+)
+
+//edge邻接表中的边结构体
+//保存该边指向的顶点编号和权重
+type edge struct {
+	to     int     //指向的顶点编号
+	weight float64 //权重
+}
+
+//Edge边结构体
+//From和To为边的两个顶点,无向图中两者没有先后之分
+//Weight为该边的权重
+type Edge struct {
+	From   interface{} //起点
+	To     interface{} //终点
+	Weight float64     //权重
+}
+
+//indexer索引接口
+//即hashMap所提供的函数中本结构所需的部分
+type indexer interface {
+	Insert(key, value interface{}) (b bool)
+	Get(key interface{}) (value interface{})
+}
+
+//graph图结构体
+//index保存顶点到编号的映射,vertices保存编号到顶点的映射
+//adj为每个顶点的出边列表,无向图中每条边会同时出现在两个顶点的列表中,自环只出现一次
+//同时保存图中边的数量
+type Graph struct {
+	directed bool               //是否为有向图
+	index    indexer            //顶点到编号的映射
+	vertices []interface{}      //编号到顶点的映射
+	adj      [][]edge           //邻接表
+	edges    int                //边的数量
+	hash     []algorithm.Hasher //创建时传入的hash函数集
+	mutex    sync.Mutex         //并发控制锁
+}
+
+//graph图容器接口
+//存放了graph图可使用的函数
+//对应函数介绍见下方
+type grapher interface {
+	Iterator() (i *Iterator.Iterator)                     //返回包含所有顶点的迭代器
+	Size() (num int)                                      //返回图中顶点的数量
+	EdgeCount() (num int)                                 //返回图中边的数量
+	Directed() (b bool)                                   //判断是否为有向图
+	Clear()                                               //清空图
+	Empty() (b bool)                                      //判断图中是否有顶点
+	AddVertex(v interface{}) (b bool)                     //增加顶点v
+	HasVertex(v interface{}) (b bool)                     //判断顶点v是否存在
+	AddEdge(u, v interface{}) (b bool)                    //增加一条从u到v权重为1的边
+	AddWeightedEdge(u, v interface{}, w float64) (b bool) //增加一条从u到v权重为w的边
+	EraseEdge(u, v interface{}) (b bool)                  //删除一条从u到v的边
+	HasEdge(u, v interface{}) (b bool)                    //判断是否存在从u到v的边
+	Weight(u, v interface{}) (w float64, b bool)          //返回从u到v的边中的最小权重
+	Neighbors(v interface{}) (vs []interface{})           //返回顶点v的所有出边指向的顶点
+	Edges() (es []Edge)                                   //返回图中的所有边
+}
+
+//@title    New
+//@description
+//		新建一个graph图容器并返回
+//		初始时不含有顶点和边
+//		若有传入的hash函数,则将传入的第一个hash函数设为顶点的hash函数
+//@receiver		nil
+//@param    	directed	bool					是否为有向图?
+//@param    	hash		...algorithm.Hasher		顶点的hash函数集
+//@return    	g        	*Graph					新建的graph指针
+func New(directed bool, hash ...algorithm.Hasher) (g *Graph) {
+	return &Graph{
+		directed: directed,
+		index:    hashMap.New(hash...),
+		vertices: make([]interface{}, 0),
+		adj:      make([][]edge, 0),
+		edges:    0,
+		hash:     hash,
+		mutex:    sync.Mutex{},
+	}
+}
+
+//@title    NewDirected
+//@description
+//		新建一个有向图并返回
+//@receiver		nil
+//@param    	hash		...algorithm.Hasher		顶点的hash函数集
+//@return    	g        	*Graph					新建的graph指针
+func NewDirected(hash ...algorithm.Hasher) (g *Graph) {
+	return New(true, hash...)
+}
+
+//@title    NewUndirected
+//@description
+//		新建一个无向图并返回
+//@receiver		nil
+//@param    	hash		...algorithm.Hasher		顶点的hash函数集
+//@return    	g        	*Graph					新建的graph指针
+func NewUndirected(hash ...algorithm.Hasher) (g *Graph) {
+	return New(false, hash...)
+}
+
+//@title    id
+//@description
+//		以graph图做接收者
+//		返回顶点v的编号
+//		若v不存在且isInsert为真则为其分配新的编号,否则返回-1
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	v			interface{}				顶点
+//@param    	isInsert	bool					不存在时是否插入?
+//@return    	x			int						顶点编号
+func (g *Graph) id(v interface{}, isInsert bool) (x int) {
+	if v == nil {
+		return -1
+	}
+	if x, ok := g.index.Get(v).(int); ok {
+		return x
+	}
+	if !isInsert || !g.index.Insert(v, len(g.vertices)) {
+		return -1
+	}
+	g.vertices = append(g.vertices, v)
+	g.adj = append(g.adj, make([]edge, 0))
+	return len(g.vertices) - 1
+}
+
+//@title    addEdge
+//@description
+//		以graph图做接收者
+//		增加一条从编号u到编号v权重为w的边
+//		无向图中同时增加反向边,自环只增加一次
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	u			int						起点编号
+//@param    	v			int						终点编号
+//@param    	w			float64					权重
+//@return    	nil
+func (g *Graph) addEdge(u, v int, w float64) {
+	g.adj[u] = append(g.adj[u], edge{to: v, weight: w})
+	if !g.directed && u != v {
+		g.adj[v] = append(g.adj[v], edge{to: u, weight: w})
+	}
+	g.edges++
+}
+
+//@title    eraseEdge
+//@description
+//		以graph图做接收者
+//		从编号u的出边列表中删除一条指向编号v的边
+//		若不存在则返回false
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	u			int						起点编号
+//@param    	v			int						终点编号
+//@return    	b			bool					删除成功?
+func (g *Graph) eraseEdge(u, v int) (b bool) {
+	for i, e := range g.adj[u] {
+		if e.to == v {
+			g.adj[u] = append(g.adj[u][:i], g.adj[u][i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+//@title    Iterator
+//@description
+//		以graph图做接收者
+//		将图中的所有顶点按加入顺序放入迭代器中
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	nil
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (g *Graph) Iterator() (i *Iterator.Iterator) {
+	if g == nil {
+		return nil
+	}
+	g.mutex.Lock()
+	es := make([]interface{}, len(g.vertices))
+	copy(es, g.vertices)
+	g.mutex.Unlock()
+	return Iterator.New(&es)
+}
+
+//@title    Size
+//@description
+//		以graph图做接收者
+//		返回图中顶点的数量
+//		如果容器为nil返回0
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	nil
+//@return    	num        	int						顶点数量
+func (g *Graph) Size() (num int) {
+	if g == nil {
+		return 0
+	}
+	return len(g.vertices)
+}
+
+//@title    EdgeCount
+//@description
+//		以graph图做接收者
+//		返回图中边的数量,无向图中每条边只计一次
+//		如果容器为nil返回0
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	nil
+//@return    	num        	int						边的数量
+func (g *Graph) EdgeCount() (num int) {
+	if g == nil {
+		return 0
+	}
+	return g.edges
+}
+
+//@title    Directed
+//@description
+//		以graph图做接收者
+//		判断该图是否为有向图
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	nil
+//@return    	b			bool					是有向图吗?
+func (g *Graph) Directed() (b bool) {
+	if g == nil {
+		return false
+	}
+	return g.directed
+}
+
+//@title    Clear
+//@description
+//		以graph图做接收者
+//		将图中的所有顶点和边清空
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	nil
+//@return    	nil
+func (g *Graph) Clear() {
+	if g == nil {
+		return
+	}
+	g.mutex.Lock()
+	g.index = hashMap.New(g.hash...)
+	g.vertices = make([]interface{}, 0)
+	g.adj = make([][]edge, 0)
+	g.edges = 0
+	g.mutex.Unlock()
+}
+
+//@title    Empty
+//@description
+//		以graph图做接收者
+//		判断图中是否含有顶点
+//		如果容器不存在,返回true
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	nil
+//@return    	b			bool					该容器是空的吗?
+func (g *Graph) Empty() (b bool) {
+	if g == nil {
+		return true
+	}
+	return len(g.vertices) == 0
+}
+
+//@title    AddVertex
+//@description
+//		以graph图做接收者
+//		向图中增加顶点v
+//		若v已存在或无法对v进行hash则返回false
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	v			interface{}				待增加的顶点
+//@return    	b			bool					增加成功?
+func (g *Graph) AddVertex(v interface{}) (b bool) {
+	if g == nil {
+		return false
+	}
+	g.mutex.Lock()
+	if g.id(v, false) != -1 {
+		g.mutex.Unlock()
+		return false
+	}
+	b = g.id(v, true) != -1
+	g.mutex.Unlock()
+	return b
+}
+
+//@title    HasVertex
+//@description
+//		以graph图做接收者
+//		判断图中是否存在顶点v
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	v			interface{}				待判断的顶点
+//@return    	b			bool					存在吗?
+func (g *Graph) HasVertex(v interface{}) (b bool) {
+	if g == nil {
+		return false
+	}
+	g.mutex.Lock()
+	b = g.id(v, false) != -1
+	g.mutex.Unlock()
+	return b
+}
+
+//@title    AddEdge
+//@description
+//		以graph图做接收者
+//		向图中增加一条从u到v权重为1的边
+//		若顶点不存在则先将其加入图中
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	u			interface{}				起点
+//@param    	v			interface{}				终点
+//@return    	b			bool					增加成功?
+func (g *Graph) AddEdge(u, v interface{}) (b bool) {
+	return g.AddWeightedEdge(u, v, 1)
+}
+
+//@title    AddWeightedEdge
+//@description
+//		以graph图做接收者
+//		向图中增加一条从u到v权重为w的边
+//		若顶点不存在则先将其加入图中
+//		无向图中该边同时可以从v到达u
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	u			interface{}				起点
+//@param    	v			interface{}				终点
+//@param    	w			float64					权重
+//@return    	b			bool					增加成功?
+func (g *Graph) AddWeightedEdge(u, v interface{}, w float64) (b bool) {
+	if g == nil {
+		return false
+	}
+	g.mutex.Lock()
+	x, y := g.id(u, true), g.id(v, true)
+	if x == -1 || y == -1 {
+		g.mutex.Unlock()
+		return false
+	}
+	g.addEdge(x, y, w)
+	g.mutex.Unlock()
+	return true
+}
+
+//@title    EraseEdge
+//@description
+//		以graph图做接收者
+//		从图中删除一条从u到v的边,若存在重边则只删除最早加入的一条
+//		若不存在这样的边则返回false
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	u			interface{}				起点
+//@param    	v			interface{}				终点
+//@return    	b			bool					删除成功?
+func (g *Graph) EraseEdge(u, v interface{}) (b bool) {
+	if g == nil {
+		return false
+	}
+	g.mutex.Lock()
+	x, y := g.id(u, false), g.id(v, false)
+	if x == -1 || y == -1 || !g.eraseEdge(x, y) {
+		g.mutex.Unlock()
+		return false
+	}
+	if !g.directed && x != y {
+		g.eraseEdge(y, x)
+	}
+	g.edges--
+	g.mutex.Unlock()
+	return true
+}
+
+//@title    HasEdge
+//@description
+//		以graph图做接收者
+//		判断图中是否存在从u到v的边
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	u			interface{}				起点
+//@param    	v			interface{}				终点
+//@return    	b			bool					存在吗?
+func (g *Graph) HasEdge(u, v interface{}) (b bool) {
+	_, b = g.Weight(u, v)
+	return b
+}
+
+//@title    Weight
+//@description
+//		以graph图做接收者
+//		返回从u到v的边的权重,若存在重边则返回其中的最小权重
+//		若不存在这样的边则返回false
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	u			interface{}				起点
+//@param    	v			interface{}				终点
+//@return    	w			float64					权重
+//@return    	b			bool					存在吗?
+func (g *Graph) Weight(u, v interface{}) (w float64, b bool) {
+	if g == nil {
+		return 0, false
+	}
+	g.mutex.Lock()
+	x, y := g.id(u, false), g.id(v, false)
+	if x == -1 || y == -1 {
+		g.mutex.Unlock()
+		return 0, false
+	}
+	for _, e := range g.adj[x] {
+		if e.to == y && (!b || e.weight < w) {
+			w, b = e.weight, true
+		}
+	}
+	g.mutex.Unlock()
+	return w, b
+}
+
+//@title    Neighbors
+//@description
+//		以graph图做接收者
+//		返回顶点v的所有出边按加入顺序指向的顶点,重边会出现多次
+//		若v不存在则返回nil
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	v			interface{}				顶点
+//@return    	vs			[]interface{}			相邻顶点
+func (g *Graph) Neighbors(v interface{}) (vs []interface{}) {
+	if g == nil {
+		return nil
+	}
+	g.mutex.Lock()
+	x := g.id(v, false)
+	if x == -1 {
+		g.mutex.Unlock()
+		return nil
+	}
+	vs = make([]interface{}, len(g.adj[x]))
+	for i, e := range g.adj[x] {
+		vs[i] = g.vertices[e.to]
+	}
+	g.mutex.Unlock()
+	return vs
+}
+
+//@title    Edges
+//@description
+//		以graph图做接收者
+//		返回图中的所有边,无向图中每条边只返回一次
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	nil
+//@return    	es			[]Edge					所有边
+func (g *Graph) Edges() (es []Edge) {
+	if g == nil {
+		return nil
+	}
+	g.mutex.Lock()
+	es = make([]Edge, 0, g.edges)
+	for u := range g.adj {
+		for _, e := range g.adj[u] {
+			if g.directed || e.to >= u {
+				es = append(es, Edge{From: g.vertices[u], To: g.vertices[e.to], Weight: e.weight})
+			}
+		}
+	}
+	g.mutex.Unlock()
+	return es
+}
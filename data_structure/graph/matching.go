@@ -0,0 +1,155 @@
+package graph
+
+//@Title		graph
+//@Description
+//		二分图的最大匹配
+//		先通过广度优先遍历对顶点进行二染色,再使用Hopcroft-Karp算法求最大匹配
+//		有向图中忽略边的方向
+
+import (
+	"github.com/hlccd/goSTL/data_structure/queue"
+)
+
+//@title    bipartition
+//@description
+//		以graph图做接收者
+//		忽略边的方向对图进行二染色
+//		返回每个顶点的颜色以及忽略方向后的邻接表
+//		若图不是二分图则返回false
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	nil
+//@return    	color		[]int					每个顶点的颜色,为0或1
+//@return    	adj			[][]int					忽略方向后的邻接表
+//@return    	b			bool					是二分图吗?
+func (g *Graph) bipartition() (color []int, adj [][]int, b bool) {
+	n := len(g.vertices)
+	adj = make([][]int, n)
+	for u := range g.adj {
+		for _, e := range g.adj[u] {
+			adj[u] = append(adj[u], e.to)
+			if g.directed && e.to != u {
+				adj[e.to] = append(adj[e.to], u)
+			}
+		}
+	}
+	color = make([]int, n)
+	for i := range color {
+		color[i] = -1
+	}
+	for s := 0; s < n; s++ {
+		if color[s] != -1 {
+			continue
+		}
+		color[s] = 0
+		q := queue.New()
+		q.Push(s)
+		for !q.Empty() {
+			u := q.Pop().(int)
+			for _, v := range adj[u] {
+				if color[v] == -1 {
+					color[v] = 1 - color[u]
+					q.Push(v)
+				} else if color[v] == color[u] {
+					return nil, nil, false
+				}
+			}
+		}
+	}
+	return color, adj, true
+}
+
+//@title    BipartiteMatching
+//@description
+//		以graph图做接收者
+//		求二分图的最大匹配
+//		先对图进行二染色,颜色为0的顶点作为左部,再使用Hopcroft-Karp算法,时间复杂度为O(E sqrt(V))
+//		返回的每条边中From为左部顶点,To为右部顶点,Weight为两者之间边的最小权重
+//		若图不是二分图则返回false
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	nil
+//@return    	ms			[]Edge					匹配中的边
+//@return    	b			bool					是二分图吗?
+func (g *Graph) BipartiteMatching() (ms []Edge, b bool) {
+	if g == nil {
+		return nil, false
+	}
+	g.mutex.Lock()
+	color, adj, b := g.bipartition()
+	if !b {
+		g.mutex.Unlock()
+		return nil, false
+	}
+	n := len(g.vertices)
+	match := make([]int, n)
+	for i := range match {
+		match[i] = -1
+	}
+	dist := make([]int, n)
+	//bfs从所有未匹配的左部顶点出发分层,返回是否存在增广路
+	bfs := func() bool {
+		q := queue.New()
+		for u := 0; u < n; u++ {
+			dist[u] = -1
+			if color[u] == 0 && match[u] == -1 {
+				dist[u] = 0
+				q.Push(u)
+			}
+		}
+		found := false
+		for !q.Empty() {
+			u := q.Pop().(int)
+			for _, v := range adj[u] {
+				w := match[v]
+				if w == -1 {
+					found = true
+				} else if dist[w] == -1 {
+					dist[w] = dist[u] + 1
+					q.Push(w)
+				}
+			}
+		}
+		return found
+	}
+	//dfs沿分层图寻找从左部顶点u出发的增广路
+	var dfs func(u int) bool
+	dfs = func(u int) bool {
+		for _, v := range adj[u] {
+			w := match[v]
+			if w == -1 || dist[w] == dist[u]+1 && dfs(w) {
+				match[u], match[v] = v, u
+				return true
+			}
+		}
+		//该顶点无法继续增广,本轮不再访问
+		dist[u] = -1
+		return false
+	}
+	for bfs() {
+		for u := 0; u < n; u++ {
+			if color[u] == 0 && match[u] == -1 {
+				dfs(u)
+			}
+		}
+	}
+	ms = make([]Edge, 0)
+	for u := 0; u < n; u++ {
+		if color[u] != 0 || match[u] == -1 {
+			continue
+		}
+		v := match[u]
+		w, found := 0.0, false
+		for _, e := range g.adj[u] {
+			if e.to == v && (!found || e.weight < w) {
+				w, found = e.weight, true
+			}
+		}
+		for _, e := range g.adj[v] {
+			if e.to == u && (!found || e.weight < w) {
+				w, found = e.weight, true
+			}
+		}
+		ms = append(ms, Edge{From: g.vertices[u], To: g.vertices[v], Weight: w})
+	}
+	g.mutex.Unlock()
+	return ms, true
+}
@@ -0,0 +1,425 @@
+package graph
+
+//@Title		graph
+//@Description
+//		图的最短路算法
+//		Dijkstra和A*借助priority_queue实现,要求边权非负
+//		Bellman-Ford可处理负权边并检测从源点可达的负环
+//		Floyd-Warshall求出所有顶点对之间的最短路并检测负环
+//		结果中保存了求解时图中的顶点,之后向图中加入的顶点视为不可达
+
+import (
+	"github.com/hlccd/goSTL/data_structure/priority_queue"
+	"math"
+)
+
+//item优先队列中的元素结构体
+//保存顶点编号和该顶点的当前估计距离
+type item struct {
+	dist float64 //估计距离
+	v    int     //顶点编号
+}
+
+//@title    itemCmp
+//@description
+//		优先队列中元素的比较器,估计距离小的元素位于顶端
+//@receiver		nil
+//@param    	a			interface{}				待比较元素
+//@param    	b			interface{}				待比较元素
+//@return    	num			int						比较结果
+func itemCmp(a, b interface{}) (num int) {
+	da, db := a.(item).dist, b.(item).dist
+	if da < db {
+		return -1
+	} else if da > db {
+		return 1
+	}
+	return 0
+}
+
+//Paths单源最短路结构体
+//保存源点到每个顶点的最短距离以及最短路上每个顶点的前驱
+//不可达的顶点距离为正无穷,前驱为-1
+type Paths struct {
+	source   interface{}   //源点
+	index    indexer       //顶点到编号的映射
+	vertices []interface{} //编号到顶点的映射
+	dist     []float64     //最短距离
+	prev     []int         //最短路上的前驱
+}
+
+//AllPairs全源最短路结构体
+//保存每对顶点之间的最短距离以及最短路上起点的下一个顶点
+type AllPairs struct {
+	index    indexer       //顶点到编号的映射
+	vertices []interface{} //编号到顶点的映射
+	dist     [][]float64   //最短距离
+	next     [][]int       //最短路上起点的下一个顶点
+}
+
+//@title    newPaths
+//@description
+//		以graph图做接收者
+//		以编号为s的顶点为源点新建单源最短路结果
+//		初始时仅源点距离为0,其余顶点均不可达
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	s			int						源点编号
+//@return    	p			*Paths					新建的Paths指针
+func (g *Graph) newPaths(s int) (p *Paths) {
+	n := len(g.vertices)
+	p = &Paths{
+		source:   g.vertices[s],
+		index:    g.index,
+		vertices: g.vertices[:n:n],
+		dist:     make([]float64, n),
+		prev:     make([]int, n),
+	}
+	for i := range p.dist {
+		p.dist[i], p.prev[i] = math.Inf(1), -1
+	}
+	p.dist[s] = 0
+	return p
+}
+
+//@title    Dijkstra
+//@description
+//		以graph图做接收者
+//		以顶点src为源点使用Dijkstra算法求单源最短路
+//		借助priority_queue每次取出估计距离最小的顶点,时间复杂度为O((V+E)log V)
+//		要求所有边权非负,否则结果不保证正确
+//		若src不存在则返回nil
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	src			interface{}				源点
+//@return    	p			*Paths					单源最短路结果
+func (g *Graph) Dijkstra(src interface{}) (p *Paths) {
+	if g == nil {
+		return nil
+	}
+	g.mutex.Lock()
+	s := g.id(src, false)
+	if s == -1 {
+		g.mutex.Unlock()
+		return nil
+	}
+	p = g.newPaths(s)
+	done := make([]bool, len(g.vertices))
+	pq := priority_queue.New(itemCmp)
+	pq.Push(item{dist: 0, v: s})
+	for !pq.Empty() {
+		u := pq.Top().(item).v
+		pq.Pop()
+		if done[u] {
+			continue
+		}
+		done[u] = true
+		for _, e := range g.adj[u] {
+			if d := p.dist[u] + e.weight; d < p.dist[e.to] {
+				p.dist[e.to], p.prev[e.to] = d, u
+				pq.Push(item{dist: d, v: e.to})
+			}
+		}
+	}
+	g.mutex.Unlock()
+	return p
+}
+
+//@title    AStar
+//@description
+//		以graph图做接收者
+//		使用A*算法求从src到dst的最短路
+//		h为启发函数,返回顶点到dst的估计距离,要求其不超过真实距离,否则结果不保证最短
+//		h为nil时即退化为Dijkstra算法
+//		要求所有边权非负
+//		若不可达或顶点不存在则返回false
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	src			interface{}				起点
+//@param    	dst			interface{}				终点
+//@param    	h			func(v interface{}) float64	启发函数
+//@return    	path		[]interface{}			最短路上的顶点,包含起点和终点
+//@return    	dist		float64					最短距离
+//@return    	b			bool					可达吗?
+func (g *Graph) AStar(src, dst interface{}, h func(v interface{}) float64) (path []interface{}, dist float64, b bool) {
+	if g == nil {
+		return nil, 0, false
+	}
+	g.mutex.Lock()
+	s, t := g.id(src, false), g.id(dst, false)
+	if s == -1 || t == -1 {
+		g.mutex.Unlock()
+		return nil, 0, false
+	}
+	if h == nil {
+		h = func(v interface{}) float64 { return 0 }
+	}
+	p := g.newPaths(s)
+	pq := priority_queue.New(itemCmp)
+	pq.Push(item{dist: h(src), v: s})
+	for !pq.Empty() {
+		it := pq.Top().(item)
+		pq.Pop()
+		u := it.v
+		if u == t {
+			path, _ = p.path(t)
+			dist, b = p.dist[t], true
+			g.mutex.Unlock()
+			return path, dist, b
+		}
+		//该元素入队后顶点距离又被更新过,跳过过期的元素
+		//启发函数不满足一致性时顶点可能被多次展开
+		if it.dist > p.dist[u]+h(g.vertices[u]) {
+			continue
+		}
+		for _, e := range g.adj[u] {
+			if d := p.dist[u] + e.weight; d < p.dist[e.to] {
+				p.dist[e.to], p.prev[e.to] = d, u
+				pq.Push(item{dist: d + h(g.vertices[e.to]), v: e.to})
+			}
+		}
+	}
+	g.mutex.Unlock()
+	return nil, 0, false
+}
+
+//@title    BellmanFord
+//@description
+//		以graph图做接收者
+//		以顶点src为源点使用Bellman-Ford算法求单源最短路
+//		最多进行V-1轮松弛,某轮没有更新时提前结束,时间复杂度为O(VE)
+//		可以处理负权边,无向图中的负权边本身即构成负环
+//		若src不存在或存在从src可达的负环则返回false
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	src			interface{}				源点
+//@return    	p			*Paths					单源最短路结果
+//@return    	b			bool					求解成功?
+func (g *Graph) BellmanFord(src interface{}) (p *Paths, b bool) {
+	if g == nil {
+		return nil, false
+	}
+	g.mutex.Lock()
+	s := g.id(src, false)
+	if s == -1 {
+		g.mutex.Unlock()
+		return nil, false
+	}
+	p = g.newPaths(s)
+	for round := 0; round < len(g.vertices); round++ {
+		updated := false
+		for u := range g.adj {
+			if math.IsInf(p.dist[u], 1) {
+				continue
+			}
+			for _, e := range g.adj[u] {
+				if d := p.dist[u] + e.weight; d < p.dist[e.to] {
+					p.dist[e.to], p.prev[e.to] = d, u
+					updated = true
+				}
+			}
+		}
+		if !updated {
+			g.mutex.Unlock()
+			return p, true
+		}
+	}
+	//第V轮仍有更新说明存在可达的负环
+	g.mutex.Unlock()
+	return nil, false
+}
+
+//@title    FloydWarshall
+//@description
+//		以graph图做接收者
+//		使用Floyd-Warshall算法求所有顶点对之间的最短路
+//		时间复杂度为O(V^3),空间复杂度为O(V^2)
+//		若存在负环则返回false
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	nil
+//@return    	ap			*AllPairs				全源最短路结果
+//@return    	b			bool					求解成功?
+func (g *Graph) FloydWarshall() (ap *AllPairs, b bool) {
+	if g == nil {
+		return nil, false
+	}
+	g.mutex.Lock()
+	n := len(g.vertices)
+	ap = &AllPairs{
+		index:    g.index,
+		vertices: g.vertices[:n:n],
+		dist:     make([][]float64, n),
+		next:     make([][]int, n),
+	}
+	for i := 0; i < n; i++ {
+		ap.dist[i], ap.next[i] = make([]float64, n), make([]int, n)
+		for j := 0; j < n; j++ {
+			ap.dist[i][j], ap.next[i][j] = math.Inf(1), -1
+		}
+		ap.dist[i][i], ap.next[i][i] = 0, i
+	}
+	for u := range g.adj {
+		for _, e := range g.adj[u] {
+			if e.weight < ap.dist[u][e.to] {
+				ap.dist[u][e.to], ap.next[u][e.to] = e.weight, e.to
+			}
+		}
+	}
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			if math.IsInf(ap.dist[i][k], 1) {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if d := ap.dist[i][k] + ap.dist[k][j]; d < ap.dist[i][j] {
+					ap.dist[i][j], ap.next[i][j] = d, ap.next[i][k]
+				}
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		if ap.dist[i][i] < 0 {
+			g.mutex.Unlock()
+			return nil, false
+		}
+	}
+	g.mutex.Unlock()
+	return ap, true
+}
+
+//@title    lookup
+//@description
+//		返回顶点v在求解时的编号
+//		若v在求解时不存在则返回-1
+//@receiver		nil
+//@param    	index		indexer					顶点到编号的映射
+//@param    	n			int						求解时的顶点数量
+//@param    	v			interface{}				顶点
+//@return    	x			int						顶点编号
+func lookup(index indexer, n int, v interface{}) (x int) {
+	if v == nil {
+		return -1
+	}
+	if x, ok := index.Get(v).(int); ok && x < n {
+		return x
+	}
+	return -1
+}
+
+//@title    Source
+//@description
+//		以Paths单源最短路结果做接收者
+//		返回该结果的源点
+//@receiver		p			*Paths					接受者Paths的指针
+//@param    	nil
+//@return    	src			interface{}				源点
+func (p *Paths) Source() (src interface{}) {
+	if p == nil {
+		return nil
+	}
+	return p.source
+}
+
+//@title    Dist
+//@description
+//		以Paths单源最短路结果做接收者
+//		返回源点到顶点v的最短距离
+//		若不可达则返回false
+//@receiver		p			*Paths					接受者Paths的指针
+//@param    	v			interface{}				顶点
+//@return    	d			float64					最短距离
+//@return    	b			bool					可达吗?
+func (p *Paths) Dist(v interface{}) (d float64, b bool) {
+	if p == nil {
+		return 0, false
+	}
+	x := lookup(p.index, len(p.vertices), v)
+	if x == -1 || math.IsInf(p.dist[x], 1) {
+		return 0, false
+	}
+	return p.dist[x], true
+}
+
+//@title    path
+//@description
+//		以Paths单源最短路结果做接收者
+//		沿前驱还原从源点到编号x的最短路
+//@receiver		p			*Paths					接受者Paths的指针
+//@param    	x			int						终点编号
+//@return    	path		[]interface{}			最短路上的顶点,包含起点和终点
+//@return    	b			bool					可达吗?
+func (p *Paths) path(x int) (path []interface{}, b bool) {
+	if math.IsInf(p.dist[x], 1) {
+		return nil, false
+	}
+	for ; x != -1; x = p.prev[x] {
+		path = append(path, p.vertices[x])
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path, true
+}
+
+//@title    PathTo
+//@description
+//		以Paths单源最短路结果做接收者
+//		返回从源点到顶点v的最短路
+//		若不可达则返回false
+//@receiver		p			*Paths					接受者Paths的指针
+//@param    	v			interface{}				终点
+//@return    	path		[]interface{}			最短路上的顶点,包含起点和终点
+//@return    	b			bool					可达吗?
+func (p *Paths) PathTo(v interface{}) (path []interface{}, b bool) {
+	if p == nil {
+		return nil, false
+	}
+	x := lookup(p.index, len(p.vertices), v)
+	if x == -1 {
+		return nil, false
+	}
+	return p.path(x)
+}
+
+//@title    Dist
+//@description
+//		以AllPairs全源最短路结果做接收者
+//		返回从u到v的最短距离
+//		若不可达则返回false
+//@receiver		ap			*AllPairs				接受者AllPairs的指针
+//@param    	u			interface{}				起点
+//@param    	v			interface{}				终点
+//@return    	d			float64					最短距离
+//@return    	b			bool					可达吗?
+func (ap *AllPairs) Dist(u, v interface{}) (d float64, b bool) {
+	if ap == nil {
+		return 0, false
+	}
+	x, y := lookup(ap.index, len(ap.vertices), u), lookup(ap.index, len(ap.vertices), v)
+	if x == -1 || y == -1 || math.IsInf(ap.dist[x][y], 1) {
+		return 0, false
+	}
+	return ap.dist[x][y], true
+}
+
+//@title    Path
+//@description
+//		以AllPairs全源最短路结果做接收者
+//		返回从u到v的最短路
+//		若不可达则返回false
+//@receiver		ap			*AllPairs				接受者AllPairs的指针
+//@param    	u			interface{}				起点
+//@param    	v			interface{}				终点
+//@return    	path		[]interface{}			最短路上的顶点,包含起点和终点
+//@return    	b			bool					可达吗?
+func (ap *AllPairs) Path(u, v interface{}) (path []interface{}, b bool) {
+	if ap == nil {
+		return nil, false
+	}
+	x, y := lookup(ap.index, len(ap.vertices), u), lookup(ap.index, len(ap.vertices), v)
+	if x == -1 || y == -1 || ap.next[x][y] == -1 {
+		return nil, false
+	}
+	path = append(path, ap.vertices[x])
+	for x != y {
+		x = ap.next[x][y]
+		path = append(path, ap.vertices[x])
+	}
+	return path, true
+}
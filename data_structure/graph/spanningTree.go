@@ -0,0 +1,129 @@
+package graph
+
+//@Title		graph
+//@Description
+//		无向图的最小生成树算法
+//		Kruskal算法将边按权重排序后借助并查集依次加入不成环的边
+//		Prim算法借助priority_queue从每个未访问的顶点出发不断加入权重最小的横切边
+//		若图不连通则求出的是最小生成森林
+
+import (
+	"github.com/hlccd/goSTL/data_structure/priority_queue"
+	"github.com/hlccd/goSTL/data_structure/unionFind"
+	"github.com/hlccd/goSTL/utils/comparator"
+)
+
+//indexedEdge以编号表示的边结构体
+type indexedEdge struct {
+	from   int     //起点编号
+	to     int     //终点编号
+	weight float64 //权重
+}
+
+//@title    Kruskal
+//@description
+//		以graph图做接收者
+//		使用Kruskal算法求无向图的最小生成森林
+//		将所有边按权重从小到大排序,借助并查集依次加入两端不连通的边,时间复杂度为O(E log E)
+//		若为有向图则返回nil
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	nil
+//@return    	es			[]Edge					生成森林中的边
+//@return    	total		float64					生成森林的总权重
+func (g *Graph) Kruskal() (es []Edge, total float64) {
+	if g == nil {
+		return nil, 0
+	}
+	g.mutex.Lock()
+	if g.directed {
+		g.mutex.Unlock()
+		return nil, 0
+	}
+	arr := make([]interface{}, 0, g.edges)
+	for u := range g.adj {
+		for _, e := range g.adj[u] {
+			if e.to > u {
+				arr = append(arr, indexedEdge{from: u, to: e.to, weight: e.weight})
+			}
+		}
+	}
+	comparator.Sort(&arr, func(a, b interface{}) int {
+		wa, wb := a.(indexedEdge).weight, b.(indexedEdge).weight
+		if wa < wb {
+			return -1
+		} else if wa > wb {
+			return 1
+		}
+		return 0
+	})
+	uf := unionFind.New(len(g.vertices))
+	es = make([]Edge, 0)
+	for _, a := range arr {
+		e := a.(indexedEdge)
+		if uf.Union(e.from, e.to) {
+			es = append(es, Edge{From: g.vertices[e.from], To: g.vertices[e.to], Weight: e.weight})
+			total += e.weight
+		}
+	}
+	g.mutex.Unlock()
+	return es, total
+}
+
+//@title    Prim
+//@description
+//		以graph图做接收者
+//		使用Prim算法求无向图的最小生成森林
+//		借助priority_queue每次取出连接已访问顶点和未访问顶点的最小权重边,时间复杂度为O(E log E)
+//		若为有向图则返回nil
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	nil
+//@return    	es			[]Edge					生成森林中的边
+//@return    	total		float64					生成森林的总权重
+func (g *Graph) Prim() (es []Edge, total float64) {
+	if g == nil {
+		return nil, 0
+	}
+	g.mutex.Lock()
+	if g.directed {
+		g.mutex.Unlock()
+		return nil, 0
+	}
+	cmp := func(a, b interface{}) int {
+		wa, wb := a.(indexedEdge).weight, b.(indexedEdge).weight
+		if wa < wb {
+			return -1
+		} else if wa > wb {
+			return 1
+		}
+		return 0
+	}
+	visited := make([]bool, len(g.vertices))
+	es = make([]Edge, 0)
+	for s := range g.vertices {
+		if visited[s] {
+			continue
+		}
+		visited[s] = true
+		pq := priority_queue.New(cmp)
+		for _, e := range g.adj[s] {
+			pq.Push(indexedEdge{from: s, to: e.to, weight: e.weight})
+		}
+		for !pq.Empty() {
+			e := pq.Top().(indexedEdge)
+			pq.Pop()
+			if visited[e.to] {
+				continue
+			}
+			visited[e.to] = true
+			es = append(es, Edge{From: g.vertices[e.from], To: g.vertices[e.to], Weight: e.weight})
+			total += e.weight
+			for _, f := range g.adj[e.to] {
+				if !visited[f.to] {
+					pq.Push(indexedEdge{from: e.to, to: f.to, weight: f.weight})
+				}
+			}
+		}
+	}
+	g.mutex.Unlock()
+	return es, total
+}
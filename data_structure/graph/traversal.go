@@ -0,0 +1,293 @@
+package graph
+
+//@Title		graph
+//@Description
+//		图的遍历与结构分析
+//		广度优先遍历借助queue实现,深度优先遍历借助stack实现
+//		拓扑排序使用Kahn算法,连通分量借助并查集求得,强连通分量使用非递归的Tarjan算法
+
+import (
+	"github.com/hlccd/goSTL/data_structure/queue"
+	"github.com/hlccd/goSTL/data_structure/stack"
+	"github.com/hlccd/goSTL/data_structure/unionFind"
+	"github.com/hlccd/goSTL/utils/iterator"
+)
+
+//@title    BFS
+//@description
+//		以graph图做接收者
+//		从顶点start出发进行广度优先遍历
+//		将遍历到的顶点按遍历顺序放入迭代器中
+//		若start不存在则返回nil
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	start		interface{}				起点
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (g *Graph) BFS(start interface{}) (i *Iterator.Iterator) {
+	if g == nil {
+		return nil
+	}
+	g.mutex.Lock()
+	s := g.id(start, false)
+	if s == -1 {
+		g.mutex.Unlock()
+		return nil
+	}
+	es := make([]interface{}, 0)
+	visited := make([]bool, len(g.vertices))
+	q := queue.New()
+	q.Push(s)
+	visited[s] = true
+	for !q.Empty() {
+		u := q.Pop().(int)
+		es = append(es, g.vertices[u])
+		for _, e := range g.adj[u] {
+			if !visited[e.to] {
+				visited[e.to] = true
+				q.Push(e.to)
+			}
+		}
+	}
+	i = Iterator.New(&es)
+	g.mutex.Unlock()
+	return i
+}
+
+//@title    DFS
+//@description
+//		以graph图做接收者
+//		从顶点start出发进行深度优先遍历
+//		将遍历到的顶点按先序顺序放入迭代器中,相邻顶点按加入顺序访问
+//		若start不存在则返回nil
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	start		interface{}				起点
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (g *Graph) DFS(start interface{}) (i *Iterator.Iterator) {
+	if g == nil {
+		return nil
+	}
+	g.mutex.Lock()
+	s := g.id(start, false)
+	if s == -1 {
+		g.mutex.Unlock()
+		return nil
+	}
+	es := make([]interface{}, 0)
+	visited := make([]bool, len(g.vertices))
+	st := stack.New()
+	st.Push(s)
+	for !st.Empty() {
+		u := st.Top().(int)
+		st.Pop()
+		if visited[u] {
+			continue
+		}
+		visited[u] = true
+		es = append(es, g.vertices[u])
+		//逆序压栈以保证按加入顺序访问相邻顶点
+		for j := len(g.adj[u]) - 1; j >= 0; j-- {
+			if v := g.adj[u][j].to; !visited[v] {
+				st.Push(v)
+			}
+		}
+	}
+	i = Iterator.New(&es)
+	g.mutex.Unlock()
+	return i
+}
+
+//@title    TopologicalSort
+//@description
+//		以graph图做接收者
+//		返回有向图的一个拓扑序列
+//		使用Kahn算法,每次取出入度为0的顶点,同时入度为0的顶点按加入顺序取出
+//		若图中存在环或该图为无向图则返回false
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	nil
+//@return    	order		[]interface{}			拓扑序列
+//@return    	b			bool					存在拓扑序列吗?
+func (g *Graph) TopologicalSort() (order []interface{}, b bool) {
+	if g == nil {
+		return nil, false
+	}
+	g.mutex.Lock()
+	if !g.directed {
+		g.mutex.Unlock()
+		return nil, false
+	}
+	in := make([]int, len(g.vertices))
+	for u := range g.adj {
+		for _, e := range g.adj[u] {
+			in[e.to]++
+		}
+	}
+	q := queue.New()
+	for u := range in {
+		if in[u] == 0 {
+			q.Push(u)
+		}
+	}
+	order = make([]interface{}, 0, len(g.vertices))
+	for !q.Empty() {
+		u := q.Pop().(int)
+		order = append(order, g.vertices[u])
+		for _, e := range g.adj[u] {
+			if in[e.to]--; in[e.to] == 0 {
+				q.Push(e.to)
+			}
+		}
+	}
+	//仍有顶点未被取出说明存在环
+	if len(order) < len(g.vertices) {
+		g.mutex.Unlock()
+		return nil, false
+	}
+	g.mutex.Unlock()
+	return order, true
+}
+
+//@title    HasCycle
+//@description
+//		以graph图做接收者
+//		判断图中是否存在环
+//		有向图中即不存在拓扑序列,无向图中即边数超过顶点数减去连通分量数
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	nil
+//@return    	b			bool					存在环吗?
+func (g *Graph) HasCycle() (b bool) {
+	if g == nil {
+		return false
+	}
+	if g.Directed() {
+		_, b = g.TopologicalSort()
+		return !b
+	}
+	g.mutex.Lock()
+	uf := unionFind.New(len(g.vertices))
+	for u := range g.adj {
+		for _, e := range g.adj[u] {
+			//自环和重边均会使合并失败
+			if e.to >= u && !uf.Union(u, e.to) {
+				g.mutex.Unlock()
+				return true
+			}
+		}
+	}
+	g.mutex.Unlock()
+	return false
+}
+
+//@title    ConnectedComponents
+//@description
+//		以graph图做接收者
+//		返回图中的所有连通分量,有向图中即为弱连通分量
+//		借助并查集对每条边的两端进行合并
+//		分量内的顶点按加入顺序排列,分量之间按其最早加入的顶点排列
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	nil
+//@return    	cs			[][]interface{}			所有连通分量
+func (g *Graph) ConnectedComponents() (cs [][]interface{}) {
+	if g == nil {
+		return nil
+	}
+	g.mutex.Lock()
+	uf := unionFind.New(len(g.vertices))
+	for u := range g.adj {
+		for _, e := range g.adj[u] {
+			uf.Union(u, e.to)
+		}
+	}
+	cs = g.toVertices(uf.Sets())
+	g.mutex.Unlock()
+	return cs
+}
+
+//@title    StronglyConnectedComponents
+//@description
+//		以graph图做接收者
+//		返回图中的所有强连通分量,无向图中即为连通分量
+//		使用非递归的Tarjan算法,时间复杂度为O(V+E)
+//		分量按Tarjan算法求出的顺序排列,即每个分量都不可达其之后的分量
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	nil
+//@return    	cs			[][]interface{}			所有强连通分量
+func (g *Graph) StronglyConnectedComponents() (cs [][]interface{}) {
+	if g == nil {
+		return nil
+	}
+	g.mutex.Lock()
+	n := len(g.vertices)
+	idx := make([]int, n)  //访问次序,0表示未访问
+	low := make([]int, n)  //可回溯到的最小访问次序
+	next := make([]int, n) //每个顶点下一条待访问的出边
+	onStack := make([]bool, n)
+	st := make([]int, 0)   //Tarjan算法中保存候选顶点的栈
+	call := make([]int, 0) //模拟递归调用的栈
+	counter := 0
+	sets := make([][]int, 0)
+	for s := 0; s < n; s++ {
+		if idx[s] != 0 {
+			continue
+		}
+		call = append(call, s)
+		for len(call) > 0 {
+			u := call[len(call)-1]
+			if next[u] == 0 && idx[u] == 0 {
+				counter++
+				idx[u], low[u] = counter, counter
+				st = append(st, u)
+				onStack[u] = true
+			}
+			if next[u] < len(g.adj[u]) {
+				v := g.adj[u][next[u]].to
+				next[u]++
+				if idx[v] == 0 {
+					call = append(call, v)
+				} else if onStack[v] && idx[v] < low[u] {
+					low[u] = idx[v]
+				}
+				continue
+			}
+			//u的所有出边均已访问,回溯
+			call = call[:len(call)-1]
+			if len(call) > 0 {
+				if p := call[len(call)-1]; low[u] < low[p] {
+					low[p] = low[u]
+				}
+			}
+			if low[u] == idx[u] {
+				set := make([]int, 0)
+				for {
+					v := st[len(st)-1]
+					st = st[:len(st)-1]
+					onStack[v] = false
+					set = append(set, v)
+					if v == u {
+						break
+					}
+				}
+				sets = append(sets, set)
+			}
+		}
+	}
+	cs = g.toVertices(sets)
+	g.mutex.Unlock()
+	return cs
+}
+
+//@title    toVertices
+//@description
+//		以graph图做接收者
+//		将以编号表示的顶点集合转换为以顶点表示
+//@receiver		g			*Graph					接受者graph的指针
+//@param    	sets		[][]int					以编号表示的顶点集合
+//@return    	cs			[][]interface{}			以顶点表示的顶点集合
+func (g *Graph) toVertices(sets [][]int) (cs [][]interface{}) {
+	cs = make([][]interface{}, len(sets))
+	for i := range sets {
+		cs[i] = make([]interface{}, len(sets[i]))
+		for j, x := range sets[i] {
+			cs[i][j] = g.vertices[x]
+		}
+	}
+	return cs
+}
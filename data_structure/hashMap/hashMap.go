@@ -421,8 +421,10 @@ func (hm *hashMap) Get(key interface{}) (value interface{}) {
 	//从avl树中找到对应该hash值的key-value
 	info := hm.arr.At(hash).(*avlTree.AvlTree).Find(&indexes{key: key, value: nil})
 	hm.mutex.Unlock()
-	if info == nil {
+	//avl树为空时Find返回的并非索引指针,需一并视为未找到
+	idx, ok := info.(*indexes)
+	if !ok {
 		return nil
 	}
-	return info.(*indexes).value
+	return idx.value
 }
@@ -0,0 +1,330 @@
+package intervalTree
+
+//@Title		intervalTree
+//@Description
+//		区间树-Interval Tree
+//		以平衡二叉树的形式实现,节点以区间起点为主序、终点为次序排序
+//		每个节点额外记录其子树中最大的区间终点,从而可以快速查找与给定区间相交或包含给定点的区间
+//		区间均为闭区间[Start,End],端点通过比较器进行比较,可用于时间窗口、IP段等任意有序的端点类型
+//		可以在创建时设置区间是否可重复
+//		若区间可重复则增加节点中的数值,否则对区间携带的值进行覆盖
+//		使用互斥锁实现并发控制
+
+import (
+	"github.com/hlccd/goSTL/utils/comparator"
+	"github.com/hlccd/goSTL/utils/iterator"
+	"sync"
+)
+
+//Interval区间结构体
+//表示闭区间[Start,End],Value为该区间携带的值
+//两个区间的起点和终点均相等时视为同一区间,与携带的值无关
+type Interval struct {
+	Start interface{} //区间起点
+	End   interface{} //区间终点
+	Value interface{} //区间携带的值
+}
+
+//intervalTree区间树结构体
+//该实例存储区间树的根节点
+//同时保存该区间树已经存储了多少个区间
+//端点比较使用的比较器在创建时传入,若不传入则在插入首个区间时从默认比较器中寻找
+//创建时传入是否允许该区间树出现重复区间,如果不允许则进行覆盖,允许则对节点数目增加即可
+type IntervalTree struct {
+	root    *node                 //根节点指针
+	size    int                   //存储区间数量
+	cmp     comparator.Comparator //端点比较器
+	isMulti bool                  //是否允许重复
+	mutex   sync.Mutex            //并发控制锁
+}
+
+//intervalTree区间树容器接口
+//存放了intervalTree区间树可使用的函数
+//对应函数介绍见下方
+type intervalTreer interface {
+	Iterator() (i *Iterator.Iterator)                      //返回包含该区间树的所有区间,重复则返回多个
+	Size() (num int)                                       //返回该区间树中保存的区间个数
+	Clear()                                                //清空该区间树
+	Empty() (b bool)                                       //判断该区间树是否为空
+	Insert(start, end, value interface{}) (b bool)         //向区间树中插入区间[start,end]
+	Erase(start, end interface{}) (b bool)                 //从区间树中删除区间[start,end]
+	Count(start, end interface{}) (num int)                //返回区间[start,end]在区间树中的个数
+	Find(start, end interface{}) (iv Interval, b bool)     //返回区间树中与[start,end]相等的区间
+	Overlapping(lo, hi interface{}) (i *Iterator.Iterator) //返回所有与[lo,hi]相交的区间
+	Containing(x interface{}) (i *Iterator.Iterator)       //返回所有包含x的区间
+	AnyOverlap(lo, hi interface{}) (iv Interval, b bool)   //返回任意一个与[lo,hi]相交的区间
+}
+
+//@title    New
+//@description
+//		新建一个intervalTree区间树容器并返回
+//		初始根节点为nil
+//		传入该区间树是否为可重复属性,如果为true则保存重复区间,否则对原有相等区间携带的值进行覆盖
+//		若有传入的比较器,则将传入的第一个比较器设为该区间树的端点比较器
+//@receiver		nil
+//@param    	isMulti		bool						该区间树是否保存重复区间?
+//@param    	Cmp			 ...comparator.Comparator	intervalTree端点比较器集
+//@return    	it        	*IntervalTree				新建的intervalTree指针
+func New(isMulti bool, cmps ...comparator.Comparator) (it *IntervalTree) {
+	//判断是否有传入比较器,若有则设为该区间树默认比较器
+	var cmp comparator.Comparator
+	if len(cmps) == 0 {
+		cmp = nil
+	} else {
+		cmp = cmps[0]
+	}
+	return &IntervalTree{
+		root:    nil,
+		size:    0,
+		cmp:     cmp,
+		isMulti: isMulti,
+	}
+}
+
+//@title    Iterator
+//@description
+//		以intervalTree区间树做接收者
+//		将该区间树中所有保存的区间按起点、终点从小到大的顺序放入迭代器中
+//		迭代器中的元素类型为Interval
+//		若允许重复存储则对于重复区间进行多次放入
+//@receiver		it			*IntervalTree			接受者intervalTree的指针
+//@param    	nil
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (it *IntervalTree) Iterator() (i *Iterator.Iterator) {
+	if it == nil {
+		return nil
+	}
+	it.mutex.Lock()
+	es := make([]interface{}, 0, it.size)
+	it.root.inOrder(&es)
+	it.mutex.Unlock()
+	return Iterator.New(&es)
+}
+
+//@title    Size
+//@description
+//		以intervalTree区间树做接收者
+//		返回该容器当前含有区间的数量
+//		如果容器为nil返回0
+//@receiver		it			*IntervalTree			接受者intervalTree的指针
+//@param    	nil
+//@return    	num        	int						容器中区间的数量
+func (it *IntervalTree) Size() (num int) {
+	if it == nil {
+		return 0
+	}
+	return it.size
+}
+
+//@title    Clear
+//@description
+//		以intervalTree区间树做接收者
+//		将该容器中所承载的区间清空
+//		将该容器的size置0
+//@receiver		it			*IntervalTree			接受者intervalTree的指针
+//@param    	nil
+//@return    	nil
+func (it *IntervalTree) Clear() {
+	if it == nil {
+		return
+	}
+	it.mutex.Lock()
+	it.root = nil
+	it.size = 0
+	it.mutex.Unlock()
+}
+
+//@title    Empty
+//@description
+//		以intervalTree区间树做接收者
+//		判断该区间树是否含有区间
+//		如果含有区间则不为空,返回false
+//		如果不含有区间则说明为空,返回true
+//		如果容器不存在,返回true
+//@receiver		it			*IntervalTree			接受者intervalTree的指针
+//@param    	nil
+//@return    	b			bool					该容器是空的吗?
+func (it *IntervalTree) Empty() (b bool) {
+	if it == nil {
+		return true
+	}
+	return it.size == 0
+}
+
+//@title    Insert
+//@description
+//		以intervalTree区间树做接收者
+//		向区间树插入携带值value的闭区间[start,end]
+//		若不允许重复则对相等区间携带的值进行覆盖
+//		若start大于end或无法获取比较器则插入失败
+//@receiver		it			*IntervalTree			接受者intervalTree的指针
+//@param    	start		interface{}				区间起点
+//@param    	end			interface{}				区间终点
+//@param    	value		interface{}				区间携带的值
+//@return    	b			bool					添加成功?
+func (it *IntervalTree) Insert(start, end, value interface{}) (b bool) {
+	if it == nil {
+		return false
+	}
+	it.mutex.Lock()
+	if it.cmp == nil {
+		it.cmp = comparator.GetCmp(start)
+	}
+	if it.cmp == nil || it.cmp(start, end) > 0 {
+		it.mutex.Unlock()
+		return false
+	}
+	it.root, b = it.root.insert(Interval{Start: start, End: end, Value: value}, it.isMulti, it.cmp)
+	if b {
+		//插入成功,数量+1
+		it.size++
+	}
+	it.mutex.Unlock()
+	return b
+}
+
+//@title    Erase
+//@description
+//		以intervalTree区间树做接收者
+//		从区间树中删除闭区间[start,end]
+//		若允许重复记录则对承载该区间的节点中数量记录减一即可
+//@receiver		it			*IntervalTree			接受者intervalTree的指针
+//@param    	start		interface{}				区间起点
+//@param    	end			interface{}				区间终点
+//@return    	b			bool					删除成功?
+func (it *IntervalTree) Erase(start, end interface{}) (b bool) {
+	if it == nil {
+		return false
+	}
+	it.mutex.Lock()
+	if it.size == 0 {
+		it.mutex.Unlock()
+		return false
+	}
+	it.root, b = it.root.erase(Interval{Start: start, End: end}, it.cmp)
+	if b {
+		//删除成功,数量-1
+		it.size--
+	}
+	it.mutex.Unlock()
+	return b
+}
+
+//@title    Count
+//@description
+//		以intervalTree区间树做接收者
+//		返回区间树中与闭区间[start,end]相等的区间个数
+//		如果不允许重复则最多返回1
+//@receiver		it			*IntervalTree			接受者intervalTree的指针
+//@param    	start		interface{}				区间起点
+//@param    	end			interface{}				区间终点
+//@return    	num			int						该区间在区间树中存储的个数
+func (it *IntervalTree) Count(start, end interface{}) (num int) {
+	if it == nil {
+		return 0
+	}
+	it.mutex.Lock()
+	if it.size == 0 {
+		it.mutex.Unlock()
+		return 0
+	}
+	if n := it.root.find(Interval{Start: start, End: end}, it.cmp); n != nil {
+		num = n.num
+		it.mutex.Unlock()
+		return num
+	}
+	it.mutex.Unlock()
+	return 0
+}
+
+//@title    Find
+//@description
+//		以intervalTree区间树做接收者
+//		返回区间树中与闭区间[start,end]相等的区间,可用于获取其携带的值
+//		如果未找到则返回false
+//@receiver		it			*IntervalTree			接受者intervalTree的指针
+//@param    	start		interface{}				区间起点
+//@param    	end			interface{}				区间终点
+//@return    	iv			Interval				找到的区间
+//@return    	b			bool					找到了吗?
+func (it *IntervalTree) Find(start, end interface{}) (iv Interval, b bool) {
+	if it == nil {
+		return iv, false
+	}
+	it.mutex.Lock()
+	if it.size == 0 {
+		it.mutex.Unlock()
+		return iv, false
+	}
+	if n := it.root.find(Interval{Start: start, End: end}, it.cmp); n != nil {
+		iv, b = n.interval, true
+		it.mutex.Unlock()
+		return iv, b
+	}
+	it.mutex.Unlock()
+	return iv, false
+}
+
+//@title    Overlapping
+//@description
+//		以intervalTree区间树做接收者
+//		将所有与闭区间[lo,hi]相交的区间按起点、终点从小到大的顺序放入迭代器中
+//		区间[s,e]与[lo,hi]相交当且仅当s不大于hi且e不小于lo
+//		时间复杂度为O(log n + k),k为结果数量
+//@receiver		it			*IntervalTree			接受者intervalTree的指针
+//@param    	lo			interface{}				查询区间的起点
+//@param    	hi			interface{}				查询区间的终点
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (it *IntervalTree) Overlapping(lo, hi interface{}) (i *Iterator.Iterator) {
+	if it == nil {
+		return nil
+	}
+	it.mutex.Lock()
+	es := make([]interface{}, 0)
+	if it.size > 0 && it.cmp(lo, hi) <= 0 {
+		it.root.overlapping(lo, hi, it.cmp, &es)
+	}
+	it.mutex.Unlock()
+	return Iterator.New(&es)
+}
+
+//@title    Containing
+//@description
+//		以intervalTree区间树做接收者
+//		将所有包含点x的区间按起点、终点从小到大的顺序放入迭代器中
+//		即与闭区间[x,x]相交的区间
+//@receiver		it			*IntervalTree			接受者intervalTree的指针
+//@param    	x			interface{}				查询点
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (it *IntervalTree) Containing(x interface{}) (i *Iterator.Iterator) {
+	return it.Overlapping(x, x)
+}
+
+//@title    AnyOverlap
+//@description
+//		以intervalTree区间树做接收者
+//		返回任意一个与闭区间[lo,hi]相交的区间
+//		只需沿一条路径查找,时间复杂度为O(log n)
+//		如果不存在则返回false
+//@receiver		it			*IntervalTree			接受者intervalTree的指针
+//@param    	lo			interface{}				查询区间的起点
+//@param    	hi			interface{}				查询区间的终点
+//@return    	iv			Interval				相交的区间
+//@return    	b			bool					存在相交的区间吗?
+func (it *IntervalTree) AnyOverlap(lo, hi interface{}) (iv Interval, b bool) {
+	if it == nil {
+		return iv, false
+	}
+	it.mutex.Lock()
+	if it.size == 0 || it.cmp(lo, hi) > 0 {
+		it.mutex.Unlock()
+		return iv, false
+	}
+	if n := it.root.anyOverlap(lo, hi, it.cmp); n != nil {
+		iv, b = n.interval, true
+		it.mutex.Unlock()
+		return iv, b
+	}
+	it.mutex.Unlock()
+	return iv, false
+}
@@ -0,0 +1,344 @@
+package intervalTree
+
+//@Title		intervalTree
+//@Description
+//		区间树的节点
+//		节点以区间起点为主序、终点为次序进行排序,并记录以该节点为根的子树中最大的区间终点
+//		增减节点后通过左右旋转的方式保持平衡,旋转时同时维护最大终点
+//		查询时借助最大终点剪去不可能与查询区间相交的子树
+import (
+	"github.com/hlccd/goSTL/utils/comparator"
+)
+
+//node树节点结构体
+//该节点是区间树的树节点
+//若该区间树允许重复则对节点num+1即可,否则对区间所携带的值进行覆盖
+type node struct {
+	interval Interval    //节点中存储的区间
+	num      int         //该区间数量
+	depth    int         //该节点的深度
+	maxEnd   interface{} //以该节点为根的子树中最大的区间终点
+	left     *node       //左节点指针
+	right    *node       //右节点指针
+}
+
+//@title    newNode
+//@description
+//		新建一个区间树节点并返回
+//		将传入的区间作为该节点的承载区间,最大终点即为该区间的终点
+//		该节点的num和depth默认为1,左右子节点设为nil
+//@receiver		nil
+//@param    	iv			Interval				承载区间
+//@return    	n        	*node					新建的节点的指针
+func newNode(iv Interval) (n *node) {
+	return &node{
+		interval: iv,
+		num:      1,
+		depth:    1,
+		maxEnd:   iv.End,
+		left:     nil,
+		right:    nil,
+	}
+}
+
+//@title    compare
+//@description
+//		以起点为主序、终点为次序比较区间a和区间b
+//@receiver		nil
+//@param    	a			Interval				待比较区间
+//@param    	b			Interval				待比较区间
+//@param    	cmp			comparator.Comparator	端点比较器
+//@return    	c        	int						a<b返回负数,a=b返回0,a>b返回正数
+func compare(a, b Interval, cmp comparator.Comparator) (c int) {
+	if c = cmp(a.Start, b.Start); c != 0 {
+		return c
+	}
+	return cmp(a.End, b.End)
+}
+
+//@title    inOrder
+//@description
+//		以node区间树节点做接收者
+//		以中缀序列将节点中的区间放入es中
+//		若允许重复存储则对于重复区间进行多次放入
+//@receiver		n			*node					接受者node的指针
+//@param    	es			*[]interface{}			承载结果的切片指针
+//@return    	nil
+func (n *node) inOrder(es *[]interface{}) {
+	if n == nil {
+		return
+	}
+	n.left.inOrder(es)
+	for i := 0; i < n.num; i++ {
+		*es = append(*es, n.interval)
+	}
+	n.right.inOrder(es)
+}
+
+//@title    getDepth
+//@description
+//		以node区间树节点做接收者
+//		返回该节点的深度,节点不存在返回0
+//@receiver		n			*node					接受者node的指针
+//@param    	nil
+//@return    	depth       int						该节点的深度
+func (n *node) getDepth() (depth int) {
+	if n == nil {
+		return 0
+	}
+	return n.depth
+}
+
+//@title    update
+//@description
+//		以node区间树节点做接收者
+//		根据左右子节点重新计算n节点的深度和最大终点
+//@receiver		n			*node					接受者node的指针
+//@param    	cmp			comparator.Comparator	端点比较器
+//@return    	nil
+func (n *node) update(cmp comparator.Comparator) {
+	n.depth, n.maxEnd = 1, n.interval.End
+	for _, son := range []*node{n.left, n.right} {
+		if son == nil {
+			continue
+		}
+		if son.depth+1 > n.depth {
+			n.depth = son.depth + 1
+		}
+		if cmp(son.maxEnd, n.maxEnd) > 0 {
+			n.maxEnd = son.maxEnd
+		}
+	}
+}
+
+//@title    leftRotate
+//@description
+//		以node区间树节点做接收者
+//		将该节点向左节点方向转动,使右节点作为原来节点,并返回右节点
+//		同时更新两个节点的深度和最大终点
+//@receiver		n			*node					接受者node的指针
+//@param    	cmp			comparator.Comparator	端点比较器
+//@return    	m       	*node					旋转后的原节点
+func (n *node) leftRotate(cmp comparator.Comparator) (m *node) {
+	headNode := n.right
+	n.right = headNode.left
+	headNode.left = n
+	n.update(cmp)
+	headNode.update(cmp)
+	return headNode
+}
+
+//@title    rightRotate
+//@description
+//		以node区间树节点做接收者
+//		将该节点向右节点方向转动,使左节点作为原来节点,并返回左节点
+//		同时更新两个节点的深度和最大终点
+//@receiver		n			*node					接受者node的指针
+//@param    	cmp			comparator.Comparator	端点比较器
+//@return    	m       	*node					旋转后的原节点
+func (n *node) rightRotate(cmp comparator.Comparator) (m *node) {
+	headNode := n.left
+	n.left = headNode.right
+	headNode.right = n
+	n.update(cmp)
+	headNode.update(cmp)
+	return headNode
+}
+
+//@title    adjust
+//@description
+//		以node区间树节点做接收者
+//		对n节点进行旋转以保持节点左右子树平衡
+//@receiver		n			*node					接受者node的指针
+//@param    	cmp			comparator.Comparator	端点比较器
+//@return    	m       	*node					调整后的n节点
+func (n *node) adjust(cmp comparator.Comparator) (m *node) {
+	if n.right.getDepth()-n.left.getDepth() >= 2 {
+		//右子树过高,应当对n进行左旋
+		if n.right.right.getDepth() < n.right.left.getDepth() {
+			//右左子树高于右右子树,先右旋右子树
+			n.right = n.right.rightRotate(cmp)
+		}
+		return n.leftRotate(cmp)
+	}
+	if n.left.getDepth()-n.right.getDepth() >= 2 {
+		//左子树过高,应当对n进行右旋
+		if n.left.left.getDepth() < n.left.right.getDepth() {
+			//左右子树高于左左子树,先左旋左子树
+			n.left = n.left.leftRotate(cmp)
+		}
+		return n.rightRotate(cmp)
+	}
+	return n
+}
+
+//@title    insert
+//@description
+//		以node区间树节点做接收者
+//		从n节点中插入区间iv,并返回插入后的子树根节点
+//		如果n节点与该区间相等,且允许重复值,则将num+1否则对区间携带的值进行覆盖
+//		插入成功返回true,不允许重复时进行覆盖返回false
+//@receiver		n			*node					接受者node的指针
+//@param    	iv			Interval				待插入区间
+//@param    	isMulti		bool					是否允许重复?
+//@param    	cmp			comparator.Comparator	端点比较器
+//@return    	m        	*node					插入后的子树根节点
+//@return    	b        	bool					是否插入成功?
+func (n *node) insert(iv Interval, isMulti bool, cmp comparator.Comparator) (m *node, b bool) {
+	if n == nil {
+		return newNode(iv), true
+	}
+	c := compare(iv, n.interval, cmp)
+	if c < 0 {
+		n.left, b = n.left.insert(iv, isMulti, cmp)
+	} else if c > 0 {
+		n.right, b = n.right.insert(iv, isMulti, cmp)
+	} else if isMulti {
+		//允许重复,数目+1
+		n.num++
+		return n, true
+	} else {
+		//不允许重复,对值进行覆盖
+		n.interval = iv
+		return n, false
+	}
+	n.update(cmp)
+	return n.adjust(cmp), b
+}
+
+//@title    erase
+//@description
+//		以node区间树节点做接收者
+//		从n节点中删除区间iv,并返回删除后的子树根节点
+//		如果n节点与该区间相等且存在重复值,则将num-1,否则删除该节点
+//		删除有两个子节点的节点时,以后继节点的区间替换该节点的区间并删除后继节点
+//@receiver		n			*node					接受者node的指针
+//@param    	iv			Interval				待删除区间
+//@param    	cmp			comparator.Comparator	端点比较器
+//@return    	m        	*node					删除后的子树根节点
+//@return    	b        	bool					是否删除成功?
+func (n *node) erase(iv Interval, cmp comparator.Comparator) (m *node, b bool) {
+	if n == nil {
+		return nil, false
+	}
+	c := compare(iv, n.interval, cmp)
+	if c < 0 {
+		n.left, b = n.left.erase(iv, cmp)
+	} else if c > 0 {
+		n.right, b = n.right.erase(iv, cmp)
+	} else if n.num > 1 {
+		//有重复值,节点无需删除,直接-1即可
+		n.num--
+		return n, true
+	} else if n.left != nil && n.right != nil {
+		//以后继节点替换该节点
+		s := n.right
+		for s.left != nil {
+			s = s.left
+		}
+		n.interval, n.num = s.interval, s.num
+		n.right = n.right.eraseMin(cmp)
+		b = true
+	} else if n.left != nil {
+		return n.left, true
+	} else {
+		return n.right, true
+	}
+	if !b {
+		return n, false
+	}
+	n.update(cmp)
+	return n.adjust(cmp), true
+}
+
+//@title    eraseMin
+//@description
+//		以node区间树节点做接收者
+//		从以n为根的子树中将承载最小区间的节点整体删除,并返回删除后的子树根节点
+//@receiver		n			*node					接受者node的指针
+//@param    	cmp			comparator.Comparator	端点比较器
+//@return    	m        	*node					删除后的子树根节点
+func (n *node) eraseMin(cmp comparator.Comparator) (m *node) {
+	if n.left == nil {
+		return n.right
+	}
+	n.left = n.left.eraseMin(cmp)
+	n.update(cmp)
+	return n.adjust(cmp)
+}
+
+//@title    find
+//@description
+//		以node区间树节点做接收者
+//		从n节点开始查找与区间iv相等的节点
+//		若不存在则返回nil
+//@receiver		n			*node					接受者node的指针
+//@param    	iv			Interval				待查找区间
+//@param    	cmp			comparator.Comparator	端点比较器
+//@return    	m        	*node					与iv相等的节点
+func (n *node) find(iv Interval, cmp comparator.Comparator) (m *node) {
+	for n != nil {
+		c := compare(iv, n.interval, cmp)
+		if c < 0 {
+			n = n.left
+		} else if c > 0 {
+			n = n.right
+		} else {
+			return n
+		}
+	}
+	return nil
+}
+
+//@title    overlapping
+//@description
+//		以node区间树节点做接收者
+//		将以n为根的子树中与闭区间[lo,hi]相交的区间按顺序放入es中
+//		子树的最大终点小于lo时整棵子树都不相交,节点起点大于hi时其右子树都不相交
+//		时间复杂度为O(log n + k),k为结果数量
+//@receiver		n			*node					接受者node的指针
+//@param    	lo			interface{}				查询区间的起点
+//@param    	hi			interface{}				查询区间的终点
+//@param    	cmp			comparator.Comparator	端点比较器
+//@param    	es			*[]interface{}			承载结果的切片指针
+//@return    	nil
+func (n *node) overlapping(lo, hi interface{}, cmp comparator.Comparator, es *[]interface{}) {
+	if n == nil || cmp(n.maxEnd, lo) < 0 {
+		return
+	}
+	n.left.overlapping(lo, hi, cmp, es)
+	if cmp(n.interval.Start, hi) > 0 {
+		return
+	}
+	if cmp(n.interval.End, lo) >= 0 {
+		for i := 0; i < n.num; i++ {
+			*es = append(*es, n.interval)
+		}
+	}
+	n.right.overlapping(lo, hi, cmp, es)
+}
+
+//@title    anyOverlap
+//@description
+//		以node区间树节点做接收者
+//		从n节点开始查找任意一个与闭区间[lo,hi]相交的区间所在的节点
+//		若左子树的最大终点不小于lo,则左子树中存在相交区间或整棵树都不存在相交区间,因此只需沿一条路径查找
+//		若不存在则返回nil
+//@receiver		n			*node					接受者node的指针
+//@param    	lo			interface{}				查询区间的起点
+//@param    	hi			interface{}				查询区间的终点
+//@param    	cmp			comparator.Comparator	端点比较器
+//@return    	m        	*node					与查询区间相交的节点
+func (n *node) anyOverlap(lo, hi interface{}, cmp comparator.Comparator) (m *node) {
+	for n != nil {
+		if cmp(n.interval.Start, hi) <= 0 && cmp(n.interval.End, lo) >= 0 {
+			return n
+		}
+		if n.left != nil && cmp(n.left.maxEnd, lo) >= 0 {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return nil
+}
@@ -0,0 +1,423 @@
+package kdTree
+
+//@Title		kdTree
+//@Description
+//		k-d树-k-dimensional tree
+//		用于存储k维空间中的点,每个点可以携带一个值
+//		每层按一个维度对空间进行划分,维度随深度循环变化
+//		可以在O(n log n)时间内从一组点建立平衡的k-d树
+//		支持k近邻查询、轴对齐的范围查询和半径查询
+//		插入时若某棵子树失衡则对其重建,删除时仅做标记,已删除的点超过一半时对整棵树重建
+//		允许存在坐标相同的点
+//		使用互斥锁实现并发控制
+
+import (
+	"github.com/hlccd/goSTL/data_structure/priority_queue"
+	"github.com/hlccd/goSTL/utils/comparator"
+	"github.com/hlccd/goSTL/utils/iterator"
+	"sync"
+)
+
+//子树失衡的判断系数,子节点的节点数超过父节点的该比例时视为失衡
+const alpha = 0.75
+
+//Point点结构体
+//Coords为点的坐标,其长度应与k-d树的维度数相同
+//Value为该点携带的值
+type Point struct {
+	Coords []float64   //坐标
+	Value  interface{} //携带的值
+}
+
+//kdTree k-d树结构体
+//该实例存储k-d树的根节点
+//同时保存维度数、未删除的点的数量以及已标记删除的节点数量
+type KdTree struct {
+	root    *node      //根节点指针
+	dim     int        //维度数
+	size    int        //未删除的点的数量
+	deleted int        //已标记删除的节点数量
+	mutex   sync.Mutex //并发控制锁
+}
+
+//kdTree k-d树容器接口
+//存放了kdTree k-d树可使用的函数
+//对应函数介绍见下方
+type kdTreer interface {
+	Iterator() (i *Iterator.Iterator)                          //返回包含所有点的迭代器
+	Size() (num int)                                           //返回点的数量
+	Dim() (num int)                                            //返回维度数
+	Clear()                                                    //清空k-d树
+	Empty() (b bool)                                           //判断k-d树是否为空
+	Insert(coords []float64, value interface{}) (b bool)       //插入坐标为coords携带值value的点
+	Erase(coords []float64) (b bool)                           //删除一个坐标为coords的点
+	Find(coords []float64) (p Point, b bool)                   //查找一个坐标为coords的点
+	Nearest(target []float64, k int) (ps []Point)              //返回距离target最近的k个点
+	Range(lo, hi []float64) (i *Iterator.Iterator)             //返回落在盒[lo,hi]内的所有点
+	Radius(center []float64, r float64) (i *Iterator.Iterator) //返回到center距离不超过r的所有点
+}
+
+//@title    New
+//@description
+//		新建一个dim维的kdTree k-d树容器并返回
+//		初始根节点为nil
+//		若dim不为正数则返回nil
+//@receiver		nil
+//@param    	dim			int						维度数
+//@return    	kd        	*KdTree					新建的kdTree指针
+func New(dim int) (kd *KdTree) {
+	if dim <= 0 {
+		return nil
+	}
+	return &KdTree{
+		root:    nil,
+		dim:     dim,
+		size:    0,
+		deleted: 0,
+		mutex:   sync.Mutex{},
+	}
+}
+
+//@title    Build
+//@description
+//		以ps中的点新建一个dim维的平衡kdTree k-d树容器并返回
+//		每层借助NthElement选取中位数作为划分点,时间复杂度为O(n log n)
+//		坐标维度数与dim不同的点会被忽略
+//		若dim不为正数则返回nil
+//@receiver		nil
+//@param    	dim			int						维度数
+//@param    	ps			[]Point					初始的点
+//@return    	kd        	*KdTree					新建的kdTree指针
+func Build(dim int, ps []Point) (kd *KdTree) {
+	kd = New(dim)
+	if kd == nil {
+		return nil
+	}
+	es := make([]interface{}, 0, len(ps))
+	for _, p := range ps {
+		if len(p.Coords) == dim {
+			es = append(es, copyPoint(p))
+		}
+	}
+	kd.root = build(es, 0, dim)
+	kd.size = len(es)
+	return kd
+}
+
+//@title    copyPoint
+//@description
+//		复制点的坐标,防止外部对坐标切片的修改影响k-d树
+//@receiver		nil
+//@param    	p			Point					待复制的点
+//@return    	q			Point					复制得到的点
+func copyPoint(p Point) (q Point) {
+	q.Coords = make([]float64, len(p.Coords))
+	copy(q.Coords, p.Coords)
+	q.Value = p.Value
+	return q
+}
+
+//@title    rebuild
+//@description
+//		以kdTree k-d树做接收者
+//		将以n为根、深度为depth的子树中未删除的点重新建为平衡的子树并返回其根节点
+//		重建后子树中不再含有已删除的节点
+//@receiver		kd			*KdTree					接受者kdTree的指针
+//@param    	n			*node					子树根节点
+//@param    	depth		int						子树根节点的深度
+//@return    	m			*node					重建后的子树根节点
+func (kd *KdTree) rebuild(n *node, depth int) (m *node) {
+	ps := make([]interface{}, 0, n.getSize())
+	n.collect(&ps)
+	kd.deleted -= n.getSize() - len(ps)
+	return build(ps, depth, kd.dim)
+}
+
+//@title    Iterator
+//@description
+//		以kdTree k-d树做接收者
+//		将k-d树中所有未删除的点放入迭代器中,迭代器中的元素类型为Point
+//@receiver		kd			*KdTree					接受者kdTree的指针
+//@param    	nil
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (kd *KdTree) Iterator() (i *Iterator.Iterator) {
+	if kd == nil {
+		return nil
+	}
+	kd.mutex.Lock()
+	ps := make([]interface{}, 0, kd.size)
+	kd.root.collect(&ps)
+	kd.mutex.Unlock()
+	return Iterator.New(&ps)
+}
+
+//@title    Size
+//@description
+//		以kdTree k-d树做接收者
+//		返回k-d树中未删除的点的数量
+//		如果容器为nil返回0
+//@receiver		kd			*KdTree					接受者kdTree的指针
+//@param    	nil
+//@return    	num        	int						点的数量
+func (kd *KdTree) Size() (num int) {
+	if kd == nil {
+		return 0
+	}
+	return kd.size
+}
+
+//@title    Dim
+//@description
+//		以kdTree k-d树做接收者
+//		返回k-d树的维度数
+//		如果容器为nil返回0
+//@receiver		kd			*KdTree					接受者kdTree的指针
+//@param    	nil
+//@return    	num        	int						维度数
+func (kd *KdTree) Dim() (num int) {
+	if kd == nil {
+		return 0
+	}
+	return kd.dim
+}
+
+//@title    Clear
+//@description
+//		以kdTree k-d树做接收者
+//		将k-d树中的所有点清空
+//@receiver		kd			*KdTree					接受者kdTree的指针
+//@param    	nil
+//@return    	nil
+func (kd *KdTree) Clear() {
+	if kd == nil {
+		return
+	}
+	kd.mutex.Lock()
+	kd.root = nil
+	kd.size = 0
+	kd.deleted = 0
+	kd.mutex.Unlock()
+}
+
+//@title    Empty
+//@description
+//		以kdTree k-d树做接收者
+//		判断k-d树中是否含有点
+//		如果容器不存在,返回true
+//@receiver		kd			*KdTree					接受者kdTree的指针
+//@param    	nil
+//@return    	b			bool					该容器是空的吗?
+func (kd *KdTree) Empty() (b bool) {
+	if kd == nil {
+		return true
+	}
+	return kd.size == 0
+}
+
+//@title    Insert
+//@description
+//		以kdTree k-d树做接收者
+//		向k-d树中插入坐标为coords携带值value的点
+//		从根节点向下找到插入位置,坐标相等时进入右子树
+//		插入后自上而下寻找第一个失衡的节点并对以其为根的子树进行重建
+//		若坐标的维度数与k-d树不同则插入失败
+//@receiver		kd			*KdTree					接受者kdTree的指针
+//@param    	coords		[]float64				坐标
+//@param    	value		interface{}				携带的值
+//@return    	b			bool					插入成功?
+func (kd *KdTree) Insert(coords []float64, value interface{}) (b bool) {
+	if kd == nil || len(coords) != kd.dim {
+		return false
+	}
+	kd.mutex.Lock()
+	p := copyPoint(Point{Coords: coords, Value: value})
+	kd.size++
+	if kd.root == nil {
+		kd.root = &node{point: p, axis: 0, size: 1}
+		kd.mutex.Unlock()
+		return true
+	}
+	//记录插入路径
+	path := make([]*node, 0)
+	for n := kd.root; ; {
+		n.size++
+		path = append(path, n)
+		if p.Coords[n.axis] < n.point.Coords[n.axis] {
+			if n.left == nil {
+				n.left = &node{point: p, axis: (n.axis + 1) % kd.dim, size: 1}
+				break
+			}
+			n = n.left
+		} else {
+			if n.right == nil {
+				n.right = &node{point: p, axis: (n.axis + 1) % kd.dim, size: 1}
+				break
+			}
+			n = n.right
+		}
+	}
+	//寻找深度最小的失衡节点进行重建
+	for depth, n := range path {
+		if float64(n.left.getSize()) > alpha*float64(n.size) || float64(n.right.getSize()) > alpha*float64(n.size) {
+			m := kd.rebuild(n, depth)
+			if depth == 0 {
+				kd.root = m
+			} else if parent := path[depth-1]; parent.left == n {
+				parent.left = m
+			} else {
+				parent.right = m
+			}
+			//重建时移除了已删除的节点,需要修正祖先节点中记录的数量
+			for _, a := range path[:depth] {
+				a.size -= n.size - m.getSize()
+			}
+			break
+		}
+	}
+	kd.mutex.Unlock()
+	return true
+}
+
+//@title    Erase
+//@description
+//		以kdTree k-d树做接收者
+//		从k-d树中删除一个坐标为coords的点
+//		仅对节点进行标记,当已删除的节点数量超过未删除的点的数量时对整棵树重建
+//		若不存在该坐标的点则删除失败
+//@receiver		kd			*KdTree					接受者kdTree的指针
+//@param    	coords		[]float64				坐标
+//@return    	b			bool					删除成功?
+func (kd *KdTree) Erase(coords []float64) (b bool) {
+	if kd == nil || len(coords) != kd.dim {
+		return false
+	}
+	kd.mutex.Lock()
+	n := kd.root.find(coords)
+	if n == nil {
+		kd.mutex.Unlock()
+		return false
+	}
+	n.deleted = true
+	n.point.Value = nil
+	kd.size--
+	kd.deleted++
+	if kd.deleted > kd.size {
+		kd.root = kd.rebuild(kd.root, 0)
+	}
+	kd.mutex.Unlock()
+	return true
+}
+
+//@title    Find
+//@description
+//		以kdTree k-d树做接收者
+//		查找一个坐标为coords的点并返回
+//		若不存在则返回false
+//@receiver		kd			*KdTree					接受者kdTree的指针
+//@param    	coords		[]float64				坐标
+//@return    	p			Point					找到的点
+//@return    	b			bool					找到了吗?
+func (kd *KdTree) Find(coords []float64) (p Point, b bool) {
+	if kd == nil || len(coords) != kd.dim {
+		return p, false
+	}
+	kd.mutex.Lock()
+	n := kd.root.find(coords)
+	if n == nil {
+		kd.mutex.Unlock()
+		return p, false
+	}
+	p, b = copyPoint(n.point), true
+	kd.mutex.Unlock()
+	return p, b
+}
+
+//@title    Nearest
+//@description
+//		以kdTree k-d树做接收者
+//		返回距离target最近的k个点,按距离从近到远排列
+//		借助容量为k的priority_queue大顶堆保存当前的候选点,并以堆顶的距离对子树进行剪枝
+//		若点的数量不足k个则返回所有点
+//		若坐标的维度数与k-d树不同或k不为正数则返回nil
+//@receiver		kd			*KdTree					接受者kdTree的指针
+//@param    	target		[]float64				查询点
+//@param    	k			int						近邻个数
+//@return    	ps			[]Point					最近的k个点
+func (kd *KdTree) Nearest(target []float64, k int) (ps []Point) {
+	if kd == nil || len(target) != kd.dim || k <= 0 {
+		return nil
+	}
+	kd.mutex.Lock()
+	//距离越大越靠近堆顶
+	pq := priority_queue.New(func(a, b interface{}) int {
+		da, db := a.(neighbor).dist, b.(neighbor).dist
+		if da > db {
+			return -1
+		} else if da < db {
+			return 1
+		}
+		return 0
+	})
+	kd.root.nearest(target, k, pq)
+	ps = make([]Point, pq.Size())
+	for i := len(ps) - 1; i >= 0; i-- {
+		ps[i] = copyPoint(pq.Top().(neighbor).point)
+		pq.Pop()
+	}
+	kd.mutex.Unlock()
+	return ps
+}
+
+//@title    Range
+//@description
+//		以kdTree k-d树做接收者
+//		将所有落在闭区间盒[lo,hi]内的点放入迭代器中
+//		即每一维坐标均在lo和hi对应维度之间的点
+//		若坐标的维度数与k-d树不同则返回nil
+//@receiver		kd			*KdTree					接受者kdTree的指针
+//@param    	lo			[]float64				盒的下界
+//@param    	hi			[]float64				盒的上界
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (kd *KdTree) Range(lo, hi []float64) (i *Iterator.Iterator) {
+	if kd == nil || len(lo) != kd.dim || len(hi) != kd.dim {
+		return nil
+	}
+	kd.mutex.Lock()
+	ps := make([]interface{}, 0)
+	kd.root.rangeSearch(lo, hi, &ps)
+	kd.mutex.Unlock()
+	return Iterator.New(&ps)
+}
+
+//@title    Radius
+//@description
+//		以kdTree k-d树做接收者
+//		将所有到center的欧氏距离不超过r的点按距离从近到远放入迭代器中
+//		若坐标的维度数与k-d树不同或r为负数则返回nil
+//@receiver		kd			*KdTree					接受者kdTree的指针
+//@param    	center		[]float64				球心
+//@param    	r			float64					半径
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (kd *KdTree) Radius(center []float64, r float64) (i *Iterator.Iterator) {
+	if kd == nil || len(center) != kd.dim || r < 0 {
+		return nil
+	}
+	kd.mutex.Lock()
+	ns := make([]interface{}, 0)
+	kd.root.radius(center, r*r, &ns)
+	kd.mutex.Unlock()
+	comparator.Sort(&ns, func(a, b interface{}) int {
+		da, db := a.(neighbor).dist, b.(neighbor).dist
+		if da < db {
+			return -1
+		} else if da > db {
+			return 1
+		}
+		return 0
+	})
+	ps := make([]interface{}, len(ns))
+	for j := range ns {
+		ps[j] = ns[j].(neighbor).point
+	}
+	return Iterator.New(&ps)
+}
@@ -0,0 +1,257 @@
+package kdTree
+
+//@Title		kdTree
+//@Description
+//		k-d树的节点
+//		每个节点保存一个点,并以该点在划分维度上的坐标将子树划分为左右两部分
+//		左子树中的点在划分维度上不大于该点,右子树中的点在划分维度上不小于该点
+//		节点中记录以其为根的子树中的节点数量(包含已删除的节点),用于判断是否需要重建
+//		删除时仅对节点进行标记,在重建时才真正移除
+
+import (
+	"github.com/hlccd/goSTL/data_structure/priority_queue"
+	"github.com/hlccd/goSTL/utils/comparator"
+)
+
+//node树节点结构体
+//该节点是k-d树的树节点
+//axis为该节点的划分维度,即其深度对维度数取模
+//若该节点已被删除则deleted为true
+type node struct {
+	point   Point //存储的点
+	axis    int   //划分维度
+	size    int   //子树中的节点数量,包含已删除的节点
+	deleted bool  //是否已删除
+	left    *node //左节点指针
+	right   *node //右节点指针
+}
+
+//neighbor近邻结构体
+//保存一个点以及其到查询点距离的平方
+type neighbor struct {
+	point Point   //点
+	dist  float64 //距离的平方
+}
+
+//@title    dist
+//@description
+//		返回两个坐标之间欧氏距离的平方
+//@receiver		nil
+//@param    	a			[]float64				坐标
+//@param    	b			[]float64				坐标
+//@return    	d			float64					距离的平方
+func dist(a, b []float64) (d float64) {
+	for i := range a {
+		d += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return d
+}
+
+//@title    equal
+//@description
+//		判断两个坐标是否完全相同
+//@receiver		nil
+//@param    	x			[]float64				坐标
+//@param    	y			[]float64				坐标
+//@return    	b			bool					相同吗?
+func equal(x, y []float64) (b bool) {
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
+
+//@title    build
+//@description
+//		以ps中的点建立一棵平衡的k-d树并返回其根节点
+//		每层以当前维度上的中位数作为根节点,借助NthElement在线性时间内找到中位数
+//		总时间复杂度为O(n log n)
+//@receiver		nil
+//@param    	ps			[]interface{}			待建树的点,元素类型为Point
+//@param    	depth		int						子树根节点的深度
+//@param    	dim			int						维度数
+//@return    	n			*node					子树根节点
+func build(ps []interface{}, depth, dim int) (n *node) {
+	if len(ps) == 0 {
+		return nil
+	}
+	axis := depth % dim
+	mid := len(ps) / 2
+	comparator.NthElement(&ps, mid, func(a, b interface{}) int {
+		ca, cb := a.(Point).Coords[axis], b.(Point).Coords[axis]
+		if ca < cb {
+			return -1
+		} else if ca > cb {
+			return 1
+		}
+		return 0
+	})
+	return &node{
+		point: ps[mid].(Point),
+		axis:  axis,
+		size:  len(ps),
+		left:  build(ps[:mid], depth+1, dim),
+		right: build(ps[mid+1:], depth+1, dim),
+	}
+}
+
+//@title    collect
+//@description
+//		以node节点做接收者
+//		将以该节点为根的子树中所有未删除的点放入ps中
+//@receiver		n			*node					接受者node的指针
+//@param    	ps			*[]interface{}			存放点的切片指针
+//@return    	nil
+func (n *node) collect(ps *[]interface{}) {
+	if n == nil {
+		return
+	}
+	n.left.collect(ps)
+	if !n.deleted {
+		*ps = append(*ps, n.point)
+	}
+	n.right.collect(ps)
+}
+
+//@title    getSize
+//@description
+//		以node节点做接收者
+//		返回以该节点为根的子树中的节点数量
+//@receiver		n			*node					接受者node的指针
+//@param    	nil
+//@return    	num			int						节点数量
+func (n *node) getSize() (num int) {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+//@title    find
+//@description
+//		以node节点做接收者
+//		在以该节点为根的子树中查找坐标为coords的未删除节点
+//		由于相同坐标可能分布在划分点两侧,坐标相等时需要同时查找左右子树
+//@receiver		n			*node					接受者node的指针
+//@param    	coords		[]float64				待查找的坐标
+//@return    	m			*node					找到的节点,未找到则为nil
+func (n *node) find(coords []float64) (m *node) {
+	if n == nil {
+		return nil
+	}
+	if !n.deleted && equal(n.point.Coords, coords) {
+		return n
+	}
+	c := coords[n.axis]
+	if c <= n.point.Coords[n.axis] {
+		if m = n.left.find(coords); m != nil {
+			return m
+		}
+	}
+	if c >= n.point.Coords[n.axis] {
+		return n.right.find(coords)
+	}
+	return nil
+}
+
+//@title    rangeSearch
+//@description
+//		以node节点做接收者
+//		将以该节点为根的子树中所有落在闭区间盒[lo,hi]内的点放入ps中
+//		仅访问可能与该盒相交的子树
+//@receiver		n			*node					接受者node的指针
+//@param    	lo			[]float64				盒的下界
+//@param    	hi			[]float64				盒的上界
+//@param    	ps			*[]interface{}			存放点的切片指针
+//@return    	nil
+func (n *node) rangeSearch(lo, hi []float64, ps *[]interface{}) {
+	if n == nil {
+		return
+	}
+	c := n.point.Coords[n.axis]
+	if lo[n.axis] <= c {
+		n.left.rangeSearch(lo, hi, ps)
+	}
+	if !n.deleted {
+		inside := true
+		for i, x := range n.point.Coords {
+			if x < lo[i] || x > hi[i] {
+				inside = false
+				break
+			}
+		}
+		if inside {
+			*ps = append(*ps, n.point)
+		}
+	}
+	if hi[n.axis] >= c {
+		n.right.rangeSearch(lo, hi, ps)
+	}
+}
+
+//@title    radius
+//@description
+//		以node节点做接收者
+//		将以该节点为根的子树中所有到center的距离平方不超过r2的点放入ns中
+//		若查询点到划分平面的距离已超过半径则跳过另一侧的子树
+//@receiver		n			*node					接受者node的指针
+//@param    	center		[]float64				球心
+//@param    	r2			float64					半径的平方
+//@param    	ns			*[]interface{}			存放近邻的切片指针,元素类型为neighbor
+//@return    	nil
+func (n *node) radius(center []float64, r2 float64, ns *[]interface{}) {
+	if n == nil {
+		return
+	}
+	if !n.deleted {
+		if d := dist(n.point.Coords, center); d <= r2 {
+			*ns = append(*ns, neighbor{point: n.point, dist: d})
+		}
+	}
+	diff := center[n.axis] - n.point.Coords[n.axis]
+	near, far := n.left, n.right
+	if diff > 0 {
+		near, far = far, near
+	}
+	near.radius(center, r2, ns)
+	if diff*diff <= r2 {
+		far.radius(center, r2, ns)
+	}
+}
+
+//@title    nearest
+//@description
+//		以node节点做接收者
+//		在以该节点为根的子树中查找距离target最近的k个点
+//		pq为以距离为序的大顶堆,堆顶为当前找到的k个点中最远的点,堆中元素不超过k个
+//		先搜索target所在一侧的子树,若另一侧可能存在更近的点再搜索另一侧
+//@receiver		n			*node					接受者node的指针
+//@param    	target		[]float64				查询点
+//@param    	k			int						近邻个数
+//@param    	pq			*priority_queue.Priority_queue	保存当前近邻的大顶堆
+//@return    	nil
+func (n *node) nearest(target []float64, k int, pq *priority_queue.Priority_queue) {
+	if n == nil {
+		return
+	}
+	if !n.deleted {
+		d := dist(n.point.Coords, target)
+		if int(pq.Size()) < k {
+			pq.Push(neighbor{point: n.point, dist: d})
+		} else if d < pq.Top().(neighbor).dist {
+			pq.Pop()
+			pq.Push(neighbor{point: n.point, dist: d})
+		}
+	}
+	diff := target[n.axis] - n.point.Coords[n.axis]
+	near, far := n.left, n.right
+	if diff > 0 {
+		near, far = far, near
+	}
+	near.nearest(target, k, pq)
+	if int(pq.Size()) < k || diff*diff < pq.Top().(neighbor).dist {
+		far.nearest(target, k, pq)
+	}
+}
@@ -0,0 +1,148 @@
+package patricia
+
+//@Title		patricia
+//@Description
+//		压缩前缀树的节点
+//		每条边上保存一段字节串作为标签,没有分叉且不承载元素的节点会与其唯一的子节点合并
+//		同一节点的子节点的标签首字节互不相同,并按首字节升序排列
+//		从根节点到某节点路径上所有标签依次拼接即为该节点对应的key
+
+//node树节点结构体
+//该节点是patricia的树节点
+//label为从父节点到该节点的边上的标签
+//若有key以该节点为终点则isEnd为true并承载其元素
+type node struct {
+	label string      //边上的标签
+	sons  []*node     //子节点,按标签首字节升序排列
+	isEnd bool        //是否有key以该节点为终点
+	value interface{} //承载的元素
+}
+
+//@title    newNode
+//@description
+//		新建一个标签为label的节点并返回
+//@receiver		nil
+//@param    	label		string					边上的标签
+//@return    	n        	*node					新建的节点的指针
+func newNode(label string) (n *node) {
+	return &node{
+		label: label,
+		sons:  make([]*node, 0),
+	}
+}
+
+//@title    commonPrefix
+//@description
+//		返回两个string的最长公共前缀的长度
+//@receiver		nil
+//@param    	a			string					string
+//@param    	b			string					string
+//@return    	l			int						最长公共前缀的长度
+func commonPrefix(a, b string) (l int) {
+	for l < len(a) && l < len(b) && a[l] == b[l] {
+		l++
+	}
+	return l
+}
+
+//@title    search
+//@description
+//		以node节点做接收者
+//		在子节点中二分查找第一个标签首字节不小于c的位置
+//@receiver		n			*node					接受者node的指针
+//@param    	c			byte					标签首字节
+//@return    	idx			int						第一个标签首字节不小于c的位置
+func (n *node) search(c byte) (idx int) {
+	l, r := 0, len(n.sons)
+	for l < r {
+		m := (l + r) / 2
+		if n.sons[m].label[0] < c {
+			l = m + 1
+		} else {
+			r = m
+		}
+	}
+	return l
+}
+
+//@title    get
+//@description
+//		以node节点做接收者
+//		返回标签首字节为c的子节点及其下标
+//		不存在时返回nil
+//@receiver		n			*node					接受者node的指针
+//@param    	c			byte					标签首字节
+//@return    	son			*node					子节点
+//@return    	idx			int						子节点的下标
+func (n *node) get(c byte) (son *node, idx int) {
+	idx = n.search(c)
+	if idx < len(n.sons) && n.sons[idx].label[0] == c {
+		return n.sons[idx], idx
+	}
+	return nil, idx
+}
+
+//@title    add
+//@description
+//		以node节点做接收者
+//		在保持有序的前提下添加子节点son,其标签首字节原本不存在
+//@receiver		n			*node					接受者node的指针
+//@param    	son			*node					待添加的子节点
+//@return    	nil
+func (n *node) add(son *node) {
+	idx := n.search(son.label[0])
+	n.sons = append(n.sons, nil)
+	copy(n.sons[idx+1:], n.sons[idx:])
+	n.sons[idx] = son
+}
+
+//@title    remove
+//@description
+//		以node节点做接收者
+//		删除下标为idx的子节点
+//@receiver		n			*node					接受者node的指针
+//@param    	idx			int						子节点的下标
+//@return    	nil
+func (n *node) remove(idx int) {
+	copy(n.sons[idx:], n.sons[idx+1:])
+	n.sons[len(n.sons)-1] = nil
+	n.sons = n.sons[:len(n.sons)-1]
+}
+
+//@title    merge
+//@description
+//		以node节点做接收者
+//		若该节点不承载元素且只有一个子节点,则将子节点合并进该节点
+//		根节点不参与合并
+//@receiver		n			*node					接受者node的指针
+//@param    	nil
+//@return    	nil
+func (n *node) merge() {
+	if n.isEnd || len(n.sons) != 1 {
+		return
+	}
+	son := n.sons[0]
+	n.label += son.label
+	n.sons, n.isEnd, n.value = son.sons, son.isEnd, son.value
+}
+
+//@title    walk
+//@description
+//		以node节点做接收者
+//		按字典序对以该节点为根的子树中的每个key调用f,当f返回false时停止
+//		key为到达该节点时的完整key
+//@receiver		n			*node					接受者node的指针
+//@param    	key			string					到达该节点时的key
+//@param    	f			func(key string, value interface{}) bool	对每个key调用的函数
+//@return    	b			bool					是否遍历了全部key?
+func (n *node) walk(key string, f func(key string, value interface{}) bool) (b bool) {
+	if n.isEnd && !f(key, n.value) {
+		return false
+	}
+	for _, son := range n.sons {
+		if !son.walk(key+son.label, f) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,405 @@
+package patricia
+
+//@Title		patricia
+//@Description
+//		压缩前缀树-Patricia Tree
+//		以字节为单位对key进行索引,没有分叉的链会被压缩为一条带有字节串标签的边
+//		key可以是任意字节串,包括空串,每个key携带一个元素
+//		插入已存在的key时覆盖其元素
+//		支持按字典序遍历、前缀遍历、最长前缀查找以及最小最大key的查找
+//		使用互斥锁实现并发控制
+
+import (
+	"github.com/hlccd/goSTL/utils/iterator"
+	"sync"
+)
+
+//patricia压缩前缀树结构体
+//该实例存储压缩前缀树的根节点
+//根节点的标签为空串
+//同时保存该树中存储的key的数量
+type Patricia struct {
+	root  *node      //根节点指针
+	size  int        //存储的key的数量
+	mutex sync.Mutex //并发控制锁
+}
+
+//patricia压缩前缀树容器接口
+//存放了patricia压缩前缀树可使用的函数
+//对应函数介绍见下方
+type patriciaer interface {
+	Iterator() (i *Iterator.Iterator)                                     //返回按字典序包含所有key的迭代器
+	Size() (num int)                                                      //返回存储的key的数量
+	Clear()                                                               //清空该树
+	Empty() (b bool)                                                      //判断该树是否为空
+	Put(key string, value interface{}) (b bool)                           //存储key及其元素,key已存在时覆盖
+	Get(key string) (value interface{}, b bool)                           //返回key携带的元素
+	Delete(key string) (b bool)                                           //删除key
+	Walk(f func(key string, value interface{}) bool)                      //按字典序遍历所有key
+	WalkPrefix(prefix string, f func(key string, value interface{}) bool) //按字典序遍历以prefix为前缀的所有key
+	LongestPrefix(s string) (key string, value interface{}, b bool)       //返回是s的前缀的最长key
+	Minimum() (key string, value interface{}, b bool)                     //返回字典序最小的key
+	Maximum() (key string, value interface{}, b bool)                     //返回字典序最大的key
+}
+
+//@title    New
+//@description
+//		新建一个patricia压缩前缀树容器并返回
+//		初始根节点的标签为空串
+//@receiver		nil
+//@param    	nil
+//@return    	p        	*Patricia				新建的patricia指针
+func New() (p *Patricia) {
+	return &Patricia{
+		root:  newNode(""),
+		size:  0,
+		mutex: sync.Mutex{},
+	}
+}
+
+//@title    Iterator
+//@description
+//		以patricia压缩前缀树做接收者
+//		将该树中所有key按字典序放入迭代器中并返回
+//@receiver		p			*Patricia				接受者patricia的指针
+//@param    	nil
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (p *Patricia) Iterator() (i *Iterator.Iterator) {
+	if p == nil {
+		return nil
+	}
+	p.mutex.Lock()
+	es := make([]interface{}, 0, p.size)
+	p.root.walk("", func(key string, value interface{}) bool {
+		es = append(es, key)
+		return true
+	})
+	p.mutex.Unlock()
+	return Iterator.New(&es)
+}
+
+//@title    Size
+//@description
+//		以patricia压缩前缀树做接收者
+//		返回该树中存储的key的数量
+//		如果容器为nil返回0
+//@receiver		p			*Patricia				接受者patricia的指针
+//@param    	nil
+//@return    	num        	int						存储的key的数量
+func (p *Patricia) Size() (num int) {
+	if p == nil {
+		return 0
+	}
+	return p.size
+}
+
+//@title    Clear
+//@description
+//		以patricia压缩前缀树做接收者
+//		将该树中存储的所有key清空
+//@receiver		p			*Patricia				接受者patricia的指针
+//@param    	nil
+//@return    	nil
+func (p *Patricia) Clear() {
+	if p == nil {
+		return
+	}
+	p.mutex.Lock()
+	p.root = newNode("")
+	p.size = 0
+	p.mutex.Unlock()
+}
+
+//@title    Empty
+//@description
+//		以patricia压缩前缀树做接收者
+//		判断该树中是否存储了key
+//		如果容器不存在,返回true
+//@receiver		p			*Patricia				接受者patricia的指针
+//@param    	nil
+//@return    	b			bool					该容器是空的吗?
+func (p *Patricia) Empty() (b bool) {
+	if p == nil {
+		return true
+	}
+	return p.size == 0
+}
+
+//@title    Put
+//@description
+//		以patricia压缩前缀树做接收者
+//		存储key及其携带的元素value,key已存在时覆盖其元素
+//		沿key向下,当key与某条边的标签只有部分相同时,在分歧处拆分该边
+//@receiver		p			*Patricia				接受者patricia的指针
+//@param    	key			string					待存储的key
+//@param    	value		interface{}				key携带的元素
+//@return    	b			bool					是否为新增的key?
+func (p *Patricia) Put(key string, value interface{}) (b bool) {
+	if p == nil {
+		return false
+	}
+	p.mutex.Lock()
+	n, s := p.root, key
+	for len(s) > 0 {
+		son, idx := n.get(s[0])
+		if son == nil {
+			//不存在可以继续向下的边,直接新建叶子节点
+			leaf := newNode(s)
+			leaf.isEnd, leaf.value = true, value
+			n.add(leaf)
+			p.size++
+			p.mutex.Unlock()
+			return true
+		}
+		l := commonPrefix(son.label, s)
+		if l < len(son.label) {
+			//在分歧处拆分该边
+			mid := newNode(son.label[:l])
+			son.label = son.label[l:]
+			mid.sons = append(mid.sons, son)
+			n.sons[idx] = mid
+			son = mid
+		}
+		n, s = son, s[l:]
+	}
+	b = !n.isEnd
+	n.isEnd, n.value = true, value
+	if b {
+		p.size++
+	}
+	p.mutex.Unlock()
+	return b
+}
+
+//@title    locate
+//@description
+//		以patricia压缩前缀树做接收者
+//		返回key所对应的节点以及从根节点到其父节点的路径
+//		若不存在与key完全对应的节点则返回nil
+//@receiver		p			*Patricia				接受者patricia的指针
+//@param    	key			string					待查找的key
+//@return    	n			*node					key所对应的节点
+//@return    	path		[]*node					从根节点到其父节点的路径
+func (p *Patricia) locate(key string) (n *node, path []*node) {
+	n, s := p.root, key
+	for len(s) > 0 {
+		son, _ := n.get(s[0])
+		if son == nil || commonPrefix(son.label, s) < len(son.label) {
+			return nil, nil
+		}
+		path = append(path, n)
+		n, s = son, s[len(son.label):]
+	}
+	return n, path
+}
+
+//@title    Get
+//@description
+//		以patricia压缩前缀树做接收者
+//		返回key携带的元素
+//		若key不存在则返回false
+//@receiver		p			*Patricia				接受者patricia的指针
+//@param    	key			string					待查找的key
+//@return    	value		interface{}				key携带的元素
+//@return    	b			bool					key存在吗?
+func (p *Patricia) Get(key string) (value interface{}, b bool) {
+	if p == nil {
+		return nil, false
+	}
+	p.mutex.Lock()
+	if n, _ := p.locate(key); n != nil && n.isEnd {
+		value, b = n.value, true
+		p.mutex.Unlock()
+		return value, b
+	}
+	p.mutex.Unlock()
+	return nil, false
+}
+
+//@title    Delete
+//@description
+//		以patricia压缩前缀树做接收者
+//		删除key及其携带的元素
+//		删除后若该节点没有子节点则将其移除,并尝试将其父节点与父节点剩余的唯一子节点合并
+//		若该节点只有一个子节点则与其合并,以保持压缩
+//		若key不存在则删除失败
+//@receiver		p			*Patricia				接受者patricia的指针
+//@param    	key			string					待删除的key
+//@return    	b			bool					删除成功?
+func (p *Patricia) Delete(key string) (b bool) {
+	if p == nil {
+		return false
+	}
+	p.mutex.Lock()
+	n, path := p.locate(key)
+	if n == nil || !n.isEnd {
+		p.mutex.Unlock()
+		return false
+	}
+	n.isEnd, n.value = false, nil
+	p.size--
+	if n == p.root {
+		p.mutex.Unlock()
+		return true
+	}
+	parent := path[len(path)-1]
+	if len(n.sons) == 0 {
+		_, idx := parent.get(n.label[0])
+		parent.remove(idx)
+		if parent != p.root {
+			parent.merge()
+		}
+	} else {
+		n.merge()
+	}
+	p.mutex.Unlock()
+	return true
+}
+
+//@title    Walk
+//@description
+//		以patricia压缩前缀树做接收者
+//		按字典序对每个key及其元素调用f,当f返回false时停止
+//		调用f时不持有锁,f中可以调用该树的其他函数,见WalkPrefix
+//@receiver		p			*Patricia				接受者patricia的指针
+//@param    	f			func(key string, value interface{}) bool	对每个key调用的函数
+//@return    	nil
+func (p *Patricia) Walk(f func(key string, value interface{}) bool) {
+	p.WalkPrefix("", f)
+}
+
+//@title    WalkPrefix
+//@description
+//		以patricia压缩前缀树做接收者
+//		按字典序对以prefix为前缀的每个key及其元素调用f,当f返回false时停止
+//		prefix可以结束在某条边的中间,此时该边下方的所有key均以prefix为前缀
+//		先在持有锁时收集全部符合条件的key及其元素,释放锁后再依次调用f
+//		因此f中可以调用该树的其他函数,但f看到的是收集时的快照
+//@receiver		p			*Patricia				接受者patricia的指针
+//@param    	prefix		string					前缀
+//@param    	f			func(key string, value interface{}) bool	对每个key调用的函数
+//@return    	nil
+func (p *Patricia) WalkPrefix(prefix string, f func(key string, value interface{}) bool) {
+	if p == nil || f == nil {
+		return
+	}
+	p.mutex.Lock()
+	n, s, key := p.root, prefix, ""
+	for len(s) > 0 {
+		son, _ := n.get(s[0])
+		if son == nil {
+			p.mutex.Unlock()
+			return
+		}
+		l := commonPrefix(son.label, s)
+		if l < len(s) && l < len(son.label) {
+			p.mutex.Unlock()
+			return
+		}
+		n, s, key = son, s[l:], key+son.label
+	}
+	ks, vs := make([]string, 0), make([]interface{}, 0)
+	n.walk(key, func(key string, value interface{}) bool {
+		ks, vs = append(ks, key), append(vs, value)
+		return true
+	})
+	p.mutex.Unlock()
+	for i := range ks {
+		if !f(ks[i], vs[i]) {
+			return
+		}
+	}
+}
+
+//@title    LongestPrefix
+//@description
+//		以patricia压缩前缀树做接收者
+//		返回该树中是s的前缀的最长key及其元素,s本身也视为自身的前缀
+//		若不存在则返回false
+//@receiver		p			*Patricia				接受者patricia的指针
+//@param    	s			string					待查找的string
+//@return    	key			string					最长的前缀key
+//@return    	value		interface{}				该key携带的元素
+//@return    	b			bool					找到了吗?
+func (p *Patricia) LongestPrefix(s string) (key string, value interface{}, b bool) {
+	if p == nil {
+		return "", nil, false
+	}
+	p.mutex.Lock()
+	n, length := p.root, 0
+	for {
+		if n.isEnd {
+			key, value, b = s[:length], n.value, true
+		}
+		if length == len(s) {
+			p.mutex.Unlock()
+			return key, value, b
+		}
+		son, _ := n.get(s[length])
+		if son == nil || commonPrefix(son.label, s[length:]) < len(son.label) {
+			p.mutex.Unlock()
+			return key, value, b
+		}
+		n, length = son, length+len(son.label)
+	}
+}
+
+//@title    Minimum
+//@description
+//		以patricia压缩前缀树做接收者
+//		返回字典序最小的key及其元素
+//		由于前缀小于以其为前缀的string,沿首个子节点向下遇到的第一个key即为最小
+//		若该树为空则返回false
+//@receiver		p			*Patricia				接受者patricia的指针
+//@param    	nil
+//@return    	key			string					最小的key
+//@return    	value		interface{}				该key携带的元素
+//@return    	b			bool					找到了吗?
+func (p *Patricia) Minimum() (key string, value interface{}, b bool) {
+	if p == nil {
+		return "", nil, false
+	}
+	p.mutex.Lock()
+	if p.size == 0 {
+		p.mutex.Unlock()
+		return "", nil, false
+	}
+	n := p.root
+	for !n.isEnd {
+		n = n.sons[0]
+		key += n.label
+	}
+	value, b = n.value, true
+	p.mutex.Unlock()
+	return key, value, b
+}
+
+//@title    Maximum
+//@description
+//		以patricia压缩前缀树做接收者
+//		返回字典序最大的key及其元素
+//		沿最后一个子节点一直向下到叶子节点,该叶子节点的key即为最大
+//		若该树为空则返回false
+//@receiver		p			*Patricia				接受者patricia的指针
+//@param    	nil
+//@return    	key			string					最大的key
+//@return    	value		interface{}				该key携带的元素
+//@return    	b			bool					找到了吗?
+func (p *Patricia) Maximum() (key string, value interface{}, b bool) {
+	if p == nil {
+		return "", nil, false
+	}
+	p.mutex.Lock()
+	if p.size == 0 {
+		p.mutex.Unlock()
+		return "", nil, false
+	}
+	n := p.root
+	for len(n.sons) > 0 {
+		n = n.sons[len(n.sons)-1]
+		key += n.label
+	}
+	value, b = n.value, true
+	p.mutex.Unlock()
+	return key, value, b
+}
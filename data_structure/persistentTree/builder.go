@@ -0,0 +1,203 @@
+package persistentTree
+
+//@Title		persistentTree
+//@Description
+//		可持久化平衡二叉树的批量构建器
+//		构建器持有一个编辑标记,由该标记创建的节点只被构建器自身引用,可直接原地修改
+//		与已发布版本共享的节点在首次修改时复制一次,此后对其的修改均原地进行
+//		Snapshot时构建器更换编辑标记,使已发布的节点不再被原地修改,因此快照只需O(1)
+//		使用互斥锁实现并发控制
+
+import (
+	"github.com/hlccd/goSTL/utils/comparator"
+	"github.com/hlccd/goSTL/utils/iterator"
+	"sync"
+)
+
+//Builder批量构建器结构体
+//该实例存储构建中的根节点和当前的编辑标记
+//同时保存构建中已经存储了多少个元素
+//比较器和是否允许重复继承自创建它的版本
+type Builder struct {
+	root    *node                 //根节点指针
+	size    int                   //存储元素数量
+	cmp     comparator.Comparator //比较器
+	isMulti bool                  //是否允许重复
+	edit    *edit                 //当前编辑标记
+	mutex   sync.Mutex            //并发控制锁
+}
+
+//Builder批量构建器容器接口
+//存放了Builder批量构建器可使用的函数
+//对应函数介绍见下方
+type builderer interface {
+	Iterator() (i *Iterator.Iterator) //返回包含构建器中所有元素的迭代器,重复则返回多个
+	Size() (num int)                  //返回构建器中保存的元素个数
+	Empty() (b bool)                  //判断构建器是否为空
+	Insert(e interface{}) (b bool)    //向构建器中插入元素e
+	Erase(e interface{}) (b bool)     //从构建器中删除元素e
+	Count(e interface{}) (num int)    //从构建器中寻找元素e并返回其个数
+	Snapshot() (t *PersistentTree)    //以O(1)返回构建器当前内容的不可变版本
+}
+
+//@title    Iterator
+//@description
+//		以Builder批量构建器做接收者
+//		将构建器中所有保存的元素将从根节点开始以中缀序列的形式放入迭代器中
+//		若允许重复存储则对于重复元素进行多次放入
+//@receiver		b			*Builder				接受者Builder的指针
+//@param    	nil
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (b *Builder) Iterator() (i *Iterator.Iterator) {
+	if b == nil {
+		return nil
+	}
+	b.mutex.Lock()
+	es := make([]interface{}, 0, b.size)
+	b.root.inOrder(&es)
+	b.mutex.Unlock()
+	return Iterator.New(&es)
+}
+
+//@title    Size
+//@description
+//		以Builder批量构建器做接收者
+//		返回构建器当前含有元素的数量
+//		如果构建器为nil返回0
+//@receiver		b			*Builder				接受者Builder的指针
+//@param    	nil
+//@return    	num        	int						构建器中元素的数量
+func (b *Builder) Size() (num int) {
+	if b == nil {
+		return 0
+	}
+	return b.size
+}
+
+//@title    Empty
+//@description
+//		以Builder批量构建器做接收者
+//		判断构建器是否含有元素
+//		如果含有元素则不为空,返回false
+//		如果不含有元素则说明为空,返回true
+//		如果构建器不存在,返回true
+//@receiver		b			*Builder				接受者Builder的指针
+//@param    	nil
+//@return    	ok			bool					该构建器是空的吗?
+func (b *Builder) Empty() (ok bool) {
+	if b == nil {
+		return true
+	}
+	return b.size == 0
+}
+
+//@title    Insert
+//@description
+//		以Builder批量构建器做接收者
+//		向构建器插入元素e,若不允许重复则对相等元素进行覆盖
+//		构建器自身创建的节点原地修改,与已发布版本共享的节点复制后修改
+//		若构建器尚无比较器则从默认比较器中寻找,找不到时插入失败
+//@receiver		b			*Builder				接受者Builder的指针
+//@param    	e			interface{}				待插入元素
+//@return    	ok			bool					添加成功?
+func (b *Builder) Insert(e interface{}) (ok bool) {
+	if b == nil {
+		return false
+	}
+	b.mutex.Lock()
+	if b.cmp == nil {
+		b.cmp = comparator.GetCmp(e)
+	}
+	if b.cmp == nil {
+		b.mutex.Unlock()
+		return false
+	}
+	b.root, ok = b.root.insert(e, b.isMulti, b.cmp, b.edit)
+	if ok {
+		//插入成功,数量+1
+		b.size++
+	}
+	b.mutex.Unlock()
+	return ok
+}
+
+//@title    Erase
+//@description
+//		以Builder批量构建器做接收者
+//		从构建器中删除元素e
+//		若允许重复记录则对承载元素e的节点中数量记录减一即可
+//		构建器自身创建的节点原地修改,与已发布版本共享的节点复制后修改
+//@receiver		b			*Builder				接受者Builder的指针
+//@param    	e			interface{}				待删除元素
+//@return    	ok			bool					删除成功?
+func (b *Builder) Erase(e interface{}) (ok bool) {
+	if b == nil {
+		return false
+	}
+	b.mutex.Lock()
+	if b.size == 0 {
+		b.mutex.Unlock()
+		return false
+	}
+	b.root, ok = b.root.erase(e, b.cmp, b.edit)
+	if ok {
+		//删除成功,数量-1
+		b.size--
+	}
+	b.mutex.Unlock()
+	return ok
+}
+
+//@title    Count
+//@description
+//		以Builder批量构建器做接收者
+//		从构建器中查找元素e的个数
+//		如果找到则返回构建器中和元素e相同元素的个数
+//		如果不允许重复则最多返回1
+//		如果未找到则返回0
+//@receiver		b			*Builder				接受者Builder的指针
+//@param    	e			interface{}				待查找元素
+//@return    	num			int						待查找元素在构建器中存储的个数
+func (b *Builder) Count(e interface{}) (num int) {
+	if b == nil {
+		return 0
+	}
+	b.mutex.Lock()
+	if b.size == 0 {
+		b.mutex.Unlock()
+		return 0
+	}
+	if n := b.root.find(e, b.cmp); n != nil {
+		num = n.num
+		b.mutex.Unlock()
+		return num
+	}
+	b.mutex.Unlock()
+	return 0
+}
+
+//@title    Snapshot
+//@description
+//		以Builder批量构建器做接收者
+//		以O(1)返回构建器当前内容的不可变版本
+//		返回后构建器更换编辑标记,之后的修改会复制与该版本共享的节点,从而不影响该版本
+//		构建器可继续使用,多次快照得到的版本之间同样共享未修改的子树
+//@receiver		b			*Builder				接受者Builder的指针
+//@param    	nil
+//@return    	t			*PersistentTree			构建器当前内容的版本
+func (b *Builder) Snapshot() (t *PersistentTree) {
+	if b == nil {
+		return nil
+	}
+	b.mutex.Lock()
+	t = &PersistentTree{
+		root:    b.root,
+		size:    b.size,
+		cmp:     b.cmp,
+		isMulti: b.isMulti,
+	}
+	//更换编辑标记,已发布的节点此后只会被复制而不会被修改
+	b.edit = &edit{}
+	b.mutex.Unlock()
+	return t
+}
@@ -0,0 +1,427 @@
+package persistentTree
+
+//@Title		persistentTree
+//@Description
+//		可持久化平衡二叉树的节点
+//		节点一旦被某个版本引用便不再修改,修改时复制从根节点到修改位置的路径上的节点
+//		每个节点记录创建它的编辑标记,持有相同编辑标记的修改可直接在节点上进行而无需复制
+//		通过编辑标记,单次操作和批量构建都只会复制每个节点至多一次
+import (
+	"github.com/hlccd/goSTL/utils/comparator"
+)
+
+//edit编辑标记结构体
+//每次修改操作或每个批量构建器持有一个编辑标记
+//仅当节点的编辑标记与当前修改的编辑标记相同时才可以直接修改该节点
+//该结构体非空,以保证每次新建的编辑标记的指针互不相同
+type edit struct {
+	id int //占位,无实际意义
+}
+
+//node树节点结构体
+//该节点是可持久化平衡二叉树的树节点
+//若该树允许重复则对节点num+1即可,否则对value进行覆盖
+type node struct {
+	value interface{} //节点中存储的元素
+	num   int         //该元素数量
+	depth int         //该节点的深度
+	edit  *edit       //创建该节点的编辑标记
+	left  *node       //左节点指针
+	right *node       //右节点指针
+}
+
+//@title    newNode
+//@description
+//		新建一个可持久化平衡二叉树节点并返回
+//		将传入的元素e作为该节点的承载元素
+//		该节点的num和depth默认为1,左右子节点设为nil
+//@receiver		nil
+//@param    	e			interface{}				承载元素e
+//@param    	ed			*edit					当前修改的编辑标记
+//@return    	n        	*node					新建的节点的指针
+func newNode(e interface{}, ed *edit) (n *node) {
+	return &node{
+		value: e,
+		num:   1,
+		depth: 1,
+		edit:  ed,
+		left:  nil,
+		right: nil,
+	}
+}
+
+//@title    ensure
+//@description
+//		以node可持久化平衡二叉树节点做接收者
+//		返回一个可在当前修改中直接修改的节点
+//		若n节点由当前编辑标记创建则直接返回n,否则复制一份并标记为当前编辑标记
+//@receiver		n			*node					接受者node的指针
+//@param    	ed			*edit					当前修改的编辑标记
+//@return    	m        	*node					可直接修改的节点
+func (n *node) ensure(ed *edit) (m *node) {
+	if n.edit == ed {
+		return n
+	}
+	return &node{
+		value: n.value,
+		num:   n.num,
+		depth: n.depth,
+		edit:  ed,
+		left:  n.left,
+		right: n.right,
+	}
+}
+
+//@title    inOrder
+//@description
+//		以node可持久化平衡二叉树节点做接收者
+//		以中缀序列将节点中的元素放入es中
+//		若允许重复存储则对于重复元素进行多次放入
+//@receiver		n			*node					接受者node的指针
+//@param    	es			*[]interface{}			承载结果的切片指针
+//@return    	nil
+func (n *node) inOrder(es *[]interface{}) {
+	if n == nil {
+		return
+	}
+	n.left.inOrder(es)
+	for i := 0; i < n.num; i++ {
+		*es = append(*es, n.value)
+	}
+	n.right.inOrder(es)
+}
+
+//@title    getDepth
+//@description
+//		以node可持久化平衡二叉树节点做接收者
+//		返回该节点的深度,节点不存在返回0
+//@receiver		n			*node					接受者node的指针
+//@param    	nil
+//@return    	depth       int						该节点的深度
+func (n *node) getDepth() (depth int) {
+	if n == nil {
+		return 0
+	}
+	return n.depth
+}
+
+//@title    max
+//@description
+//		返回a和b中较大的值
+//@receiver		nil
+//@param    	a			int 					带比较的值
+//@param    	b			int 					带比较的值
+//@return    	m        	int						两个值中较大的值
+func max(a, b int) (m int) {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+//@title    update
+//@description
+//		以node可持久化平衡二叉树节点做接收者
+//		根据左右子节点重新计算n节点的深度
+//		调用前需保证n节点可直接修改
+//@receiver		n			*node					接受者node的指针
+//@param    	nil
+//@return    	nil
+func (n *node) update() {
+	n.depth = max(n.left.getDepth(), n.right.getDepth()) + 1
+}
+
+//@title    leftRotate
+//@description
+//		以node可持久化平衡二叉树节点做接收者
+//		将该节点向左节点方向转动,使右节点作为原来节点,并返回右节点
+//		调用前需保证n节点可直接修改,右节点将按需复制
+//@receiver		n			*node					接受者node的指针
+//@param    	ed			*edit					当前修改的编辑标记
+//@return    	m       	*node					旋转后的原节点
+func (n *node) leftRotate(ed *edit) (m *node) {
+	headNode := n.right.ensure(ed)
+	n.right = headNode.left
+	headNode.left = n
+	n.update()
+	headNode.update()
+	return headNode
+}
+
+//@title    rightRotate
+//@description
+//		以node可持久化平衡二叉树节点做接收者
+//		将该节点向右节点方向转动,使左节点作为原来节点,并返回左节点
+//		调用前需保证n节点可直接修改,左节点将按需复制
+//@receiver		n			*node					接受者node的指针
+//@param    	ed			*edit					当前修改的编辑标记
+//@return    	m       	*node					旋转后的原节点
+func (n *node) rightRotate(ed *edit) (m *node) {
+	headNode := n.left.ensure(ed)
+	n.left = headNode.right
+	headNode.right = n
+	n.update()
+	headNode.update()
+	return headNode
+}
+
+//@title    adjust
+//@description
+//		以node可持久化平衡二叉树节点做接收者
+//		对n节点进行旋转以保持节点左右子树平衡
+//		调用前需保证n节点可直接修改
+//@receiver		n			*node					接受者node的指针
+//@param    	ed			*edit					当前修改的编辑标记
+//@return    	m       	*node					调整后的n节点
+func (n *node) adjust(ed *edit) (m *node) {
+	if n.right.getDepth()-n.left.getDepth() >= 2 {
+		//右子树过高,应当对n进行左旋
+		if n.right.right.getDepth() < n.right.left.getDepth() {
+			//右左子树高于右右子树,先右旋右子树
+			n.right = n.right.ensure(ed).rightRotate(ed)
+		}
+		return n.leftRotate(ed)
+	}
+	if n.left.getDepth()-n.right.getDepth() >= 2 {
+		//左子树过高,应当对n进行右旋
+		if n.left.left.getDepth() < n.left.right.getDepth() {
+			//左右子树高于左左子树,先左旋左子树
+			n.left = n.left.ensure(ed).leftRotate(ed)
+		}
+		return n.rightRotate(ed)
+	}
+	return n
+}
+
+//@title    insert
+//@description
+//		以node可持久化平衡二叉树节点做接收者
+//		从n节点中插入元素e,并返回插入后的子树根节点
+//		查找路径上的节点按需复制,其余子树与原版本共享
+//		如果n节点与该元素相等,且允许重复值,则将num+1否则对value进行覆盖
+//		插入成功返回true,不允许重复时进行覆盖返回false
+//@receiver		n			*node					接受者node的指针
+//@param    	e			interface{}				待插入元素
+//@param    	isMulti		bool					是否允许重复?
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@param    	ed			*edit					当前修改的编辑标记
+//@return    	m        	*node					插入后的子树根节点
+//@return    	b        	bool					是否插入成功?
+func (n *node) insert(e interface{}, isMulti bool, cmp comparator.Comparator, ed *edit) (m *node, b bool) {
+	if n == nil {
+		return newNode(e, ed), true
+	}
+	c := cmp(e, n.value)
+	n = n.ensure(ed)
+	if c < 0 {
+		n.left, b = n.left.insert(e, isMulti, cmp, ed)
+	} else if c > 0 {
+		n.right, b = n.right.insert(e, isMulti, cmp, ed)
+	} else if isMulti {
+		//允许重复,数目+1
+		n.num++
+		return n, true
+	} else {
+		//不允许重复,对值进行覆盖
+		n.value = e
+		return n, false
+	}
+	n.update()
+	return n.adjust(ed), b
+}
+
+//@title    erase
+//@description
+//		以node可持久化平衡二叉树节点做接收者
+//		从n节点中删除元素e,并返回删除后的子树根节点
+//		若元素不存在则不复制任何节点,直接返回n
+//		如果n节点与该元素相等且存在重复值,则将num-1,否则删除该节点
+//		删除有两个子节点的节点时,以后继节点的元素替换该节点的元素并删除后继节点
+//@receiver		n			*node					接受者node的指针
+//@param    	e			interface{}				待删除元素
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@param    	ed			*edit					当前修改的编辑标记
+//@return    	m        	*node					删除后的子树根节点
+//@return    	b        	bool					是否删除成功?
+func (n *node) erase(e interface{}, cmp comparator.Comparator, ed *edit) (m *node, b bool) {
+	if n == nil {
+		return nil, false
+	}
+	c := cmp(e, n.value)
+	if c < 0 {
+		var l *node
+		if l, b = n.left.erase(e, cmp, ed); !b {
+			return n, false
+		}
+		n = n.ensure(ed)
+		n.left = l
+	} else if c > 0 {
+		var r *node
+		if r, b = n.right.erase(e, cmp, ed); !b {
+			return n, false
+		}
+		n = n.ensure(ed)
+		n.right = r
+	} else if n.num > 1 {
+		//有重复值,节点无需删除,直接-1即可
+		n = n.ensure(ed)
+		n.num--
+		return n, true
+	} else if n.left != nil && n.right != nil {
+		//以后继节点替换该节点
+		s := n.right
+		for s.left != nil {
+			s = s.left
+		}
+		n = n.ensure(ed)
+		n.value, n.num = s.value, s.num
+		n.right = n.right.eraseMin(ed)
+	} else if n.left != nil {
+		return n.left, true
+	} else {
+		return n.right, true
+	}
+	n.update()
+	return n.adjust(ed), true
+}
+
+//@title    eraseMin
+//@description
+//		以node可持久化平衡二叉树节点做接收者
+//		从以n为根的子树中将承载最小元素的节点整体删除,并返回删除后的子树根节点
+//@receiver		n			*node					接受者node的指针
+//@param    	ed			*edit					当前修改的编辑标记
+//@return    	m        	*node					删除后的子树根节点
+func (n *node) eraseMin(ed *edit) (m *node) {
+	if n.left == nil {
+		return n.right
+	}
+	n = n.ensure(ed)
+	n.left = n.left.eraseMin(ed)
+	n.update()
+	return n.adjust(ed)
+}
+
+//@title    find
+//@description
+//		以node可持久化平衡二叉树节点做接收者
+//		从n节点开始查找与元素e相等的节点
+//		若不存在则返回nil
+//@receiver		n			*node					接受者node的指针
+//@param    	e			interface{}				待查找元素
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	m        	*node					与e相等的节点
+func (n *node) find(e interface{}, cmp comparator.Comparator) (m *node) {
+	for n != nil {
+		c := cmp(e, n.value)
+		if c < 0 {
+			n = n.left
+		} else if c > 0 {
+			n = n.right
+		} else {
+			return n
+		}
+	}
+	return nil
+}
+
+//@title    join
+//@description
+//		将子树l、节点k和子树r连接为一棵平衡的子树并返回其根节点
+//		要求l中的元素均小于k中的元素,r中的元素均大于k中的元素,且k可直接修改
+//		沿较高一侧的内侧路径向下,路径上的节点按需复制
+//@receiver		nil
+//@param    	l			*node					元素较小的子树
+//@param    	k			*node					中间节点
+//@param    	r			*node					元素较大的子树
+//@param    	ed			*edit					当前修改的编辑标记
+//@return    	m        	*node					连接后的子树根节点
+func join(l, k, r *node, ed *edit) (m *node) {
+	if l.getDepth() > r.getDepth()+1 {
+		l = l.ensure(ed)
+		l.right = join(l.right, k, r, ed)
+		l.update()
+		return l.adjust(ed)
+	}
+	if r.getDepth() > l.getDepth()+1 {
+		r = r.ensure(ed)
+		r.left = join(l, k, r.left, ed)
+		r.update()
+		return r.adjust(ed)
+	}
+	k.left, k.right = l, r
+	k.update()
+	return k
+}
+
+//@title    split
+//@description
+//		以node可持久化平衡二叉树节点做接收者
+//		将以n为根的子树按元素e拆分为小于e的子树、与e相等的节点和大于e的子树
+//		拆分不修改原有节点,返回的与e相等的节点仅供读取
+//@receiver		n			*node					接受者node的指针
+//@param    	e			interface{}				拆分依据的元素
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@param    	ed			*edit					当前修改的编辑标记
+//@return    	l        	*node					小于e的元素构成的子树
+//@return    	m        	*node					与e相等的节点
+//@return    	r        	*node					大于e的元素构成的子树
+func (n *node) split(e interface{}, cmp comparator.Comparator, ed *edit) (l, m, r *node) {
+	if n == nil {
+		return nil, nil, nil
+	}
+	c := cmp(e, n.value)
+	if c < 0 {
+		l, m, r = n.left.split(e, cmp, ed)
+		return l, m, join(r, n.ensure(ed), n.right, ed)
+	}
+	if c > 0 {
+		l, m, r = n.right.split(e, cmp, ed)
+		return join(n.left, n.ensure(ed), l, ed), m, r
+	}
+	return n.left, n, n.right
+}
+
+//@title    diff
+//@description
+//		比较以o为根的旧版本子树和以n为根的新版本子树
+//		将新版本中增加的元素放入added,将新版本中减少的元素放入removed
+//		两个版本共享的子树直接跳过,因此比较的代价只与两个版本之间的差异规模有关
+//		以旧版本根节点的元素为界拆分新版本,分别比较左右两侧
+//@receiver		nil
+//@param    	o			*node					旧版本子树
+//@param    	n			*node					新版本子树
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@param    	ed			*edit					当前比较的编辑标记
+//@param    	added		*[]interface{}			承载增加元素的切片指针
+//@param    	removed		*[]interface{}			承载减少元素的切片指针
+//@return    	nil
+func diff(o, n *node, cmp comparator.Comparator, ed *edit, added, removed *[]interface{}) {
+	if o == n {
+		//共享的子树,无差异
+		return
+	}
+	if o == nil {
+		n.inOrder(added)
+		return
+	}
+	if n == nil {
+		o.inOrder(removed)
+		return
+	}
+	l, m, r := n.split(o.value, cmp, ed)
+	diff(o.left, l, cmp, ed, added, removed)
+	if m == nil {
+		for i := 0; i < o.num; i++ {
+			*removed = append(*removed, o.value)
+		}
+	} else {
+		for i := o.num; i < m.num; i++ {
+			*added = append(*added, m.value)
+		}
+		for i := m.num; i < o.num; i++ {
+			*removed = append(*removed, o.value)
+		}
+	}
+	diff(o.right, r, cmp, ed, added, removed)
+}
@@ -0,0 +1,277 @@
+package persistentTree
+
+//@Title		persistentTree
+//@Description
+//		可持久化平衡二叉树-Persistent Balanced Binary Tree
+//		以路径复制的平衡二叉树实现
+//		每个版本保存根节点和比较器以及保存的数量,版本一经创建便不再改变
+//		对某个版本进行插入或删除时,仅复制从根节点到修改位置路径上的节点,并返回新的版本
+//		新旧版本共享未被修改的子树,因此每次修改只需O(logn)的时间和空间
+//		可以在创建时设置节点是否可重复
+//		若节点可重复则增加节点中的数值,否则对节点存储元素进行覆盖
+//		由于版本不可变,多个协程可同时读取同一版本而无需加锁
+//		批量修改时可通过Transient获取批量构建器,构建器内的修改在自身创建的节点上原地进行
+
+import (
+	"github.com/hlccd/goSTL/utils/comparator"
+	"github.com/hlccd/goSTL/utils/iterator"
+)
+
+//persistentTree可持久化平衡二叉树结构体
+//该实例存储某一版本的根节点
+//同时保存该版本已经存储了多少个元素
+//二叉树中排序使用的比较器在创建时传入,若不传入则在插入首个节点时从默认比较器中寻找
+//创建时传入是否允许该二叉树出现重复值,如果不允许则进行覆盖,允许则对节点数目增加即可
+type PersistentTree struct {
+	root    *node                 //根节点指针
+	size    int                   //存储元素数量
+	cmp     comparator.Comparator //比较器
+	isMulti bool                  //是否允许重复
+}
+
+//persistentTree可持久化平衡二叉树容器接口
+//存放了persistentTree可持久化平衡二叉树可使用的函数
+//对应函数介绍见下方
+type persistentTreer interface {
+	Iterator() (i *Iterator.Iterator)                        //返回包含该版本的所有元素,重复则返回多个
+	Size() (num int)                                         //返回该版本中保存的元素个数
+	Empty() (b bool)                                         //判断该版本是否为空
+	Insert(e interface{}) (t *PersistentTree)                //返回插入元素e后的新版本
+	Erase(e interface{}) (t *PersistentTree)                 //返回删除元素e后的新版本
+	Count(e interface{}) (num int)                           //从该版本中寻找元素e并返回其个数
+	Find(e interface{}) (ans interface{})                    //从该版本中寻找与元素e相等的元素并返回
+	Diff(old *PersistentTree) (added, removed []interface{}) //返回相对于旧版本增加和减少的元素
+	Transient() (b *Builder)                                 //以该版本为基础创建批量构建器
+}
+
+//@title    New
+//@description
+//		新建一个persistentTree可持久化平衡二叉树的空版本并返回
+//		初始根节点为nil
+//		传入该二叉树是否为可重复属性,如果为true则保存重复值,否则对原有相等元素进行覆盖
+//		若有传入的比较器,则将传入的第一个比较器设为该二叉树的比较器
+//@receiver		nil
+//@param    	isMulti		bool						该二叉树是否保存重复值?
+//@param    	Cmp			 ...comparator.Comparator	persistentTree比较器集
+//@return    	t        	*PersistentTree				新建的persistentTree指针
+func New(isMulti bool, cmps ...comparator.Comparator) (t *PersistentTree) {
+	//判断是否有传入比较器,若有则设为该二叉树默认比较器
+	var cmp comparator.Comparator
+	if len(cmps) == 0 {
+		cmp = nil
+	} else {
+		cmp = cmps[0]
+	}
+	return &PersistentTree{
+		root:    nil,
+		size:    0,
+		cmp:     cmp,
+		isMulti: isMulti,
+	}
+}
+
+//@title    Iterator
+//@description
+//		以persistentTree可持久化平衡二叉树做接收者
+//		将该版本中所有保存的元素将从根节点开始以中缀序列的形式放入迭代器中
+//		若允许重复存储则对于重复元素进行多次放入
+//@receiver		t			*PersistentTree			接受者persistentTree的指针
+//@param    	nil
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (t *PersistentTree) Iterator() (i *Iterator.Iterator) {
+	if t == nil {
+		return nil
+	}
+	es := make([]interface{}, 0, t.size)
+	t.root.inOrder(&es)
+	return Iterator.New(&es)
+}
+
+//@title    Size
+//@description
+//		以persistentTree可持久化平衡二叉树做接收者
+//		返回该版本含有元素的数量
+//		如果容器为nil返回0
+//@receiver		t			*PersistentTree			接受者persistentTree的指针
+//@param    	nil
+//@return    	num        	int						该版本中元素的数量
+func (t *PersistentTree) Size() (num int) {
+	if t == nil {
+		return 0
+	}
+	return t.size
+}
+
+//@title    Empty
+//@description
+//		以persistentTree可持久化平衡二叉树做接收者
+//		判断该版本是否含有元素
+//		如果含有元素则不为空,返回false
+//		如果不含有元素则说明为空,返回true
+//		如果容器不存在,返回true
+//@receiver		t			*PersistentTree			接受者persistentTree的指针
+//@param    	nil
+//@return    	b			bool					该版本是空的吗?
+func (t *PersistentTree) Empty() (b bool) {
+	if t == nil {
+		return true
+	}
+	return t.size == 0
+}
+
+//@title    Insert
+//@description
+//		以persistentTree可持久化平衡二叉树做接收者
+//		返回向该版本插入元素e后的新版本,该版本本身不会改变
+//		若不允许重复则新版本中相等元素被e覆盖
+//		若该版本尚无比较器则从默认比较器中寻找,找不到时返回该版本本身
+//@receiver		t			*PersistentTree			接受者persistentTree的指针
+//@param    	e			interface{}				待插入元素
+//@return    	v			*PersistentTree			插入后的新版本
+func (t *PersistentTree) Insert(e interface{}) (v *PersistentTree) {
+	if t == nil {
+		return nil
+	}
+	cmp := t.cmp
+	if cmp == nil {
+		cmp = comparator.GetCmp(e)
+	}
+	if cmp == nil {
+		return t
+	}
+	//每次修改使用新的编辑标记,保证旧版本的节点均被复制而非修改
+	root, b := t.root.insert(e, t.isMulti, cmp, &edit{})
+	v = &PersistentTree{
+		root:    root,
+		size:    t.size,
+		cmp:     cmp,
+		isMulti: t.isMulti,
+	}
+	if b {
+		v.size++
+	}
+	return v
+}
+
+//@title    Erase
+//@description
+//		以persistentTree可持久化平衡二叉树做接收者
+//		返回从该版本删除元素e后的新版本,该版本本身不会改变
+//		若允许重复记录则新版本中承载元素e的节点数量减一
+//		若元素e不存在则直接返回该版本本身
+//@receiver		t			*PersistentTree			接受者persistentTree的指针
+//@param    	e			interface{}				待删除元素
+//@return    	v			*PersistentTree			删除后的新版本
+func (t *PersistentTree) Erase(e interface{}) (v *PersistentTree) {
+	if t == nil {
+		return nil
+	}
+	if t.Empty() {
+		return t
+	}
+	root, b := t.root.erase(e, t.cmp, &edit{})
+	if !b {
+		return t
+	}
+	return &PersistentTree{
+		root:    root,
+		size:    t.size - 1,
+		cmp:     t.cmp,
+		isMulti: t.isMulti,
+	}
+}
+
+//@title    Count
+//@description
+//		以persistentTree可持久化平衡二叉树做接收者
+//		从该版本中查找元素e的个数
+//		如果找到则返回该版本中和元素e相同元素的个数
+//		如果不允许重复则最多返回1
+//		如果未找到则返回0
+//@receiver		t			*PersistentTree			接受者persistentTree的指针
+//@param    	e			interface{}				待查找元素
+//@return    	num			int						待查找元素在该版本中存储的个数
+func (t *PersistentTree) Count(e interface{}) (num int) {
+	if t == nil || t.Empty() {
+		return 0
+	}
+	if n := t.root.find(e, t.cmp); n != nil {
+		return n.num
+	}
+	return 0
+}
+
+//@title    Find
+//@description
+//		以persistentTree可持久化平衡二叉树做接收者
+//		从该版本中查找与元素e相等的元素并返回
+//		当比较器仅比较键时,可用于获取键对应的完整元素
+//		如果未找到则返回nil
+//@receiver		t			*PersistentTree			接受者persistentTree的指针
+//@param    	e			interface{}				待查找元素
+//@return    	ans			interface{}				该版本中与e相等的元素
+func (t *PersistentTree) Find(e interface{}) (ans interface{}) {
+	if t == nil || t.Empty() {
+		return nil
+	}
+	if n := t.root.find(e, t.cmp); n != nil {
+		return n.value
+	}
+	return nil
+}
+
+//@title    Diff
+//@description
+//		以persistentTree可持久化平衡二叉树做接收者
+//		比较该版本与旧版本old,返回该版本中增加的元素和减少的元素
+//		两个版本共享的子树将被直接跳过,由同一版本派生的两个版本的比较代价与二者的差异规模相关
+//		元素是否相同仅由比较器判断,不允许重复时被覆盖的元素不视为差异
+//		返回的元素均按从小到大排列,若允许重复则按数量差重复放入
+//		若old为nil则视为空版本
+//@receiver		t			*PersistentTree			接受者persistentTree的指针
+//@param    	old			*PersistentTree			待比较的旧版本
+//@return    	added		[]interface{}			该版本相对旧版本增加的元素
+//@return    	removed		[]interface{}			该版本相对旧版本减少的元素
+func (t *PersistentTree) Diff(old *PersistentTree) (added, removed []interface{}) {
+	added, removed = make([]interface{}, 0), make([]interface{}, 0)
+	var o, n *node
+	if t != nil {
+		n = t.root
+	}
+	if old != nil {
+		o = old.root
+	}
+	cmp := comparator.Comparator(nil)
+	if t != nil && t.cmp != nil {
+		cmp = t.cmp
+	} else if old != nil {
+		cmp = old.cmp
+	}
+	if cmp == nil {
+		//两个版本均未插入过元素
+		return added, removed
+	}
+	diff(o, n, cmp, &edit{}, &added, &removed)
+	return added, removed
+}
+
+//@title    Transient
+//@description
+//		以persistentTree可持久化平衡二叉树做接收者
+//		以该版本为基础创建一个批量构建器并返回
+//		创建过程仅需O(1),构建器与该版本共享全部节点,该版本本身不会被构建器修改
+//@receiver		t			*PersistentTree			接受者persistentTree的指针
+//@param    	nil
+//@return    	b			*Builder				新建的批量构建器指针
+func (t *PersistentTree) Transient() (b *Builder) {
+	if t == nil {
+		return nil
+	}
+	return &Builder{
+		root:    t.root,
+		size:    t.size,
+		cmp:     t.cmp,
+		isMulti: t.isMulti,
+		edit:    &edit{},
+	}
+}
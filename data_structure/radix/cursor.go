@@ -0,0 +1,282 @@
+package radix
+
+//@Title		radix
+//@Description
+//		前缀基数树的游标
+//		游标保存从根节点到当前结点的路径,按段的字典序在所有string之间双向移动
+//		含可选段的string只在其完整形式的终点处被经过一次
+//		游标在移动时才查找下一个string,不会预先生成全部结果
+//		在创建游标后对前缀基数树进行增删将使游标失效
+
+//Cursor游标结构体
+//该游标指向前缀基数树中的某个string
+//游标移出首尾后即失效,可通过First、Last或Seek重新定位
+type Cursor struct {
+	radix *radix  //游标所属的前缀基数树
+	stack []*node //从根节点到当前结点的路径,栈顶为当前结点
+}
+
+//Cursor游标接口
+//存放了Cursor游标可使用的函数
+//对应函数介绍见下方
+type cursorer interface {
+	Valid() (b bool)        //判断该游标是否指向有效的string
+	Key() (s string)        //返回该游标指向的string
+	Value() (e interface{}) //返回该游标指向的string所携带的元素
+	Next() (b bool)         //将该游标移动到下一个string
+	Pre() (b bool)          //将该游标移动到上一个string
+	First() (b bool)        //将该游标移动到第一个string
+	Last() (b bool)         //将该游标移动到最后一个string
+	Seek(s string) (b bool) //将该游标移动到第一个不小于s的string
+}
+
+//@title    Seek
+//@description
+//		以radix前缀基数树做接收者
+//		新建一个游标并将其移动到第一个不小于s的string
+//		比较时按'/'分段后逐段按字典序比较,因此以s为前缀的string紧随其后连续出现
+//@receiver		r			*radix					接受者radix的指针
+//@param    	s			string					待定位的string
+//@return    	c			*Cursor					新建的游标指针
+func (r *radix) Seek(s string) (c *Cursor) {
+	c = &Cursor{radix: r, stack: make([]*node, 0)}
+	if r != nil {
+		c.Seek(s)
+	}
+	return c
+}
+
+//@title    forward
+//@description
+//		以Cursor游标做接收者
+//		按先序将游标向后移动一个结点,不论该结点是否承载string
+//		skip为true时跳过当前结点的子树
+//		已经是最后一个结点时游标失效并返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	skip		bool					是否跳过当前结点的子树
+//@return    	b			bool					移动后游标仍有效吗?
+func (c *Cursor) forward(skip bool) (b bool) {
+	if son := c.stack[len(c.stack)-1].next(""); son != nil && !skip {
+		c.stack = append(c.stack, son)
+		return true
+	}
+	for len(c.stack) > 1 {
+		part := c.stack[len(c.stack)-1].part
+		c.stack = c.stack[:len(c.stack)-1]
+		if son := c.stack[len(c.stack)-1].next(part); son != nil {
+			c.stack = append(c.stack, son)
+			return true
+		}
+	}
+	c.stack = c.stack[:0]
+	return false
+}
+
+//@title    backward
+//@description
+//		以Cursor游标做接收者
+//		按先序将游标向前移动一个结点,不论该结点是否承载string
+//		前一个结点为前一个兄弟结点子树中的最后一个结点,不存在前一个兄弟结点时为父结点
+//		已经是根节点时游标失效并返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					移动后游标仍有效吗?
+func (c *Cursor) backward() (b bool) {
+	if len(c.stack) == 1 {
+		c.stack = c.stack[:0]
+		return false
+	}
+	part := c.stack[len(c.stack)-1].part
+	c.stack = c.stack[:len(c.stack)-1]
+	if son := c.stack[len(c.stack)-1].prev(part); son != nil {
+		for ; son != nil; son = son.prev("") {
+			c.stack = append(c.stack, son)
+		}
+	}
+	return true
+}
+
+//@title    reset
+//@description
+//		以Cursor游标做接收者
+//		将游标置于根节点,前缀基数树为空时游标失效
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					游标有效吗?
+func (c *Cursor) reset() (b bool) {
+	c.stack = c.stack[:0]
+	if c.radix == nil || c.radix.root == nil {
+		return false
+	}
+	c.stack = append(c.stack, c.radix.root)
+	return true
+}
+
+//@title    Valid
+//@description
+//		以Cursor游标做接收者
+//		判断该游标是否指向有效的string
+//		游标不存在或已移出首尾时返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					该游标有效吗?
+func (c *Cursor) Valid() (b bool) {
+	if c == nil {
+		return false
+	}
+	return len(c.stack) > 0
+}
+
+//@title    Key
+//@description
+//		以Cursor游标做接收者
+//		返回该游标当前指向的string
+//		若游标无效则返回空串
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	s			string					游标指向的string
+func (c *Cursor) Key() (s string) {
+	if !c.Valid() {
+		return ""
+	}
+	return c.stack[len(c.stack)-1].pattern
+}
+
+//@title    Value
+//@description
+//		以Cursor游标做接收者
+//		返回该游标当前指向的string所携带的元素
+//		若游标无效则返回nil
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	e			interface{}				游标指向的元素
+func (c *Cursor) Value() (e interface{}) {
+	if !c.Valid() {
+		return nil
+	}
+	return c.stack[len(c.stack)-1].value
+}
+
+//@title    Next
+//@description
+//		以Cursor游标做接收者
+//		将该游标移动到下一个string
+//		按先序依次向后经过各结点,直到抵达某个string完整形式的终点
+//		已经是最后一个string时游标失效并返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					移动后游标仍有效吗?
+func (c *Cursor) Next() (b bool) {
+	if !c.Valid() {
+		return false
+	}
+	c.radix.mutex.RLock()
+	for b = c.forward(false); b && !c.stack[len(c.stack)-1].full; b = c.forward(false) {
+	}
+	c.radix.mutex.RUnlock()
+	return b
+}
+
+//@title    Pre
+//@description
+//		以Cursor游标做接收者
+//		将该游标移动到上一个string
+//		按先序依次向前经过各结点,直到抵达某个string完整形式的终点
+//		已经是第一个string时游标失效并返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					移动后游标仍有效吗?
+func (c *Cursor) Pre() (b bool) {
+	if !c.Valid() {
+		return false
+	}
+	c.radix.mutex.RLock()
+	for b = c.backward(); b && !c.stack[len(c.stack)-1].full; b = c.backward() {
+	}
+	c.radix.mutex.RUnlock()
+	return b
+}
+
+//@title    First
+//@description
+//		以Cursor游标做接收者
+//		将该游标移动到第一个string
+//		前缀基数树为空时游标失效并返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					移动后游标有效吗?
+func (c *Cursor) First() (b bool) {
+	if c == nil || c.radix == nil {
+		return false
+	}
+	c.radix.mutex.RLock()
+	for b = c.reset(); b && !c.stack[len(c.stack)-1].full; b = c.forward(false) {
+	}
+	c.radix.mutex.RUnlock()
+	return b
+}
+
+//@title    Last
+//@description
+//		以Cursor游标做接收者
+//		将该游标移动到最后一个string
+//		即沿段最大的分叉一直向下抵达的结点,若其不是完整形式的终点则继续向前移动
+//		前缀基数树为空时游标失效并返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					移动后游标有效吗?
+func (c *Cursor) Last() (b bool) {
+	if c == nil || c.radix == nil {
+		return false
+	}
+	c.radix.mutex.RLock()
+	if !c.reset() {
+		c.radix.mutex.RUnlock()
+		return false
+	}
+	for son := c.radix.root.prev(""); son != nil; son = son.prev("") {
+		c.stack = append(c.stack, son)
+	}
+	for b = true; b && !c.stack[len(c.stack)-1].full; b = c.backward() {
+	}
+	c.radix.mutex.RUnlock()
+	return b
+}
+
+//@title    Seek
+//@description
+//		以Cursor游标做接收者
+//		将该游标移动到第一个不小于s的string
+//		从根节点开始沿s的各段向下,某一段不存在时进入段更大的第一个分叉,不存在更大的分叉时跳过当前子树
+//		随后向后移动到首个完整形式的终点,不存在时游标失效并返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	s			string					待定位的string
+//@return    	b			bool					移动后游标有效吗?
+func (c *Cursor) Seek(s string) (b bool) {
+	if c == nil || c.radix == nil {
+		return false
+	}
+	ss, _ := analysis(s)
+	c.radix.mutex.RLock()
+	if b = c.reset(); !b {
+		c.radix.mutex.RUnlock()
+		return false
+	}
+	for _, part := range ss {
+		n := c.stack[len(c.stack)-1]
+		if son, ok := n.sons[part]; ok {
+			c.stack = append(c.stack, son)
+			continue
+		}
+		if son := n.next(part); son != nil {
+			c.stack = append(c.stack, son)
+		} else {
+			b = c.forward(true)
+		}
+		break
+	}
+	for ; b && !c.stack[len(c.stack)-1].full; b = c.forward(false) {
+	}
+	c.radix.mutex.RUnlock()
+	return b
+}
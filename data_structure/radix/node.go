@@ -1,6 +1,7 @@
 package radix
 
 import (
+	"sort"
 	"strings"
 )
 
@@ -10,38 +11,125 @@ import (
 //		可通过节点的分叉对string进行查找
 //		增添string时候需要增删结点,同时将结点内置的map中增删对应的string即可
 //		当string到终点时存储元素
+//		含可选段的string会展开为多条路径,它们的终点结点共享同一pattern,只有完整形式的终点计入数量
+//		子结点同时按段的字典序记录在keys中,遍历时按段的字典序依次访问,结果与插入顺序无关
+//		查找时静态段优先于':'动态段,':'动态段优先于'*'通配段,同类动态段中带后缀的、带约束的优先,再按段名排序
+//		匹配失败或约束不满足时回溯尝试下一个候选
 
 //node树节点结构体
 //该节点是radix的树节点
-//结点存储到此时的string的前缀数量
+//结点存储到此时以完整形式存储的string的数量
 //son存储其下属分叉的子结点指针
+//fuzzies按匹配优先级存储其下属的模糊匹配结点
 //该节点同时存储其元素
 type node struct {
 	pattern string           //到终点时不为"",其他都为""
+	full    bool             //到终点时是否为pattern的完整形式
 	part    string           //以当前结点的string内容
 	num     int              //以当前结点为前缀的数量
 	sons    map[string]*node //该结点下属结点的指针
-	fuzzy   bool             //模糊匹配?该结点首字符为':'或'*'为模糊匹配
+	keys    []string         //下属结点的段,按字典序升序
+	fuzzies []*node          //下属的模糊匹配结点,按匹配优先级排序
+	seg     *segment         //模糊匹配结点的段信息,非模糊匹配结点为nil
+	shape   string           //该段的形状
+	value   interface{}      //到终点时承载的元素
 }
 
 //@title    newNode
 //@description
 //		新建一个前缀基数树节点并返回
-//		将传入的元素e作为该节点的承载元素
+//		part需已经过检查,若其为模糊匹配段则同时记录其段信息和形状
 //@receiver		nil
-//@param    	name		string					该节点的名字,即其对应的string
+//@param    	part		string					该节点的名字,即其对应的string
 //@return    	n        	*node					新建的单词查找树节点的指针
 func newNode(part string) (n *node) {
-	fuzzy := false
-	if len(part) > 0 {
-		fuzzy = part[0] == ':' || part[0] == '*'
-	}
-	return &node{
+	sg, _ := parseSegment(part)
+	n = &node{
 		pattern: "",
+		full:    false,
 		part:    part,
 		num:     0,
 		sons:    make(map[string]*node),
-		fuzzy:   fuzzy,
+		keys:    make([]string, 0),
+		fuzzies: make([]*node, 0),
+		seg:     nil,
+		shape:   part,
+		value:   nil,
+	}
+	if sg != nil && sg.kind != 0 {
+		n.seg = sg
+		n.shape = sg.shape(part)
+	}
+	return n
+}
+
+//@title    less
+//@description
+//		判断模糊匹配结点a的优先级是否高于b
+//		':'动态段优先于'*'通配段
+//		':'动态段中后缀更长的优先,其次带约束的优先,最后按段名升序
+//@receiver		nil
+//@param    	a			*node					模糊匹配结点
+//@param    	b			*node					模糊匹配结点
+//@return    	ok			bool					a的优先级更高吗?
+func less(a, b *node) (ok bool) {
+	if a.seg.kind != b.seg.kind {
+		return a.seg.kind == ':'
+	}
+	if len(a.seg.suffix) != len(b.seg.suffix) {
+		return len(a.seg.suffix) > len(b.seg.suffix)
+	}
+	if (a.seg.check == nil) != (b.seg.check == nil) {
+		return a.seg.check != nil
+	}
+	return a.part < b.part
+}
+
+//@title    addSon
+//@description
+//		以node前缀基数树节点做接收者
+//		添加子结点son,同时将其段按字典序放入keys中,若其为模糊匹配结点则按优先级放入fuzzies中
+//@receiver		n			*node					接受者node的指针
+//@param    	son			*node					待添加的子结点
+//@return    	nil
+func (n *node) addSon(son *node) {
+	n.sons[son.part] = son
+	i := sort.SearchStrings(n.keys, son.part)
+	n.keys = append(n.keys, "")
+	copy(n.keys[i+1:], n.keys[i:])
+	n.keys[i] = son.part
+	if son.seg == nil {
+		return
+	}
+	idx := 0
+	for idx < len(n.fuzzies) && less(n.fuzzies[idx], son) {
+		idx++
+	}
+	n.fuzzies = append(n.fuzzies, nil)
+	copy(n.fuzzies[idx+1:], n.fuzzies[idx:])
+	n.fuzzies[idx] = son
+}
+
+//@title    removeSon
+//@description
+//		以node前缀基数树节点做接收者
+//		删除局部string为part的子结点
+//@receiver		n			*node					接受者node的指针
+//@param    	part		string					待删除子结点的局部string
+//@return    	nil
+func (n *node) removeSon(part string) {
+	son, ok := n.sons[part]
+	if !ok {
+		return
+	}
+	delete(n.sons, part)
+	i := sort.SearchStrings(n.keys, part)
+	n.keys = append(n.keys[:i], n.keys[i+1:]...)
+	for i, f := range n.fuzzies {
+		if f == son {
+			n.fuzzies = append(n.fuzzies[:i], n.fuzzies[i+1:]...)
+			break
+		}
 	}
 }
 
@@ -57,7 +145,7 @@ func newNode(part string) (n *node) {
 func analysis(s string) (ss []string, newS string) {
 	vs := strings.Split(s, "/")
 	ss = make([]string, 0)
-	newS = "/"
+	newS = ""
 	for _, item := range vs {
 		if item != "" {
 			ss = append(ss, item)
@@ -67,25 +155,46 @@ func analysis(s string) (ss []string, newS string) {
 			}
 		}
 	}
+	if newS == "" {
+		newS = "/"
+	}
 	return ss, newS
 }
 
+//@title    split
+//@description
+//		将待匹配的string按'/'进行分段
+//		只舍弃为""的部分,以'*'开头的段也视为普通内容
+//		与analysis不同,不会在以'*'开头的段处截断,用于拆分实际请求的路径
+//@receiver		nil
+//@param    	s			string					待拆分的string
+//@return    	ss			[]string				按'/'进行分层拆分后的结果
+func split(s string) (ss []string) {
+	ss = make([]string, 0)
+	for _, item := range strings.Split(s, "/") {
+		if item != "" {
+			ss = append(ss, item)
+		}
+	}
+	return ss
+}
+
 //@title    inOrder
 //@description
 //		以node前缀基数树节点做接收者
-//		遍历其分叉以找到其存储的所有string
+//		按段的字典序遍历其分叉以找到其存储的所有string,结点自身先于其分叉
+//		含可选段的string只在其完整形式的终点处记录一次
 //@receiver		n			*node					接受者node的指针
-//@param    	s			string					到该结点时的前缀string
-//@return    	es        	[]interface{}			以该前缀s为前缀的所有string的集合
-func (n *node) inOrder(s string) (es []interface{}) {
+//@return    	es        	[]interface{}			以该结点为前缀的所有string的集合
+func (n *node) inOrder() (es []interface{}) {
 	if n == nil {
 		return es
 	}
-	if n.pattern != "" {
-		es = append(es, s+n.part)
+	if n.full {
+		es = append(es, n.pattern)
 	}
-	for _, son := range n.sons {
-		es = append(es, son.inOrder(s+n.part+"/")...)
+	for _, key := range n.keys {
+		es = append(es, n.sons[key].inOrder()...)
 	}
 	return es
 }
@@ -97,12 +206,15 @@ func (n *node) inOrder(s string) (es []interface{}) {
 //		当到达s终点时进行插入,如果此时node承载了string则插入失败,否则成功
 //		当未到达终点时,根据当前抵达的位置去寻找其子结点继续遍历即可
 //		当插入失败且对应子结点为新建节点时则需要删除该子结点
+//		只有完整形式插入成功时路径上的结点数量才+1
 //@receiver		n			*node					接受者node的指针
 //@param    	pattern		string					待插入的string整体
-//@param    	ss			[]string				待删除元素的索引s的按'/'进行分层的索引集合
+//@param    	ss			[]string				待插入元素的索引s的按'/'进行分层的索引集合
 //@param    	p			int						索引当前抵达的位置
+//@param    	e			interface{}				string携带的元素
+//@param    	full		bool					是否为pattern的完整形式
 //@return    	b        	bool					是否插入成功?
-func (n *node) insert(pattern string, ss []string, p int) (b bool) {
+func (n *node) insert(pattern string, ss []string, p int, e interface{}, full bool) (b bool) {
 	if p == len(ss) {
 		if n.pattern != "" {
 			//该节点承载了string
@@ -110,7 +222,11 @@ func (n *node) insert(pattern string, ss []string, p int) (b bool) {
 		}
 		//成功插入
 		n.pattern = pattern
-		n.num++
+		n.full = full
+		n.value = e
+		if full {
+			n.num++
+		}
 		return true
 	}
 	//找到该层的string
@@ -120,16 +236,18 @@ func (n *node) insert(pattern string, ss []string, p int) (b bool) {
 	if !ok {
 		//不存在,新建并放入map中
 		son = newNode(s)
-		n.sons[s] = son
+		n.addSon(son)
 	}
 	//从子结点对应方向继续插入
-	b = son.insert(pattern, ss, p+1)
+	b = son.insert(pattern, ss, p+1, e, full)
 	if b {
-		n.num++
+		if full {
+			n.num++
+		}
 	} else {
 		if !ok {
 			//插入失败且该子节点为新建结点则需要删除该子结点
-			delete(n.sons, s)
+			n.removeSon(s)
 		}
 	}
 	return b
@@ -141,168 +259,237 @@ func (n *node) insert(pattern string, ss []string, p int) (b bool) {
 //		从n节点中继续删除以s为索引的元素e,且当前抵达的string位置为p
 //		当到达s终点时进行删除,如果此时node未承载元素则删除失败,否则成功
 //		当未到达终点时,根据当前抵达的位置去寻找其子结点继续遍历即可,若其分叉为nil则直接失败
+//		删除后不再承载string且没有子结点的结点可以销毁
 //@receiver		n			*node					接受者node的指针
 //@param    	ss			[]string				待删除元素的索引s的按'/'进行分层的索引集合
 //@param    	p			int						索引当前抵达的位置
 //@return    	b        	bool					是否删除成功?
-func (n *node) erase(ss []string, p int) (b bool) {
+//@return    	full		bool					删除的是否为完整形式?
+func (n *node) erase(ss []string, p int) (b, full bool) {
 	if p == len(ss) {
 		if n.pattern != "" {
 			//该结点承载是string是,删除成功
+			full = n.full
 			n.pattern = ""
-			n.num--
-			return true
+			n.full = false
+			n.value = nil
+			if full {
+				n.num--
+			}
+			return true, full
 		}
-		return false
+		return false, false
 	}
 	//从map中找到对应下子结点位置并递归进行删除
 	s := ss[p]
 	son, ok := n.sons[s]
 	if !ok || son == nil {
 		//未找到或son不存在,删除失败
-		return false
+		return false, false
 	}
-	b = son.erase(ss, p+1)
+	b, full = son.erase(ss, p+1)
 	if b {
-		n.num--
-		if son.num <= 0 {
-			//删除后子结点的num<=0即该节点无后续存储元素,可以销毁
-			delete(n.sons, s)
+		if full {
+			n.num--
 		}
-	}
-	return b
-}
-
-//@title    delete
-//@description
-//		以node前缀基数树节点做接收者
-//		从n节点中继续删除以s为索引的元素e,且当前抵达的string位置为p
-//		当到达s终点时进行删除,删除所有后续元素,并返回其后续元素的数量
-//		当未到达终点时,根据当前抵达的位置去寻找其子结点继续遍历即可,若其分叉为nil则直接返回0
-//@receiver		n			*node					接受者node的指针
-//@param    	ss			[]string				待删除元素的索引s的按'/'进行分层的索引集合
-//@param    	p			int						索引当前抵达的位置
-//@return    	num        	int						被删除元素的数量
-func (n *node) delete(ss []string, p int) (num int) {
-	if p == len(ss) {
-		return n.num
-	}
-	//从map中找到对应下子结点位置并递归进行删除
-	s := ss[p]
-	son, ok := n.sons[s]
-	if !ok || son == nil {
-		return 0
-	}
-	num = son.delete(ss, p+1)
-	if num > 0 {
-		son.num -= num
-		if son.num <= 0 {
-			//删除后子结点的num<=0即该节点无后续存储元素,可以销毁
-			delete(n.sons, s)
+		if son.pattern == "" && len(son.sons) == 0 {
+			//删除后子结点无后续存储元素,可以销毁
+			n.removeSon(s)
 		}
 	}
-	return num
+	return b, full
 }
 
-//@title    count
+//@title    locate
 //@description
 //		以node前缀基数树节点做接收者
-//		从n节点中继续查找以s为前缀索引的元素e,且当前抵达的string位置为p
-//		当到达s终点时返回其值即可
-//		当未到达终点时,根据当前抵达的位置去寻找其子结点继续遍历即可,当其分叉为nil则直接返回0
+//		从n节点中按段原文逐层找到ss对应的结点,且当前抵达的string位置为p
+//		不进行模糊匹配,若其分叉为nil则直接返回nil
 //@receiver		n			*node					接受者node的指针
-//@param    	ss			[]string				待删除元素的索引s的按'/'进行分层的索引集合
+//@param    	ss			[]string				按'/'进行分层的索引集合
 //@param    	p			int						索引当前抵达的位置
-//@return    	num        	int						以该s为前缀的string的数量
-func (n *node) count(ss []string, p int) (num int) {
+//@return    	q        	*node					对应的结点
+func (n *node) locate(ss []string, p int) (q *node) {
 	if p == len(ss) {
-		return n.num
+		return n
 	}
-	//从map中找到对应下子结点位置并递归进行查找
-	s := ss[p]
-	son, ok := n.sons[s]
+	son, ok := n.sons[ss[p]]
 	if !ok || son == nil {
-		return 0
+		return nil
 	}
-	return son.count(ss, p+1)
+	return son.locate(ss, p+1)
 }
 
 //@title    mate
 //@description
 //		以node前缀基数树节点做接收者
-//		先从radix树的根节点开始找到第一个可以满足该模糊匹配方案的string结点
-//		随后将s和结点的pattern进行模糊映射,将模糊查找的值和匹配值进行映射并返回即可
+//		从radix树的根节点开始按优先级找到第一个可以满足该模糊匹配方案的string结点
+//		查找的同时记录模糊匹配到的参数名和参数内容的映射
 //		若该结点未找到则直接返回nil和false即可
 //@receiver		n			*node					接受者node的指针
-//@param    	ss			[]string				待删除元素的索引s的按'/'进行分层的索引集合
-//@param    	p			int						索引当前抵达的位置
+//@param    	s			string					待匹配的string
+//@return    	q			*node					匹配到的结点
 //@return    	m			map[string]string		s从结点中利用模糊匹配到的所有key和value的映射
 //@return    	ok			bool					匹配成功?
-func (n *node) mate(s string, p int) (m map[string]string, ok bool) {
-	//解析url
-	searchParts, _ := analysis(s)
+func (n *node) mate(s string) (q *node, m map[string]string, ok bool) {
+	//拆分url,请求路径中以'*'开头的段不是通配段,不能截断
+	searchParts := split(s)
+	//动态参数映射表
+	m = make(map[string]string)
 	//从该请求类型中寻找对应的路由结点
-	q := n.find(searchParts, 0)
-	if q != nil {
-		//解析该结点的pattern
-		parts, _ := analysis(q.pattern)
-		//动态参数映射表
-		params := make(map[string]string)
-		for index, part := range parts {
-			if part[0] == ':' {
-				//动态匹配,将参数名和参数内容的映射放入映射表内
-				params[part[1:]] = searchParts[index]
-			}
-			if part[0] == '*' && len(part) > 1 {
-				//通配符,将后续所有内容全部添加到映射表内同时结束遍历
-				params[part[1:]] = strings.Join(searchParts[index:], "/")
-				break
-			}
-		}
-		return params, true
+	if q = n.find(searchParts, 0, m); q != nil {
+		return q, m, true
 	}
-	return nil, false
+	return nil, nil, false
 }
 
 //@title    find
 //@description
 //		以node前缀基数树节点做接收者
-//		从radix树的根节点开始找到第一个可以满足该模糊匹配方案的string结点
+//		从radix树的根节点开始按优先级找到第一个可以满足该模糊匹配方案的string结点
+//		先尝试与当前层string相同的静态结点,再按fuzzies中的顺序尝试动态匹配结点和通配结点
+//		动态匹配结点的后缀或约束不满足时跳过该结点
+//		某个候选的子树中匹配失败时回溯尝试下一个候选,并撤销其放入映射表的参数,因此匹配结果与插入顺序无关
 //		若该结点未找到则直接返回nil
 //@receiver		n			*node					接受者node的指针
-//@param    	ss			[]string				待删除元素的索引s的按'/'进行分层的索引集合
-//@param    	p			int						索引当前抵达的位置
-//@return    	m			map[string]string		s从结点中利用模糊匹配到的所有key和value的映射
-//@return    	ok			bool					匹配成功?
-func (n *node) find(parts []string, height int) (q *node) {
-	//根据长度和局部string的首字符进行判断
-	if len(parts) == height || strings.HasPrefix(n.part, "*") {
+//@param    	parts		[]string				待匹配的string按'/'进行分层的集合
+//@param    	height		int						当前抵达的层数
+//@param    	params		map[string]string		参数名到参数内容的映射表
+//@return    	q			*node					匹配到的结点
+func (n *node) find(parts []string, height int, params map[string]string) (q *node) {
+	//根据长度和结点的段类型进行判断
+	if len(parts) == height || n.seg != nil && n.seg.kind == '*' {
 		if n.pattern == "" {
 			//匹配失败,该结点处无匹配的信息
 			return nil
 		}
+		if n.seg != nil && n.seg.kind == '*' && n.seg.name != "" {
+			//通配符,将后续所有内容全部添加到映射表内
+			params[n.seg.name] = strings.Join(parts[height-1:], "/")
+		}
 		//匹配成功,返回该结点
 		return n
 	}
-	//从该结点的所有子结点中查找可用于递归查找的结点
-	//当局部string信息和当前层string相同时可用于递归查找
-	//当该子结点是动态匹配时也可以用于递归查找
 	part := parts[height]
-	//从所有子结点中找到可用于递归查找的结点
-	children := make([]*node, 0, 0)
-	for _, child := range n.sons {
-		if child.part == part || child.fuzzy {
-			//局部string相同或动态匹配
-			children = append(children, child)
+	//静态结点优先
+	if son, ok := n.sons[part]; ok && son.seg == nil {
+		if q = son.find(parts, height+1, params); q != nil {
+			return q
 		}
 	}
-	for _, child := range children {
-		//递归查询,并根据结果进行判断
-		result := child.find(parts, height+1)
-		if result != nil {
-			//存在一个满足时就可以返回
-			return result
+	//按优先级依次尝试模糊匹配结点
+	for _, son := range n.fuzzies {
+		if son.seg.kind == '*' {
+			if q = son.find(parts, height+1, params); q != nil {
+				return q
+			}
+			continue
+		}
+		v, ok := son.seg.extract(part)
+		if !ok {
+			//后缀或约束不满足,尝试下一个候选
+			continue
 		}
+		if son.seg.name != "" {
+			params[son.seg.name] = v
+		}
+		if q = son.find(parts, height+1, params); q != nil {
+			return q
+		}
+		delete(params, son.seg.name)
 	}
 	return nil
 }
+
+//@title    conflict
+//@description
+//		以node前缀基数树节点做接收者
+//		寻找与按'/'分层后的ss形状相同的已存储string的结点
+//		形状相同即静态段完全相同,且在相同位置上的模糊匹配段除段名外完全相同,即类型、约束和后缀均相同
+//		形状相同的两个string能匹配的string完全一致,只有优先级较高的一个能被匹配到
+//@receiver		n			*node					接受者node的指针
+//@param    	ss			[]string				按'/'进行分层的集合,各段需已经过检查
+//@param    	p			int						索引当前抵达的位置
+//@return    	q			*node					形状相同的结点,不存在时为nil
+func (n *node) conflict(ss []string, p int) (q *node) {
+	if p == len(ss) {
+		if n.pattern == "" {
+			return nil
+		}
+		return n
+	}
+	s := ss[p]
+	sg, _ := parseSegment(s)
+	if sg.kind == 0 {
+		if son, ok := n.sons[s]; ok && son.seg == nil {
+			return son.conflict(ss, p+1)
+		}
+		return nil
+	}
+	shape := sg.shape(s)
+	for _, son := range n.fuzzies {
+		if son.shape == shape {
+			if q = son.conflict(ss, p+1); q != nil {
+				return q
+			}
+		}
+	}
+	return nil
+}
+
+//@title    patterns
+//@description
+//		以node前缀基数树节点做接收者
+//		将以该结点为前缀的所有终点承载的string放入ps中,含可选段的string只记录一次
+//@receiver		n			*node					接受者node的指针
+//@param    	ps			map[string]bool			存放string的集合
+//@return    	nil
+func (n *node) patterns(ps map[string]bool) {
+	if n.pattern != "" {
+		ps[n.pattern] = true
+	}
+	for _, son := range n.sons {
+		son.patterns(ps)
+	}
+}
+
+//@title    next
+//@description
+//		以node前缀基数树节点做接收者
+//		返回段大于part的子结点中段最小的一个,part为""时返回段最小的子结点
+//		不存在时返回nil
+//@receiver		n			*node					接受者node的指针
+//@param    	part		string					段
+//@return    	son			*node					找到的子结点
+func (n *node) next(part string) (son *node) {
+	i := sort.SearchStrings(n.keys, part)
+	if i < len(n.keys) && n.keys[i] == part {
+		i++
+	}
+	if part == "" {
+		i = 0
+	}
+	if i == len(n.keys) {
+		return nil
+	}
+	return n.sons[n.keys[i]]
+}
+
+//@title    prev
+//@description
+//		以node前缀基数树节点做接收者
+//		返回段小于part的子结点中段最大的一个,part为""时返回段最大的子结点
+//		不存在时返回nil
+//@receiver		n			*node					接受者node的指针
+//@param    	part		string					段
+//@return    	son			*node					找到的子结点
+func (n *node) prev(part string) (son *node) {
+	i := sort.SearchStrings(n.keys, part)
+	if part == "" {
+		i = len(n.keys)
+	}
+	if i == 0 {
+		return nil
+	}
+	return n.sons[n.keys[i-1]]
+}
@@ -4,36 +4,59 @@ package radix
 //@Description
 //		前缀基数树-radix
 //		以多叉树的形式实现,根据'/'进行string分割,将分割后的string数组进行段存储
-//		不存储其他元素,仅对string进行分段存储和模糊匹配
-//		使用互斥锁实现并发控制
+//		对string进行分段存储和模糊匹配,每个string可以携带一个元素
+//		模糊匹配时静态段优先于':'动态段,':'动态段优先于'*'通配段,匹配结果与插入顺序无关
+//		动态段可带约束和后缀,如:id<int>、:slug<[a-z-]+>、:file.json,任一段可用'['和']'包裹表示可选,如[:lang]
+//		string可以被命名,通过名字和参数映射可反向构建出对应的路径
+//		迭代器和游标均按'/'分段后逐段按字典序排列,结果与插入顺序无关
+//		使用读写锁实现并发控制,查找和遍历只持有读锁,可以并发进行
 
 import (
 	"github.com/hlccd/goSTL/utils/iterator"
+	"strconv"
 	"sync"
 )
 
 //radix前缀基数树结构体
 //该实例存储前缀基数树的根节点
 //同时保存该树已经存储了多少个元素
+//names存储名字到已命名string的映射
 type radix struct {
-	root  *node      //前缀基数树的根节点指针
-	size  int        //当前已存放的元素数量
-	mutex sync.Mutex //并发控制锁
+	root  *node             //前缀基数树的根节点指针
+	size  int               //当前已存放的元素数量
+	names map[string]string //名字到string的映射
+	mutex sync.RWMutex      //并发控制锁
 }
 
 //radix前缀基数树容器接口
 //存放了radix前缀基数树可使用的函数
 //对应函数介绍见下方
 type radixer interface {
-	Iterator() (i *Iterator.Iterator)             //返回包含该radix的所有string
-	Size() (num int)                              //返回该radix中保存的元素个数
-	Clear()                                       //清空该radix
-	Empty() (b bool)                              //判断该radix是否为空
-	Insert(s string) (b bool)                     //向radix中插入string
-	Erase(s string) (b bool)                      //从radix中删除string
-	Delete(s string) (num int)                    //从radix中删除以s为前缀的所有string
-	Count(s string) (num int)                     //从radix中寻找以s为前缀的string单词数
-	Mate(s string) (m map[string]string, ok bool) //利用radix树中的string对s进行模糊匹配,':'可模糊匹配该层,'*'可模糊匹配后面所有
+	Iterator() (i *Iterator.Iterator)                                //返回包含该radix的所有string
+	Size() (num int)                                                 //返回该radix中保存的元素个数
+	Clear()                                                          //清空该radix
+	Empty() (b bool)                                                 //判断该radix是否为空
+	Insert(s string) (b bool)                                        //向radix中插入string
+	Erase(s string) (b bool)                                         //从radix中删除string
+	Delete(s string) (num int)                                       //从radix中删除以s为前缀的所有string
+	Count(s string) (num int)                                        //从radix中寻找以s为前缀的string单词数
+	Mate(s string) (m map[string]string, ok bool)                    //利用radix树中的string对s进行模糊匹配,':'可模糊匹配该层,'*'可模糊匹配后面所有
+	Store(s string, e interface{}) (b bool)                          //向radix中插入携带元素e的string
+	Match(s string) (m *Match, ok bool)                              //对s进行模糊匹配,返回匹配到的string、其携带的元素和参数映射
+	Conflict(s string) (p string, ok bool)                           //返回radix中与s形状相同的string
+	Name(name, s string) (b bool)                                    //为radix中的string命名
+	URL(name string, params map[string]string) (u string, err error) //根据名字和参数映射构建路径
+	Seek(s string) (c *Cursor)                                       //返回指向第一个不小于s的string的游标
+}
+
+//Match匹配结果结构体
+//Pattern为匹配到的radix中的string,以'/'开头且不含空段
+//Value为该string携带的元素
+//Params为模糊匹配得到的参数名到s中对应内容的映射,带后缀的参数不含后缀,未出现的可选参数不在其中
+type Match struct {
+	Pattern string            //匹配到的string
+	Value   interface{}       //携带的元素
+	Params  map[string]string //参数映射
 }
 
 //@title    New
@@ -47,14 +70,15 @@ func New() (r *radix) {
 	return &radix{
 		root:  newNode(""),
 		size:  0,
-		mutex: sync.Mutex{},
+		names: make(map[string]string),
+		mutex: sync.RWMutex{},
 	}
 }
 
 //@title    Iterator
 //@description
 //		以radix前缀基数树做接收者
-//		将该radix中所有存放的string放入迭代器中并返回
+//		将该radix中所有存放的string按段的字典序放入迭代器中并返回
 //@receiver		r			*radix					接受者radix的指针
 //@param    	nil
 //@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
@@ -62,10 +86,10 @@ func (r *radix) Iterator() (i *Iterator.Iterator) {
 	if r == nil {
 		return nil
 	}
-	r.mutex.Lock()
-	es := r.root.inOrder("")
+	r.mutex.RLock()
+	es := r.root.inOrder()
 	i = Iterator.New(&es)
-	r.mutex.Unlock()
+	r.mutex.RUnlock()
 	return i
 }
 
@@ -102,6 +126,7 @@ func (r *radix) Clear() {
 	r.mutex.Lock()
 	r.root = newNode("")
 	r.size = 0
+	r.names = make(map[string]string)
 	r.mutex.Unlock()
 }
 
@@ -127,7 +152,7 @@ func (r *radix) Empty() (b bool) {
 //		以radix前缀基数树做接收者
 //		向radix插入string
 //		将对string进行解析,按'/'进行分层,':'为首则为模糊匹配该层,'*'为首则为模糊匹配后面所有
-//		已经存在则无法重复插入
+//		已经存在、约束不合法或参数名重复时无法插入
 //@receiver		r			*radix					接受者radix的指针
 //@param    	s			string					待插入string
 //@return    	b			bool					添加成功?
@@ -135,21 +160,46 @@ func (r *radix) Insert(s string) (b bool) {
 	if r == nil {
 		return false
 	}
+	r.mutex.Lock()
+	b = r.store(s, nil)
+	r.mutex.Unlock()
+	return b
+}
+
+//@title    store
+//@description
+//		以radix前缀基数树做接收者
+//		向radix插入携带元素e的string,调用者需持有锁
+//		将s按可选段展开后依次插入,任一形式插入失败时撤销已插入的形式
+//@receiver		r			*radix					接受者radix的指针
+//@param    	s			string					待插入string
+//@param    	e			interface{}				string携带的元素
+//@return    	b			bool					添加成功?
+func (r *radix) store(s string, e interface{}) (b bool) {
 	//解析s并按规则重构s
 	ss, s := analysis(s)
-	r.mutex.Lock()
+	vs, ok := expand(ss)
+	if !ok {
+		return false
+	}
 	if r.root == nil {
 		//避免根节点为nil
 		r.root = newNode("")
 	}
-	//从根节点开始插入
-	b = r.root.insert(s, ss, 0)
-	if b {
-		//插入成功,size+1
-		r.size++
+	for i, v := range vs {
+		if !r.root.insert(s, v, 0, e, i == 0) {
+			for _, u := range vs[:i] {
+				r.root.erase(u, 0)
+			}
+			if r.size == 0 {
+				r.root = nil
+			}
+			return false
+		}
 	}
-	r.mutex.Unlock()
-	return b
+	//插入成功,size+1
+	r.size++
+	return true
 }
 
 //@title    Erase
@@ -170,27 +220,53 @@ func (r *radix) Erase(s string) (b bool) {
 		//根节点为nil即无法删除
 		return false
 	}
-	//解析s并按规则重构s
-	ss, _ := analysis(s)
 	r.mutex.Lock()
+	b = r.erase(s)
+	r.mutex.Unlock()
+	return b
+}
+
+//@title    erase
+//@description
+//		以radix前缀基数树做接收者
+//		从radix树中删除string,调用者需持有锁
+//		s需与插入时的string一致,删除时同时删除其按可选段展开后的所有形式以及其名字
+//@receiver		r			*radix					接受者radix的指针
+//@param    	s			string					待删除的string
+//@return    	b			bool					删除成功?
+func (r *radix) erase(s string) (b bool) {
+	//解析s并按规则重构s
+	ss, s := analysis(s)
+	vs, ok := expand(ss)
+	if !ok {
+		return false
+	}
+	if q := r.root.locate(vs[0], 0); q == nil || !q.full || q.pattern != s {
+		return false
+	}
 	//从根节点开始删除
-	b = r.root.erase(ss, 0)
-	if b {
-		//删除成功,size-1
-		r.size--
-		if r.size == 0 {
-			//所有string都被删除,根节点置为nil
-			r.root = nil
+	for _, v := range vs {
+		r.root.erase(v, 0)
+	}
+	for name, p := range r.names {
+		if p == s {
+			delete(r.names, name)
 		}
 	}
-	r.mutex.Unlock()
-	return b
+	//删除成功,size-1
+	r.size--
+	if r.size == 0 {
+		//所有string都被删除,根节点置为nil
+		r.root = nil
+	}
+	return true
 }
 
 //@title    Delete
 //@description
 //		以radix前缀基数树做接收者
 //		从radix树中删除以s为前缀的所有string
+//		含可选段的string只要有一种形式以s为前缀即被整体删除
 //@receiver		r			*radix					接受者radix的指针
 //@param    	s			string					待删除string的前缀
 //@return    	num			int						被删除的元素的数量
@@ -207,14 +283,17 @@ func (r *radix) Delete(s string) (num int) {
 	//解析s并按规则重构s
 	ss, _ := analysis(s)
 	r.mutex.Lock()
-	//从根节点开始删除
-	num = r.root.delete(ss, 0)
-	if num > 0 {
-		//删除成功
-		r.size -= num
-		if r.size <= 0 {
-			//所有string都被删除,根节点置为nil
-			r.root = nil
+	q := r.root.locate(ss, 0)
+	if q == nil {
+		r.mutex.Unlock()
+		return 0
+	}
+	//找到该前缀下所有终点承载的string,逐个整体删除
+	ps := make(map[string]bool)
+	q.patterns(ps)
+	for p := range ps {
+		if r.erase(p) {
+			num++
 		}
 	}
 	r.mutex.Unlock()
@@ -242,9 +321,11 @@ func (r *radix) Count(s string) (num int) {
 	}
 	//解析s并按规则重构s
 	ss, _ := analysis(s)
-	r.mutex.Lock()
-	num = r.root.count(ss, 0)
-	r.mutex.Unlock()
+	r.mutex.RLock()
+	if q := r.root.locate(ss, 0); q != nil {
+		num = q.num
+	}
+	r.mutex.RUnlock()
 	return num
 }
 
@@ -270,6 +351,160 @@ func (r *radix) Mate(s string) (m map[string]string, ok bool) {
 		return nil, false
 	}
 	//将s按'/'进行分割,并去掉第一个即去掉"",随后一次按照分层结果进行查找
-	m, ok = r.root.mate(s, 0)
+	r.mutex.RLock()
+	_, m, ok = r.root.mate(s)
+	r.mutex.RUnlock()
+	return m, ok
+}
+
+//@title    Store
+//@description
+//		以radix前缀基数树做接收者
+//		向radix插入携带元素e的string
+//		解析规则与Insert相同,已经存在则无法重复插入
+//@receiver		r			*radix					接受者radix的指针
+//@param    	s			string					待插入string
+//@param    	e			interface{}				string携带的元素
+//@return    	b			bool					添加成功?
+func (r *radix) Store(s string, e interface{}) (b bool) {
+	if r == nil {
+		return false
+	}
+	r.mutex.Lock()
+	b = r.store(s, e)
+	r.mutex.Unlock()
+	return b
+}
+
+//@title    Match
+//@description
+//		以radix前缀基数树做接收者
+//		按优先级对s进行模糊匹配,返回匹配到的string、其携带的元素以及参数映射
+//		静态段优先于':'动态段,':'动态段优先于'*'通配段,某个候选匹配失败时回溯尝试下一个
+//		如果未找到则返回nil和false
+//@receiver		r			*radix					接受者radix的指针
+//@param    	s			string					待匹配的string
+//@return    	m			*Match					匹配结果
+//@return    	ok			bool					匹配成功?
+func (r *radix) Match(s string) (m *Match, ok bool) {
+	if r.Empty() {
+		return nil, false
+	}
+	r.mutex.RLock()
+	if r.root == nil {
+		r.mutex.RUnlock()
+		return nil, false
+	}
+	q, params, ok := r.root.mate(s)
+	if ok {
+		m = &Match{Pattern: q.pattern, Value: q.value, Params: params}
+	}
+	r.mutex.RUnlock()
 	return m, ok
 }
+
+//@title    Conflict
+//@description
+//		以radix前缀基数树做接收者
+//		返回radix中与s形状相同的string
+//		形状相同即静态段完全相同,且在相同位置上的模糊匹配段除段名外完全相同
+//		形状相同的string能匹配的内容完全一致,同时存储时只有段名较小的一个能被匹配到
+//		含可选段时对其展开后的每种形式分别检查,展开后的形式之间形状相同时返回s本身
+//		如果不存在则返回false
+//@receiver		r			*radix					接受者radix的指针
+//@param    	s			string					待检查的string
+//@return    	p			string					形状相同的string
+//@return    	ok			bool					存在冲突吗?
+func (r *radix) Conflict(s string) (p string, ok bool) {
+	if r.Empty() {
+		return "", false
+	}
+	ss, s := analysis(s)
+	vs, ok := expand(ss)
+	if !ok {
+		return "", false
+	}
+	r.mutex.RLock()
+	if r.root == nil {
+		r.mutex.RUnlock()
+		return "", false
+	}
+	shapes := make(map[string]bool)
+	for _, v := range vs {
+		if q := r.root.conflict(v, 0); q != nil {
+			p, ok = q.pattern, true
+			r.mutex.RUnlock()
+			return p, ok
+		}
+		key := shapeOf(v)
+		if shapes[key] {
+			r.mutex.RUnlock()
+			return s, true
+		}
+		shapes[key] = true
+	}
+	r.mutex.RUnlock()
+	return "", false
+}
+
+//@title    Int
+//@description
+//		以Match匹配结果做接收者
+//		将参数name的内容按十进制解析为int64并返回
+//		参数不存在或无法解析时返回false
+//@receiver		m			*Match					接受者Match的指针
+//@param    	name		string					参数名
+//@return    	v			int64					参数的值
+//@return    	ok			bool					解析成功?
+func (m *Match) Int(name string) (v int64, ok bool) {
+	if m == nil {
+		return 0, false
+	}
+	s, ok := m.Params[name]
+	if !ok {
+		return 0, false
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	return v, err == nil
+}
+
+//@title    Uint
+//@description
+//		以Match匹配结果做接收者
+//		将参数name的内容按十进制解析为uint64并返回
+//		参数不存在或无法解析时返回false
+//@receiver		m			*Match					接受者Match的指针
+//@param    	name		string					参数名
+//@return    	v			uint64					参数的值
+//@return    	ok			bool					解析成功?
+func (m *Match) Uint(name string) (v uint64, ok bool) {
+	if m == nil {
+		return 0, false
+	}
+	s, ok := m.Params[name]
+	if !ok {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(s, 10, 64)
+	return v, err == nil
+}
+
+//@title    UUID
+//@description
+//		以Match匹配结果做接收者
+//		将参数name的内容按8-4-4-4-12的十六进制形式解析为16字节的UUID并返回
+//		参数不存在或无法解析时返回false
+//@receiver		m			*Match					接受者Match的指针
+//@param    	name		string					参数名
+//@return    	u			[16]byte				参数的值
+//@return    	ok			bool					解析成功?
+func (m *Match) UUID(name string) (u [16]byte, ok bool) {
+	if m == nil {
+		return u, false
+	}
+	s, ok := m.Params[name]
+	if !ok {
+		return u, false
+	}
+	return parseUUID(s)
+}
@@ -0,0 +1,244 @@
+package radix
+
+//@Title		radix
+//@Description
+//		前缀基数树中单个段的解析与匹配
+//		段可以是以下几种形式:
+//			static			静态段,只匹配完全相同的内容
+//			:name			动态段,匹配任意非空的一段
+//			:name<int>		带约束的动态段,约束可以是int、uint、uuid,其余内容视为正则表达式,需完整匹配
+//			:name.json		带后缀的动态段,该段必须以后缀结尾,参数内容为去掉后缀后的部分,也可与约束同时使用
+//			*name			通配段,匹配之后的所有段
+//		任一段都可以用'['和']'包裹表示可选,如[:lang],插入时会展开为包含和不包含该段的所有形式
+//		约束不满足时该段匹配失败,查找会回溯尝试下一个候选
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+//segment段结构体
+//kind为0表示静态段,':'表示动态段,'*'表示通配段
+//constraint为约束的原文,check为对应的检查函数,无约束时为nil
+type segment struct {
+	kind       byte                //段的类型
+	name       string              //参数名
+	constraint string              //约束原文
+	check      func(v string) bool //约束检查函数
+	suffix     string              //后缀
+}
+
+//@title    isNameByte
+//@description
+//		判断c是否可以出现在参数名中,即字母、数字或'_'
+//@receiver		nil
+//@param    	c			byte					待判断的字节
+//@return    	ok			bool					可以出现吗?
+func isNameByte(c byte) (ok bool) {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+//@title    parseUUID
+//@description
+//		将8-4-4-4-12形式的十六进制string解析为16字节的UUID
+//@receiver		nil
+//@param    	s			string					待解析的string
+//@return    	u			[16]byte				解析结果
+//@return    	ok			bool					解析成功?
+func parseUUID(s string) (u [16]byte, ok bool) {
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, false
+	}
+	hex := func(c byte) (v byte, ok bool) {
+		switch {
+		case c >= '0' && c <= '9':
+			return c - '0', true
+		case c >= 'a' && c <= 'f':
+			return c - 'a' + 10, true
+		case c >= 'A' && c <= 'F':
+			return c - 'A' + 10, true
+		}
+		return 0, false
+	}
+	for i, j := 0, 0; i < 36; j++ {
+		if s[i] == '-' {
+			i++
+		}
+		hi, ok1 := hex(s[i])
+		lo, ok2 := hex(s[i+1])
+		if !ok1 || !ok2 {
+			return u, false
+		}
+		u[j] = hi<<4 | lo
+		i += 2
+	}
+	return u, true
+}
+
+//@title    newCheck
+//@description
+//		根据约束原文返回对应的检查函数
+//		int、uint、uuid为内置约束,其余内容编译为需完整匹配的正则表达式
+//		正则表达式不合法时返回false
+//@receiver		nil
+//@param    	constraint	string					约束原文
+//@return    	check		func(v string) bool		检查函数
+//@return    	ok			bool					约束合法吗?
+func newCheck(constraint string) (check func(v string) bool, ok bool) {
+	switch constraint {
+	case "int":
+		return func(v string) bool {
+			_, err := strconv.ParseInt(v, 10, 64)
+			return err == nil
+		}, true
+	case "uint":
+		return func(v string) bool {
+			_, err := strconv.ParseUint(v, 10, 64)
+			return err == nil
+		}, true
+	case "uuid":
+		return func(v string) bool {
+			_, ok := parseUUID(v)
+			return ok
+		}, true
+	}
+	re, err := regexp.Compile("^(?:" + constraint + ")$")
+	if err != nil {
+		return nil, false
+	}
+	return re.MatchString, true
+}
+
+//@title    parseSegment
+//@description
+//		解析不含'['和']'的单个段
+//		参数名可以为空,此时匹配到的内容不放入参数映射中,通配段不可带约束和后缀
+//		段不合法时返回false
+//@receiver		nil
+//@param    	part		string					待解析的段
+//@return    	sg			*segment				解析结果
+//@return    	ok			bool					段合法吗?
+func parseSegment(part string) (sg *segment, ok bool) {
+	sg = &segment{}
+	if part == "" || part[0] != ':' && part[0] != '*' {
+		return sg, true
+	}
+	sg.kind = part[0]
+	i := 1
+	for i < len(part) && isNameByte(part[i]) {
+		i++
+	}
+	sg.name = part[1:i]
+	if sg.kind == '*' {
+		return sg, i == len(part)
+	}
+	if i < len(part) && part[i] == '<' {
+		end := strings.LastIndexByte(part, '>')
+		if end <= i+1 {
+			return nil, false
+		}
+		sg.constraint = part[i+1 : end]
+		if sg.check, ok = newCheck(sg.constraint); !ok {
+			return nil, false
+		}
+		i = end + 1
+	}
+	sg.suffix = part[i:]
+	return sg, true
+}
+
+//@title    extract
+//@description
+//		以segment段做接收者
+//		用动态段匹配请求中的一段v,返回参数内容
+//		带后缀时v必须以后缀结尾且去掉后缀后非空,带约束时参数内容必须满足约束
+//@receiver		sg			*segment				接受者segment的指针
+//@param    	v			string					请求中的一段
+//@return    	value		string					参数内容
+//@return    	ok			bool					匹配成功?
+func (sg *segment) extract(v string) (value string, ok bool) {
+	if len(v) <= len(sg.suffix) || !strings.HasSuffix(v, sg.suffix) {
+		return "", false
+	}
+	value = v[:len(v)-len(sg.suffix)]
+	if sg.check != nil && !sg.check(value) {
+		return "", false
+	}
+	return value, true
+}
+
+//@title    shape
+//@description
+//		以segment段做接收者
+//		返回该段去掉参数名后的形状,形状相同的段能匹配的内容完全一致
+//@receiver		sg			*segment				接受者segment的指针
+//@param    	part		string					段的原文
+//@return    	s			string					段的形状
+func (sg *segment) shape(part string) (s string) {
+	switch sg.kind {
+	case ':':
+		return ":<" + sg.constraint + ">" + sg.suffix
+	case '*':
+		return "*"
+	}
+	return part
+}
+
+//@title    expand
+//@description
+//		将按'/'分层后的段展开为所有可选段取舍后的形式
+//		同时去掉可选段两侧的'['和']',并检查各段是否合法
+//		第一个结果包含所有可选段,即完整形式
+//		通配段只能是最后一段,非空的参数名不可重复,不合法时返回false
+//@receiver		nil
+//@param    	ss			[]string				按'/'分层后的段
+//@return    	vs			[][]string				展开后的所有形式
+//@return    	ok			bool					合法吗?
+func expand(ss []string) (vs [][]string, ok bool) {
+	vs = [][]string{make([]string, 0, len(ss))}
+	names := make(map[string]bool)
+	for i, s := range ss {
+		optional := len(s) > 2 && s[0] == '[' && s[len(s)-1] == ']'
+		if optional {
+			s = s[1 : len(s)-1]
+		}
+		sg, ok := parseSegment(s)
+		if !ok || strings.ContainsAny(s, "[]") && sg.kind == 0 {
+			return nil, false
+		}
+		if sg.name != "" {
+			if names[sg.name] {
+				return nil, false
+			}
+			names[sg.name] = true
+		}
+		if sg.kind == '*' && i != len(ss)-1 {
+			return nil, false
+		}
+		n := len(vs)
+		for j := 0; j < n; j++ {
+			if optional {
+				//不包含该段的形式放在后面,保证第一个为完整形式
+				vs = append(vs, append(make([]string, 0, len(ss)), vs[j]...))
+			}
+			vs[j] = append(vs[j], s)
+		}
+	}
+	return vs, true
+}
+
+//@title    shapeOf
+//@description
+//		返回按'/'分层后的ss的整体形状,各段需已经过检查
+//		形状相同的两个string能匹配的内容完全一致
+//@receiver		nil
+//@param    	ss			[]string				按'/'分层后的段
+//@return    	key			string					整体形状
+func shapeOf(ss []string) (key string) {
+	for _, s := range ss {
+		sg, _ := parseSegment(s)
+		key += "/" + sg.shape(s)
+	}
+	return key
+}
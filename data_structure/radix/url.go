@@ -0,0 +1,158 @@
+package radix
+
+//@Title		radix
+//@Description
+//		前缀基数树的反向路由
+//		为已存储的string命名后,可通过名字和参数映射构建出能被该string匹配到的路径
+//		参数内容会进行转义,缺少必需的参数或参数不满足约束时返回错误
+//		匹配是在转义还原后的路径上进行的,因此':'动态段的内容不可含有'/'
+//		"."和".."段会被客户端和path.Clean规范化掉,因此参数也不可构建出这样的段
+//		构建出的路径会按匹配规则重新匹配一次,被优先级更高的string匹配到时同样返回错误
+//		可选段中的参数未给出时省略该段
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+var (
+	ErrUnknownName  = errors.New("radix: unknown route name")      //名字不存在
+	ErrMissingParam = errors.New("radix: missing route parameter") //缺少参数
+	ErrInvalidParam = errors.New("radix: invalid route parameter") //参数不满足约束或构建出的路径无法匹配回该string
+)
+
+//@title    Name
+//@description
+//		以radix前缀基数树做接收者
+//		为radix中已存储的string命名,s需与插入时的string一致
+//		s不存在或名字已被其他string使用时命名失败,string被删除时其名字一同删除
+//@receiver		r			*radix					接受者radix的指针
+//@param    	name		string					名字
+//@param    	s			string					已存储的string
+//@return    	b			bool					命名成功?
+func (r *radix) Name(name, s string) (b bool) {
+	if r == nil || name == "" {
+		return false
+	}
+	ss, s := analysis(s)
+	vs, ok := expand(ss)
+	if !ok {
+		return false
+	}
+	r.mutex.Lock()
+	if r.root == nil {
+		r.mutex.Unlock()
+		return false
+	}
+	if q := r.root.locate(vs[0], 0); q == nil || !q.full || q.pattern != s {
+		r.mutex.Unlock()
+		return false
+	}
+	if r.names == nil {
+		r.names = make(map[string]string)
+	}
+	if p, ok := r.names[name]; ok {
+		r.mutex.Unlock()
+		return p == s
+	}
+	r.names[name] = s
+	r.mutex.Unlock()
+	return true
+}
+
+//@title    URL
+//@description
+//		以radix前缀基数树做接收者
+//		找到名字为name的string,用params中的参数内容替换其模糊匹配段构建路径
+//		静态段原样保留,':'动态段的内容经转义后加上后缀,'*'通配段的内容按'/'分段后逐段转义
+//		参数内容为""时视为未给出,可选段中的参数未给出时省略该段
+//		构建完成后用未转义的路径重新匹配,匹配到的不是该string时说明参数与优先级更高的string冲突,如与静态段相同
+//		名字不存在时返回ErrUnknownName,缺少必需的参数时返回ErrMissingParam
+//		参数不满足约束、':'动态段的内容含有'/'、参数构建出"."或".."段或路径无法匹配回该string时返回ErrInvalidParam
+//@receiver		r			*radix					接受者radix的指针
+//@param    	name		string					名字
+//@param    	params		map[string]string		参数名到参数内容的映射
+//@return    	u			string					构建出的路径
+//@return    	err			error					构建失败时的错误
+func (r *radix) URL(name string, params map[string]string) (u string, err error) {
+	if r == nil {
+		return "", fmt.Errorf("%w: %q", ErrUnknownName, name)
+	}
+	r.mutex.RLock()
+	pattern, ok := r.names[name]
+	r.mutex.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownName, name)
+	}
+	//raw为未转义的路径,用于重新匹配
+	raw := ""
+	ss, _ := analysis(pattern)
+	for _, s := range ss {
+		optional := len(s) > 2 && s[0] == '[' && s[len(s)-1] == ']'
+		if optional {
+			s = s[1 : len(s)-1]
+		}
+		sg, _ := parseSegment(s)
+		if sg.kind == 0 {
+			u += "/" + s
+			raw += "/" + s
+			continue
+		}
+		v := params[sg.name]
+		if v == "" {
+			if optional {
+				continue
+			}
+			return "", fmt.Errorf("%w: %q needs %q", ErrMissingParam, pattern, sg.name)
+		}
+		if sg.kind == '*' {
+			rest := ""
+			for _, part := range strings.Split(v, "/") {
+				if part == "." || part == ".." {
+					return "", fmt.Errorf("%w: %q for %q contains a dot segment", ErrInvalidParam, v, sg.name)
+				}
+				if part != "" {
+					rest += "/" + url.PathEscape(part)
+					raw += "/" + part
+				}
+			}
+			if rest == "" {
+				if optional {
+					continue
+				}
+				return "", fmt.Errorf("%w: %q needs %q", ErrMissingParam, pattern, sg.name)
+			}
+			u += rest
+			continue
+		}
+		if strings.Contains(v, "/") {
+			return "", fmt.Errorf("%w: %q for %q contains '/'", ErrInvalidParam, v, sg.name)
+		}
+		if seg := v + sg.suffix; seg == "." || seg == ".." {
+			return "", fmt.Errorf("%w: %q for %q is a dot segment", ErrInvalidParam, v, sg.name)
+		}
+		if sg.check != nil && !sg.check(v) {
+			return "", fmt.Errorf("%w: %q does not satisfy %q in %q", ErrInvalidParam, v, sg.constraint, pattern)
+		}
+		u += "/" + url.PathEscape(v) + sg.suffix
+		raw += "/" + v + sg.suffix
+	}
+	if u == "" {
+		u = "/"
+	}
+	//重新匹配,确认构建出的路径能被该string匹配到
+	r.mutex.RLock()
+	p := ""
+	if r.root != nil {
+		if q, _, ok := r.root.mate(raw); ok {
+			p = q.pattern
+		}
+	}
+	r.mutex.RUnlock()
+	if p != pattern {
+		return "", fmt.Errorf("%w: %q would match %q instead of %q", ErrInvalidParam, u, p, pattern)
+	}
+	return u, nil
+}
@@ -0,0 +1,57 @@
+package radix
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+)
+
+func TestRadix_URL(t *testing.T) {
+	r := New()
+	for _, p := range []string{"/s/:q", "/f/*path", "/x/:id.json", "/users/new", "/users/:id"} {
+		if !r.Store(p, p) || !r.Name(p, p) {
+			t.Fatalf("Store(%q) failed", p)
+		}
+	}
+	//构建出的路径经转义还原后应能匹配回该string并得到相同的参数
+	cases := []struct {
+		pattern, name, value string
+	}{
+		{"/s/:q", "q", "a b"},
+		{"/s/:q", "q", "*"},
+		{"/s/:q", "q", "..."},
+		{"/f/*path", "path", "a/*b/c"},
+		{"/f/*path", "path", "*"},
+		{"/x/:id.json", "id", "."},
+		{"/users/:id", "id", "42"},
+	}
+	for _, c := range cases {
+		u, err := r.URL(c.pattern, map[string]string{c.name: c.value})
+		if err != nil {
+			t.Fatalf("URL(%q, %q) = %v", c.pattern, c.value, err)
+		}
+		raw, err := url.PathUnescape(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, ok := r.Match(raw)
+		if !ok || m.Pattern != c.pattern || m.Params[c.name] != c.value {
+			t.Fatalf("URL(%q, %q) = %q does not match back", c.pattern, c.value, u)
+		}
+	}
+	//点段会被规范化掉,与静态段相同的参数会被优先级更高的string匹配到
+	for _, c := range []struct {
+		pattern, name, value string
+	}{
+		{"/s/:q", "q", "."},
+		{"/s/:q", "q", ".."},
+		{"/f/*path", "path", "a/../b"},
+		{"/f/*path", "path", "./a"},
+		{"/s/:q", "q", "a/b"},
+		{"/users/:id", "id", "new"},
+	} {
+		if _, err := r.URL(c.pattern, map[string]string{c.name: c.value}); !errors.Is(err, ErrInvalidParam) {
+			t.Fatalf("URL(%q, %q) = %v, want ErrInvalidParam", c.pattern, c.value, err)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package rbTree
+
+//@Title		rbTree
+//@Description
+//		红黑树的游标
+//		借助节点中的双亲节点指针,游标只需保存当前节点即可双向移动
+//		从任意位置开始遍历k个元素的总时间复杂度为O(log n + k)
+//		游标不持有元素的副本,在创建游标后对红黑树进行增删将使游标失效
+
+//Cursor游标结构体
+//该游标指向红黑树中的某一个元素
+//若节点中存储了多个重复元素,则游标依次经过每一个重复元素
+//当游标移出红黑树的范围后即失效,此后无法再移动
+type Cursor struct {
+	tree *RbTree //游标所属的红黑树
+	node *node   //当前节点
+	idx  int     //当前元素在节点重复元素中的序号
+}
+
+//Cursor游标接口
+//存放了Cursor游标可使用的函数
+//对应函数介绍见下方
+type cursorer interface {
+	Valid() (b bool)        //判断该游标是否指向有效元素
+	Value() (e interface{}) //返回该游标指向的元素
+	Next() (b bool)         //将该游标后移一位
+	Pre() (b bool)          //将该游标前移一位
+}
+
+//@title    Valid
+//@description
+//		以Cursor游标做接收者
+//		判断该游标是否指向红黑树中的有效元素
+//		游标不存在或已移出范围时返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					该游标有效吗?
+func (c *Cursor) Valid() (b bool) {
+	if c == nil {
+		return false
+	}
+	return c.node != nil
+}
+
+//@title    Value
+//@description
+//		以Cursor游标做接收者
+//		返回该游标当前指向的元素
+//		若游标无效则返回nil
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	e			interface{}				游标指向的元素
+func (c *Cursor) Value() (e interface{}) {
+	if !c.Valid() {
+		return nil
+	}
+	return c.node.value
+}
+
+//@title    Next
+//@description
+//		以Cursor游标做接收者
+//		将该游标移动到下一个元素,即中缀序列中的后一个元素
+//		若当前节点仍有未经过的重复元素则只移动序号
+//		否则寻找后继节点:存在右子树则为右子树的最左节点,否则为首个从左侧回溯到的祖先节点
+//		移出范围后游标失效并返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					移动后游标仍有效吗?
+func (c *Cursor) Next() (b bool) {
+	if !c.Valid() {
+		return false
+	}
+	c.tree.mutex.Lock()
+	if c.idx+1 < c.node.num {
+		//当前节点仍有重复元素
+		c.idx++
+		c.tree.mutex.Unlock()
+		return true
+	}
+	c.idx = 0
+	c.node = c.node.next()
+	c.tree.mutex.Unlock()
+	return c.node != nil
+}
+
+//@title    Pre
+//@description
+//		以Cursor游标做接收者
+//		将该游标移动到上一个元素,即中缀序列中的前一个元素
+//		若当前节点仍有未经过的重复元素则只移动序号
+//		否则寻找前驱节点:存在左子树则为左子树的最右节点,否则为首个从右侧回溯到的祖先节点
+//		移出范围后游标失效并返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					移动后游标仍有效吗?
+func (c *Cursor) Pre() (b bool) {
+	if !c.Valid() {
+		return false
+	}
+	c.tree.mutex.Lock()
+	if c.idx > 0 {
+		//当前节点仍有重复元素
+		c.idx--
+		c.tree.mutex.Unlock()
+		return true
+	}
+	c.node = c.node.pre()
+	if c.node != nil {
+		c.idx = c.node.num - 1
+	}
+	c.tree.mutex.Unlock()
+	return c.node != nil
+}
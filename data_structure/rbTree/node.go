@@ -0,0 +1,254 @@
+package rbTree
+
+//@Title		rbTree
+//@Description
+//		红黑树的节点
+//		可通过节点实现红黑树的添加删除
+//		也可通过节点返回整个红黑树的所有元素
+//		增减节点后通过变色和左右旋转的方式保持红黑树的性质
+import (
+	"github.com/hlccd/goSTL/utils/comparator"
+)
+
+//红黑树节点的颜色
+const (
+	red   = true  //红色
+	black = false //黑色
+)
+
+//node树节点结构体
+//该节点是红黑树的树节点
+//若该红黑树允许重复则对节点num+1即可,否则对value进行覆盖
+//节点中保存双亲节点指针,便于插入删除后自下而上进行修复
+type node struct {
+	value  interface{} //节点中存储的元素
+	num    int         //该元素数量
+	color  bool        //该节点的颜色,true为红色,false为黑色
+	parent *node       //双亲节点指针
+	left   *node       //左节点指针
+	right  *node       //右节点指针
+}
+
+//@title    newNode
+//@description
+//		新建一个红黑树节点并返回
+//		将传入的元素e作为该节点的承载元素
+//		该节点的num默认为1,颜色默认为红色,左右子节点设为nil
+//@receiver		nil
+//@param    	e			interface{}				承载元素e
+//@param    	parent		*node					双亲节点
+//@return    	n        	*node					新建的红黑树节点的指针
+func newNode(e interface{}, parent *node) (n *node) {
+	return &node{
+		value:  e,
+		num:    1,
+		color:  red,
+		parent: parent,
+		left:   nil,
+		right:  nil,
+	}
+}
+
+//@title    inOrder
+//@description
+//		以node红黑树节点做接收者
+//		以中缀序列返回节点集合
+//		若允许重复存储则对于重复元素进行多次放入
+//@receiver		n			*node					接受者node的指针
+//@param    	nil
+//@return    	es        	[]interface{}			以该节点为起点的中缀序列
+func (n *node) inOrder() (es []interface{}) {
+	if n == nil {
+		return es
+	}
+	if n.left != nil {
+		es = append(es, n.left.inOrder()...)
+	}
+	for i := 0; i < n.num; i++ {
+		es = append(es, n.value)
+	}
+	if n.right != nil {
+		es = append(es, n.right.inOrder()...)
+	}
+	return es
+}
+
+//@title    colorOf
+//@description
+//		返回节点n的颜色
+//		nil节点视为黑色的叶子节点
+//@receiver		nil
+//@param    	n			*node					待判断颜色的节点
+//@return    	c        	bool					节点颜色
+func colorOf(n *node) (c bool) {
+	if n == nil {
+		return black
+	}
+	return n.color
+}
+
+//@title    getMin
+//@description
+//		以node红黑树节点做接收者
+//		返回以n为根的子树中承载最小元素的节点
+//@receiver		n			*node					接受者node的指针
+//@param    	nil
+//@return    	m       	*node					最小元素节点
+func (n *node) getMin() (m *node) {
+	if n == nil {
+		return nil
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+//@title    search
+//@description
+//		以node红黑树节点做接收者
+//		从n节点开始查找承载元素e的节点
+//		若不存在则返回nil
+//@receiver		n			*node					接受者node的指针
+//@param    	e			interface{}				待查找元素
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	m        	*node					承载元素e的节点
+func (n *node) search(e interface{}, cmp comparator.Comparator) (m *node) {
+	for n != nil {
+		c := cmp(e, n.value)
+		if c < 0 {
+			n = n.left
+		} else if c > 0 {
+			n = n.right
+		} else {
+			return n
+		}
+	}
+	return nil
+}
+
+//@title    next
+//@description
+//		以node红黑树节点做接收者
+//		返回n节点在中缀序列中的后继节点
+//		存在右子树则为右子树的最左节点,否则沿双亲节点回溯直到从左子树回到某个节点
+//		若不存在后继节点则返回nil
+//@receiver		n			*node					接受者node的指针
+//@param    	nil
+//@return    	m        	*node					后继节点
+func (n *node) next() (m *node) {
+	if n.right != nil {
+		return n.right.getMin()
+	}
+	for n.parent != nil && n == n.parent.right {
+		n = n.parent
+	}
+	return n.parent
+}
+
+//@title    pre
+//@description
+//		以node红黑树节点做接收者
+//		返回n节点在中缀序列中的前驱节点
+//		存在左子树则为左子树的最右节点,否则沿双亲节点回溯直到从右子树回到某个节点
+//		若不存在前驱节点则返回nil
+//@receiver		n			*node					接受者node的指针
+//@param    	nil
+//@return    	m        	*node					前驱节点
+func (n *node) pre() (m *node) {
+	if n.left != nil {
+		n = n.left
+		for n.right != nil {
+			n = n.right
+		}
+		return n
+	}
+	for n.parent != nil && n == n.parent.left {
+		n = n.parent
+	}
+	return n.parent
+}
+
+//@title    lowerBound
+//@description
+//		以node红黑树节点做接收者
+//		从n节点开始寻找承载不小于元素e的最小元素的节点
+//		若不存在则返回nil
+//@receiver		n			*node					接受者node的指针
+//@param    	e			interface{}				待查找元素
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	m        	*node					承载下界的节点
+func (n *node) lowerBound(e interface{}, cmp comparator.Comparator) (m *node) {
+	for n != nil {
+		if cmp(n.value, e) >= 0 {
+			//n满足条件,继续从左子树寻找更小的满足条件的节点
+			m = n
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return m
+}
+
+//@title    upperBound
+//@description
+//		以node红黑树节点做接收者
+//		从n节点开始寻找承载大于元素e的最小元素的节点
+//		若不存在则返回nil
+//@receiver		n			*node					接受者node的指针
+//@param    	e			interface{}				待查找元素
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	m        	*node					承载上界的节点
+func (n *node) upperBound(e interface{}, cmp comparator.Comparator) (m *node) {
+	for n != nil {
+		if cmp(n.value, e) > 0 {
+			//n满足条件,继续从左子树寻找更小的满足条件的节点
+			m = n
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return m
+}
+
+//@title    rangeOrder
+//@description
+//		以node红黑树节点做接收者
+//		以中缀序列将处于区间内的元素放入es中
+//		lo或hi为nil时表示该侧无界,isLoIn和isHiIn表示区间是否包含对应端点
+//		仅当子树中可能存在区间内的元素时才进入该子树,时间复杂度为O(log n + k)
+//@receiver		n			*node					接受者node的指针
+//@param    	lo			interface{}				区间下界
+//@param    	hi			interface{}				区间上界
+//@param    	isLoIn		bool					是否包含下界?
+//@param    	isHiIn		bool					是否包含上界?
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@param    	es			*[]interface{}			承载结果的切片指针
+//@return    	nil
+func (n *node) rangeOrder(lo, hi interface{}, isLoIn, isHiIn bool, cmp comparator.Comparator, es *[]interface{}) {
+	if n == nil {
+		return
+	}
+	cl, ch := 1, -1
+	if lo != nil {
+		cl = cmp(n.value, lo)
+	}
+	if hi != nil {
+		ch = cmp(n.value, hi)
+	}
+	if cl > 0 {
+		//n大于下界,左子树中可能存在区间内的元素
+		n.left.rangeOrder(lo, hi, isLoIn, isHiIn, cmp, es)
+	}
+	if (cl > 0 || (cl == 0 && isLoIn)) && (ch < 0 || (ch == 0 && isHiIn)) {
+		for i := 0; i < n.num; i++ {
+			*es = append(*es, n.value)
+		}
+	}
+	if ch < 0 {
+		//n小于上界,右子树中可能存在区间内的元素
+		n.right.rangeOrder(lo, hi, isLoIn, isHiIn, cmp, es)
+	}
+}
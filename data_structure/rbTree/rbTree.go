@@ -0,0 +1,576 @@
+package rbTree
+
+//@Title		rbTree
+//@Description
+//		红黑树-Red Black Tree
+//		以二叉树的形式实现
+//		红黑树实例保存根节点和比较器以及保存的数量
+//		可以在创建时设置节点是否可重复
+//		若节点可重复则增加节点中的数值,否则对节点存储元素进行覆盖
+//		红黑树通过节点颜色约束保持近似平衡,从根到叶子的最长路径不超过最短路径的两倍
+//		相较于平衡二叉树,红黑树在插入删除时最多只需要常数次旋转,适合写入较多的场景
+//		使用互斥锁实现并发控制
+
+import (
+	"github.com/hlccd/goSTL/utils/comparator"
+	"github.com/hlccd/goSTL/utils/iterator"
+	"sync"
+)
+
+//rbTree红黑树结构体
+//该实例存储红黑树的根节点
+//同时保存该二叉树已经存储了多少个元素
+//二叉树中排序使用的比较器在创建时传入,若不传入则在插入首个节点时从默认比较器中寻找
+//创建时传入是否允许该二叉树出现重复值,如果不允许则进行覆盖,允许则对节点数目增加即可
+type RbTree struct {
+	root    *node                 //根节点指针
+	size    int                   //存储元素数量
+	cmp     comparator.Comparator //比较器
+	isMulti bool                  //是否允许重复
+	mutex   sync.Mutex            //并发控制锁
+}
+
+//rbTree红黑树容器接口
+//存放了rbTree红黑树可使用的函数
+//对应函数介绍见下方
+type rbTreer interface {
+	Iterator() (i *Iterator.Iterator)                                             //返回包含该二叉树的所有元素,重复则返回多个
+	Size() (num int)                                                              //返回该二叉树中保存的元素个数
+	Clear()                                                                       //清空该二叉树
+	Empty() (b bool)                                                              //判断该二叉树是否为空
+	Insert(e interface{}) (b bool)                                                //向二叉树中插入元素e
+	Erase(e interface{}) (b bool)                                                 //从二叉树中删除元素e
+	Count(e interface{}) (num int)                                                //从二叉树中寻找元素e并返回其个数
+	Find(e interface{}) (ans interface{})                                         //从二叉树中寻找以元素e为索引的元素
+	LowerBound(e interface{}) (c *Cursor)                                         //返回指向不小于e的最小元素的游标
+	UpperBound(e interface{}) (c *Cursor)                                         //返回指向大于e的最小元素的游标
+	RangeIterator(lo, hi interface{}, isLoIn, isHiIn bool) (i *Iterator.Iterator) //返回包含区间内所有元素的迭代器
+}
+
+//@title    New
+//@description
+//		新建一个rbTree红黑树容器并返回
+//		初始根节点为nil
+//		传入该二叉树是否为可重复属性,如果为true则保存重复值,否则对原有相等元素进行覆盖
+//		若有传入的比较器,则将传入的第一个比较器设为该二叉树的比较器
+//@receiver		nil
+//@param    	isMulti		bool						该二叉树是否保存重复值?
+//@param    	Cmp			 ...comparator.Comparator	rbTree比较器集
+//@return    	rb        	*RbTree						新建的rbTree指针
+func New(isMulti bool, cmps ...comparator.Comparator) (rb *RbTree) {
+	//判断是否有传入比较器,若有则设为该二叉树默认比较器
+	var cmp comparator.Comparator
+	if len(cmps) == 0 {
+		cmp = nil
+	} else {
+		cmp = cmps[0]
+	}
+	return &RbTree{
+		root:    nil,
+		size:    0,
+		cmp:     cmp,
+		isMulti: isMulti,
+	}
+}
+
+//@title    Iterator
+//@description
+//		以rbTree红黑树做接收者
+//		将该二叉树中所有保存的元素将从根节点开始以中缀序列的形式放入迭代器中
+//		若允许重复存储则对于重复元素进行多次放入
+//@receiver		rb			*RbTree					接受者rbTree的指针
+//@param    	nil
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (rb *RbTree) Iterator() (i *Iterator.Iterator) {
+	if rb == nil {
+		return nil
+	}
+	rb.mutex.Lock()
+	es := rb.root.inOrder()
+	i = Iterator.New(&es)
+	rb.mutex.Unlock()
+	return i
+}
+
+//@title    Size
+//@description
+//		以rbTree红黑树做接收者
+//		返回该容器当前含有元素的数量
+//		如果容器为nil返回0
+//@receiver		rb			*RbTree					接受者rbTree的指针
+//@param    	nil
+//@return    	num        	int						容器中实际使用元素所占空间大小
+func (rb *RbTree) Size() (num int) {
+	if rb == nil {
+		return 0
+	}
+	return rb.size
+}
+
+//@title    Clear
+//@description
+//		以rbTree红黑树做接收者
+//		将该容器中所承载的元素清空
+//		将该容器的size置0
+//@receiver		rb			*RbTree					接受者rbTree的指针
+//@param    	nil
+//@return    	nil
+func (rb *RbTree) Clear() {
+	if rb == nil {
+		return
+	}
+	rb.mutex.Lock()
+	rb.root = nil
+	rb.size = 0
+	rb.mutex.Unlock()
+}
+
+//@title    Empty
+//@description
+//		以rbTree红黑树做接收者
+//		判断该红黑树是否含有元素
+//		如果含有元素则不为空,返回false
+//		如果不含有元素则说明为空,返回true
+//		如果容器不存在,返回true
+//@receiver		rb			*RbTree					接受者rbTree的指针
+//@param    	nil
+//@return    	b			bool					该容器是空的吗?
+func (rb *RbTree) Empty() (b bool) {
+	if rb == nil {
+		return true
+	}
+	if rb.size > 0 {
+		return false
+	}
+	return true
+}
+
+//@title    Insert
+//@description
+//		以rbTree红黑树做接收者
+//		向二叉树插入元素e,若不允许重复则对相等元素进行覆盖
+//		如果二叉树为空则之间用根节点承载元素e,否则以根节点开始进行查找
+//		新节点以红色插入,随后自下而上通过变色和旋转修复红黑树性质
+//@receiver		rb			*RbTree					接受者rbTree的指针
+//@param    	e			interface{}				待插入元素
+//@return    	b			bool					添加成功?
+func (rb *RbTree) Insert(e interface{}) (b bool) {
+	if rb == nil {
+		return false
+	}
+	rb.mutex.Lock()
+	if rb.Empty() {
+		if rb.cmp == nil {
+			rb.cmp = comparator.GetCmp(e)
+		}
+		if rb.cmp == nil {
+			rb.mutex.Unlock()
+			return false
+		}
+		//二叉树为空,用根节点承载元素e,根节点为黑色
+		rb.root = newNode(e, nil)
+		rb.root.color = black
+		rb.size = 1
+		rb.mutex.Unlock()
+		return true
+	}
+	//从根节点开始寻找插入位置
+	var parent *node
+	n := rb.root
+	c := 0
+	for n != nil {
+		parent = n
+		c = rb.cmp(e, n.value)
+		if c < 0 {
+			n = n.left
+		} else if c > 0 {
+			n = n.right
+		} else {
+			//该节点元素与待插入元素相同
+			if rb.isMulti {
+				//允许重复,数目+1
+				n.num++
+				rb.size++
+				rb.mutex.Unlock()
+				return true
+			}
+			//不允许重复,对值进行覆盖
+			n.value = e
+			rb.mutex.Unlock()
+			return false
+		}
+	}
+	//插入新节点并修复
+	n = newNode(e, parent)
+	if c < 0 {
+		parent.left = n
+	} else {
+		parent.right = n
+	}
+	rb.insertFixup(n)
+	rb.size++
+	rb.mutex.Unlock()
+	return true
+}
+
+//@title    Erase
+//@description
+//		以rbTree红黑树做接收者
+//		从红黑树中删除元素e
+//		若允许重复记录则对承载元素e的节点中数量记录减一即可
+//		若不允许重复记录则删除该节点,若该节点有两个子节点则先与后继节点交换承载元素
+//		若被删除的节点为黑色,则自下而上通过变色和旋转修复红黑树性质
+//@receiver		rb			*RbTree					接受者rbTree的指针
+//@param    	e			interface{}				待删除元素
+//@return    	b			bool					删除成功?
+func (rb *RbTree) Erase(e interface{}) (b bool) {
+	if rb == nil {
+		return false
+	}
+	if rb.Empty() {
+		return false
+	}
+	rb.mutex.Lock()
+	n := rb.root.search(e, rb.cmp)
+	if n == nil {
+		//待删除元素不存在
+		rb.mutex.Unlock()
+		return false
+	}
+	rb.size--
+	if n.num > 1 {
+		//有重复值,节点无需删除,直接-1即可
+		n.num--
+		rb.mutex.Unlock()
+		return true
+	}
+	rb.deleteNode(n)
+	rb.mutex.Unlock()
+	return true
+}
+
+//@title    Count
+//@description
+//		以rbTree红黑树做接收者
+//		从红黑树中查找元素e的个数
+//		如果找到则返回该二叉树中和元素e相同元素的个数
+//		如果不允许重复则最多返回1
+//		如果未找到则返回0
+//@receiver		rb			*RbTree					接受者rbTree的指针
+//@param    	e			interface{}				待查找元素
+//@return    	num			int						待查找元素在二叉树中存储的个数
+func (rb *RbTree) Count(e interface{}) (num int) {
+	if rb == nil {
+		return 0
+	}
+	if rb.Empty() {
+		return 0
+	}
+	rb.mutex.Lock()
+	if n := rb.root.search(e, rb.cmp); n != nil {
+		num = n.num
+	}
+	rb.mutex.Unlock()
+	return num
+}
+
+//@title    Find
+//@description
+//		以rbTree红黑树做接收者
+//		从红黑树中查找以元素e为索引信息的全部信息
+//		如果找到则返回该二叉树中和索引元素e相同的元素的全部信息
+//		如果未找到则返回nil
+//@receiver		rb			*RbTree					接受者rbTree的指针
+//@param    	e			interface{}				待查找索引元素
+//@return    	ans			interface{}				待查找索引元素所指向的元素
+func (rb *RbTree) Find(e interface{}) (ans interface{}) {
+	if rb == nil {
+		return nil
+	}
+	if rb.Empty() {
+		return nil
+	}
+	rb.mutex.Lock()
+	if n := rb.root.search(e, rb.cmp); n != nil {
+		ans = n.value
+	}
+	rb.mutex.Unlock()
+	return ans
+}
+
+//@title    LowerBound
+//@description
+//		以rbTree红黑树做接收者
+//		返回指向红黑树中不小于元素e的最小元素的游标
+//		若存在重复元素则指向其中第一个
+//		若不存在则返回一个无效游标
+//		查找的时间复杂度为O(log n),创建游标后对红黑树进行增删将使游标失效
+//@receiver		rb			*RbTree					接受者rbTree的指针
+//@param    	e			interface{}				待查找元素
+//@return    	c			*Cursor					指向下界的游标
+func (rb *RbTree) LowerBound(e interface{}) (c *Cursor) {
+	if rb == nil {
+		return nil
+	}
+	rb.mutex.Lock()
+	c = &Cursor{tree: rb}
+	if rb.root != nil && rb.cmp != nil {
+		c.node = rb.root.lowerBound(e, rb.cmp)
+	}
+	rb.mutex.Unlock()
+	return c
+}
+
+//@title    UpperBound
+//@description
+//		以rbTree红黑树做接收者
+//		返回指向红黑树中大于元素e的最小元素的游标
+//		若存在重复元素则指向其中第一个,LowerBound(lo)到UpperBound(hi)之前的元素即为[lo,hi]区间内的元素
+//		若不存在则返回一个无效游标
+//		查找的时间复杂度为O(log n),创建游标后对红黑树进行增删将使游标失效
+//@receiver		rb			*RbTree					接受者rbTree的指针
+//@param    	e			interface{}				待查找元素
+//@return    	c			*Cursor					指向上界的游标
+func (rb *RbTree) UpperBound(e interface{}) (c *Cursor) {
+	if rb == nil {
+		return nil
+	}
+	rb.mutex.Lock()
+	c = &Cursor{tree: rb}
+	if rb.root != nil && rb.cmp != nil {
+		c.node = rb.root.upperBound(e, rb.cmp)
+	}
+	rb.mutex.Unlock()
+	return c
+}
+
+//@title    RangeIterator
+//@description
+//		以rbTree红黑树做接收者
+//		将红黑树中处于区间内的元素以中缀序列的形式放入迭代器中
+//		lo或hi为nil时表示该侧无界,isLoIn和isHiIn表示区间是否包含对应端点
+//		若允许重复存储则对于重复元素进行多次放入
+//		仅访问区间内的节点及其查找路径,时间复杂度为O(log n + k),迭代器可双向移动
+//@receiver		rb			*RbTree					接受者rbTree的指针
+//@param    	lo			interface{}				区间下界
+//@param    	hi			interface{}				区间上界
+//@param    	isLoIn		bool					是否包含下界?
+//@param    	isHiIn		bool					是否包含上界?
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (rb *RbTree) RangeIterator(lo, hi interface{}, isLoIn, isHiIn bool) (i *Iterator.Iterator) {
+	if rb == nil {
+		return nil
+	}
+	rb.mutex.Lock()
+	es := make([]interface{}, 0)
+	if rb.root != nil && rb.cmp != nil {
+		rb.root.rangeOrder(lo, hi, isLoIn, isHiIn, rb.cmp, &es)
+	}
+	i = Iterator.New(&es)
+	rb.mutex.Unlock()
+	return i
+}
+
+//@title    leftRotate
+//@description
+//		以rbTree红黑树做接收者
+//		将n节点向左旋转,使其右节点成为该子树的根节点
+//		同时将右节点的左节点设为n节点的右节点
+//@receiver		rb			*RbTree					接受者rbTree的指针
+//@param    	n			*node					待旋转的节点
+//@return    	nil
+func (rb *RbTree) leftRotate(n *node) {
+	r := n.right
+	n.right = r.left
+	if r.left != nil {
+		r.left.parent = n
+	}
+	rb.replace(n, r)
+	r.left = n
+	n.parent = r
+}
+
+//@title    rightRotate
+//@description
+//		以rbTree红黑树做接收者
+//		将n节点向右旋转,使其左节点成为该子树的根节点
+//		同时将左节点的右节点设为n节点的左节点
+//@receiver		rb			*RbTree					接受者rbTree的指针
+//@param    	n			*node					待旋转的节点
+//@return    	nil
+func (rb *RbTree) rightRotate(n *node) {
+	l := n.left
+	n.left = l.right
+	if l.right != nil {
+		l.right.parent = n
+	}
+	rb.replace(n, l)
+	l.right = n
+	n.parent = l
+}
+
+//@title    replace
+//@description
+//		以rbTree红黑树做接收者
+//		用节点m替换节点n在其双亲节点中的位置
+//		若n为根节点则将m设为根节点
+//@receiver		rb			*RbTree					接受者rbTree的指针
+//@param    	n			*node					被替换的节点
+//@param    	m			*node					替换后的节点,可以为nil
+//@return    	nil
+func (rb *RbTree) replace(n, m *node) {
+	if n.parent == nil {
+		rb.root = m
+	} else if n == n.parent.left {
+		n.parent.left = m
+	} else {
+		n.parent.right = m
+	}
+	if m != nil {
+		m.parent = n.parent
+	}
+}
+
+//@title    insertFixup
+//@description
+//		以rbTree红黑树做接收者
+//		新插入的红色节点n可能与其双亲节点构成连续红色
+//		若叔节点为红色则双亲和叔节点变黑,祖父节点变红后继续向上修复
+//		若叔节点为黑色则通过至多两次旋转完成修复
+//@receiver		rb			*RbTree					接受者rbTree的指针
+//@param    	n			*node					新插入的节点
+//@return    	nil
+func (rb *RbTree) insertFixup(n *node) {
+	for colorOf(n.parent) == red {
+		p := n.parent
+		g := p.parent
+		if p == g.left {
+			u := g.right
+			if colorOf(u) == red {
+				p.color, u.color, g.color = black, black, red
+				n = g
+				continue
+			}
+			if n == p.right {
+				//先左旋双亲节点使其变为左左情况
+				n = p
+				rb.leftRotate(n)
+				p = n.parent
+			}
+			p.color, g.color = black, red
+			rb.rightRotate(g)
+		} else {
+			u := g.left
+			if colorOf(u) == red {
+				p.color, u.color, g.color = black, black, red
+				n = g
+				continue
+			}
+			if n == p.left {
+				//先右旋双亲节点使其变为右右情况
+				n = p
+				rb.rightRotate(n)
+				p = n.parent
+			}
+			p.color, g.color = black, red
+			rb.leftRotate(g)
+		}
+	}
+	rb.root.color = black
+}
+
+//@title    deleteNode
+//@description
+//		以rbTree红黑树做接收者
+//		从红黑树中删除节点n
+//		若n有两个子节点,则将后继节点的元素移入n中,转而删除后继节点
+//		此时待删除节点至多有一个子节点,用子节点替换它即可
+//		若被删除的节点为黑色,则需要对替换上来的位置进行修复
+//@receiver		rb			*RbTree					接受者rbTree的指针
+//@param    	n			*node					待删除的节点
+//@return    	nil
+func (rb *RbTree) deleteNode(n *node) {
+	if n.left != nil && n.right != nil {
+		//找到该节点后继节点进行交换删除
+		s := n.right.getMin()
+		n.value, n.num = s.value, s.num
+		n = s
+	}
+	//此时n至多有一个子节点
+	child := n.left
+	if child == nil {
+		child = n.right
+	}
+	parent := n.parent
+	rb.replace(n, child)
+	if n.color == black {
+		rb.eraseFixup(child, parent)
+	}
+}
+
+//@title    eraseFixup
+//@description
+//		以rbTree红黑树做接收者
+//		删除黑色节点后,n所在路径上的黑色节点数少了一个
+//		由于n可能为nil,故同时传入其双亲节点
+//		根据兄弟节点及其子节点的颜色分情况进行变色和旋转
+//@receiver		rb			*RbTree					接受者rbTree的指针
+//@param    	n			*node					替换上来的节点,可以为nil
+//@param    	parent		*node					n的双亲节点
+//@return    	nil
+func (rb *RbTree) eraseFixup(n, parent *node) {
+	for n != rb.root && colorOf(n) == black {
+		if n == parent.left {
+			s := parent.right
+			if colorOf(s) == red {
+				//兄弟节点为红色,旋转后转化为兄弟节点为黑色的情况
+				s.color, parent.color = black, red
+				rb.leftRotate(parent)
+				s = parent.right
+			}
+			if colorOf(s.left) == black && colorOf(s.right) == black {
+				//兄弟节点的子节点均为黑色,兄弟节点变红后继续向上修复
+				s.color = red
+				n, parent = parent, parent.parent
+				continue
+			}
+			if colorOf(s.right) == black {
+				//兄弟节点的左子节点为红色,先右旋兄弟节点
+				s.left.color, s.color = black, red
+				rb.rightRotate(s)
+				s = parent.right
+			}
+			s.color, parent.color = parent.color, black
+			s.right.color = black
+			rb.leftRotate(parent)
+			n = rb.root
+		} else {
+			s := parent.left
+			if colorOf(s) == red {
+				//兄弟节点为红色,旋转后转化为兄弟节点为黑色的情况
+				s.color, parent.color = black, red
+				rb.rightRotate(parent)
+				s = parent.left
+			}
+			if colorOf(s.left) == black && colorOf(s.right) == black {
+				//兄弟节点的子节点均为黑色,兄弟节点变红后继续向上修复
+				s.color = red
+				n, parent = parent, parent.parent
+				continue
+			}
+			if colorOf(s.left) == black {
+				//兄弟节点的右子节点为红色,先左旋兄弟节点
+				s.right.color, s.color = black, red
+				rb.leftRotate(s)
+				s = parent.left
+			}
+			s.color, parent.color = parent.color, black
+			s.left.color = black
+			rb.rightRotate(parent)
+			n = rb.root
+		}
+	}
+	if n != nil {
+		n.color = black
+	}
+}
@@ -0,0 +1,159 @@
+package rbTree
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/hlccd/goSTL/data_structure/avlTree"
+	"github.com/hlccd/goSTL/data_structure/bsTree"
+	"github.com/hlccd/goSTL/data_structure/treap"
+)
+
+//check校验以n为根的子树满足红黑树性质,并返回该子树的黑高
+func check(t *testing.T, n *node) (blackHeight int) {
+	if n == nil {
+		return 1
+	}
+	if n.color == red && (colorOf(n.left) == red || colorOf(n.right) == red) {
+		t.Fatalf("red node %v has red child", n.value)
+	}
+	if n.left != nil && n.left.parent != n || n.right != nil && n.right.parent != n {
+		t.Fatalf("broken parent link at %v", n.value)
+	}
+	l, r := check(t, n.left), check(t, n.right)
+	if l != r {
+		t.Fatalf("black height mismatch at %v: %d != %d", n.value, l, r)
+	}
+	if n.color == black {
+		l++
+	}
+	return l
+}
+
+func TestRbTree_InsertErase(t *testing.T) {
+	for _, isMulti := range []bool{false, true} {
+		rb := New(isMulti)
+		counts := make(map[int]int)
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 20000; i++ {
+			e := r.Intn(500)
+			if r.Intn(3) == 0 {
+				ok := rb.Erase(e)
+				if ok != (counts[e] > 0) {
+					t.Fatalf("Erase(%d) = %v, count %d", e, ok, counts[e])
+				}
+				if ok {
+					counts[e]--
+				}
+			} else {
+				ok := rb.Insert(e)
+				if ok != (isMulti || counts[e] == 0) {
+					t.Fatalf("Insert(%d) = %v, count %d", e, ok, counts[e])
+				}
+				if ok {
+					counts[e]++
+				}
+			}
+			if colorOf(rb.root) == red {
+				t.Fatal("red root")
+			}
+			check(t, rb.root)
+		}
+		size := 0
+		for e, c := range counts {
+			if got := rb.Count(e); got != c {
+				t.Fatalf("Count(%d) = %d, want %d", e, got, c)
+			}
+			size += c
+		}
+		if rb.Size() != size {
+			t.Fatalf("Size() = %d, want %d", rb.Size(), size)
+		}
+		prev := -1
+		for i := rb.Iterator().Begin(); i.HasNext(); i.Next() {
+			if v := i.Value().(int); v < prev {
+				t.Fatalf("iterator out of order: %d after %d", v, prev)
+			} else {
+				prev = v
+			}
+		}
+	}
+}
+
+//以下为四种有序树在写多和读多两种负载下的对比
+//写多:随机插入后随机删除,读多:预先插入后进行随机查找
+
+const benchN = 1 << 14
+
+func benchKeys() []int {
+	r := rand.New(rand.NewSource(42))
+	keys := make([]int, benchN)
+	for i := range keys {
+		keys[i] = r.Int()
+	}
+	return keys
+}
+
+func benchWrite(b *testing.B, insert, erase func(e interface{})) {
+	keys := benchKeys()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, k := range keys {
+			insert(k)
+		}
+		for _, k := range keys {
+			erase(k)
+		}
+	}
+}
+
+func benchRead(b *testing.B, insert func(e interface{}), count func(e interface{})) {
+	keys := benchKeys()
+	for _, k := range keys {
+		insert(k)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		count(keys[i%benchN])
+	}
+}
+
+func BenchmarkWrite_RbTree(b *testing.B) {
+	rb := New(false)
+	benchWrite(b, func(e interface{}) { rb.Insert(e) }, func(e interface{}) { rb.Erase(e) })
+}
+
+func BenchmarkWrite_AvlTree(b *testing.B) {
+	avl := avlTree.New(false)
+	benchWrite(b, func(e interface{}) { avl.Insert(e) }, func(e interface{}) { avl.Erase(e) })
+}
+
+func BenchmarkWrite_Treap(b *testing.B) {
+	t := treap.New(false)
+	benchWrite(b, t.Insert, t.Erase)
+}
+
+func BenchmarkWrite_BsTree(b *testing.B) {
+	bs := bsTree.New(false)
+	benchWrite(b, bs.Insert, bs.Erase)
+}
+
+func BenchmarkRead_RbTree(b *testing.B) {
+	rb := New(false)
+	benchRead(b, func(e interface{}) { rb.Insert(e) }, func(e interface{}) { rb.Count(e) })
+}
+
+func BenchmarkRead_AvlTree(b *testing.B) {
+	avl := avlTree.New(false)
+	benchRead(b, func(e interface{}) { avl.Insert(e) }, func(e interface{}) { avl.Count(e) })
+}
+
+func BenchmarkRead_Treap(b *testing.B) {
+	t := treap.New(false)
+	benchRead(b, t.Insert, func(e interface{}) { t.Count(e) })
+}
+
+func BenchmarkRead_BsTree(b *testing.B) {
+	bs := bsTree.New(false)
+	benchRead(b, bs.Insert, func(e interface{}) { bs.Count(e) })
+}
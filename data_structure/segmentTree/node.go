@@ -0,0 +1,79 @@
+package segmentTree
+
+//@Title		segmentTree
+//@Description
+//		线段树的节点
+//		节点中保存其所管辖区间的聚合值以及尚未下传的懒标记
+//		懒标记分为区间赋值和区间增加两种,赋值标记会覆盖此前的所有标记
+//		在赋值标记之后到来的增加标记会直接合并进赋值标记中
+
+//node树节点结构体
+//该节点是线段树的树节点
+//sum为该节点所管辖区间的聚合值
+//若isAssign为真则表示该区间整体被赋值为assign且尚未下传
+//若isAdd为真则表示该区间整体增加了add且尚未下传
+type node struct {
+	sum      interface{} //区间聚合值
+	assign   interface{} //待下传的赋值标记
+	isAssign bool        //是否存在赋值标记
+	add      interface{} //待下传的增加标记
+	isAdd    bool        //是否存在增加标记
+}
+
+//@title    pow
+//@description
+//		求length个相同元素e通过幺半群运算得到的聚合值
+//		利用结合律进行倍增,时间复杂度为O(log length)
+//@receiver		nil
+//@param    	e			interface{}				待聚合的元素
+//@param    	length		int						元素个数
+//@param    	m			*Monoid					聚合所用的幺半群
+//@return    	ans			interface{}				聚合值
+func pow(e interface{}, length int, m *Monoid) (ans interface{}) {
+	ans = m.Identity
+	for length > 0 {
+		if length&1 == 1 {
+			ans = m.Op(ans, e)
+		}
+		e = m.Op(e, e)
+		length >>= 1
+	}
+	return ans
+}
+
+//@title    applyAssign
+//@description
+//		以node树节点做接收者
+//		将长度为length的区间整体赋值为e
+//		赋值后原有的赋值和增加标记均失效
+//@receiver		n			*node					接受者node的指针
+//@param    	e			interface{}				赋值的元素
+//@param    	length		int						该节点所管辖区间的长度
+//@param    	m			*Monoid					聚合所用的幺半群
+//@return    	nil
+func (n *node) applyAssign(e interface{}, length int, m *Monoid) {
+	n.sum = pow(e, length, m)
+	n.assign, n.isAssign = e, true
+	n.add, n.isAdd = nil, false
+}
+
+//@title    applyAdd
+//@description
+//		以node树节点做接收者
+//		将长度为length的区间中每个元素都增加delta
+//		若该节点存在赋值标记则直接修改赋值标记,否则与原有的增加标记合并
+//@receiver		n			*node					接受者node的指针
+//@param    	delta		interface{}				增加的值
+//@param    	length		int						该节点所管辖区间的长度
+//@param    	m			*Monoid					聚合所用的幺半群
+//@return    	nil
+func (n *node) applyAdd(delta interface{}, length int, m *Monoid) {
+	n.sum = m.Add(n.sum, delta, length)
+	if n.isAssign {
+		n.assign = m.Add(n.assign, delta, 1)
+	} else if n.isAdd {
+		n.add = m.Add(n.add, delta, 1)
+	} else {
+		n.add, n.isAdd = delta, true
+	}
+}
@@ -0,0 +1,405 @@
+package segmentTree
+
+//@Title		segmentTree
+//@Description
+//		线段树-Segment Tree
+//		以数组形式存储的完全二叉树,每个节点管辖序列中的一段连续区间并保存该区间的聚合值
+//		聚合方式由创建时传入的幺半群决定,可以是区间和、最小值、最大值等任意满足结合律的运算
+//		可在O(n)时间内从切片或vector中建树
+//		支持单点修改、区间查询,并通过懒标记支持区间赋值和区间增加,时间复杂度均为O(log n)
+//		区间均为左闭右开区间[l,r)
+//		使用互斥锁实现并发控制
+
+import (
+	"github.com/hlccd/goSTL/data_structure/vector"
+	"github.com/hlccd/goSTL/utils/iterator"
+	"sync"
+)
+
+//Monoid幺半群结构体
+//包含一个满足结合律的二元运算和该运算的单位元
+//Add为可选的区间增加函数,返回长度为length的区间中每个元素都增加delta后的聚合值
+//如区间和为agg+delta*length,区间最小值为agg+delta
+//Add(e,delta,1)即为单个元素增加delta,同时用于合并多次尚未下传的增加
+//若Add为nil则该线段树不支持区间增加
+type Monoid struct {
+	Op       func(a, b interface{}) interface{}                   //满足结合律的二元运算
+	Identity interface{}                                          //该运算的单位元
+	Add      func(agg, delta interface{}, length int) interface{} //区间增加后的聚合值
+}
+
+//segmentTree线段树结构体
+//该实例以数组形式存储线段树的所有节点,根节点下标为1
+//同时保存该线段树所表示序列的长度
+//聚合所用的幺半群在创建时传入
+type SegmentTree struct {
+	tree   []node     //节点数组
+	size   int        //序列长度
+	monoid *Monoid    //聚合所用的幺半群
+	mutex  sync.Mutex //并发控制锁
+}
+
+//segmentTree线段树容器接口
+//存放了segmentTree线段树可使用的函数
+//对应函数介绍见下方
+type segmentTreer interface {
+	Iterator() (i *Iterator.Iterator)         //返回包含该序列所有元素的迭代器
+	Size() (num int)                          //返回该序列的长度
+	Empty() (b bool)                          //判断该序列是否为空
+	At(idx int) (e interface{})               //返回下标idx处的元素
+	Update(idx int, e interface{}) (b bool)   //将下标idx处的元素修改为e
+	Query(l, r int) (ans interface{})         //返回[l,r)区间内元素的聚合值
+	Assign(l, r int, e interface{}) (b bool)  //将[l,r)区间内的元素全部赋值为e
+	Add(l, r int, delta interface{}) (b bool) //将[l,r)区间内的元素全部增加delta
+}
+
+//@title    New
+//@description
+//		新建一个segmentTree线段树容器并返回
+//		以切片es中的元素作为初始序列,在O(n)时间内建树
+//		传入的幺半群运算不可为nil,否则返回nil
+//@receiver		nil
+//@param    	es			[]interface{}			初始序列
+//@param    	m			Monoid					聚合所用的幺半群
+//@return    	st        	*SegmentTree			新建的segmentTree指针
+func New(es []interface{}, m Monoid) (st *SegmentTree) {
+	if m.Op == nil {
+		return nil
+	}
+	st = &SegmentTree{
+		tree:   make([]node, 4*len(es)+1),
+		size:   len(es),
+		monoid: &Monoid{Op: m.Op, Identity: m.Identity, Add: m.Add},
+		mutex:  sync.Mutex{},
+	}
+	if st.size > 0 {
+		st.build(1, 0, st.size, es)
+	}
+	return st
+}
+
+//@title    NewFromVector
+//@description
+//		新建一个segmentTree线段树容器并返回
+//		以vector中的元素作为初始序列,在O(n)时间内建树
+//		传入的幺半群运算不可为nil,否则返回nil
+//@receiver		nil
+//@param    	v			*vector.Vector			初始序列
+//@param    	m			Monoid					聚合所用的幺半群
+//@return    	st        	*SegmentTree			新建的segmentTree指针
+func NewFromVector(v *vector.Vector, m Monoid) (st *SegmentTree) {
+	es := make([]interface{}, 0, v.Size())
+	if !v.Empty() {
+		for i := v.Iterator().Begin(); i.HasNext(); i.Next() {
+			es = append(es, i.Value())
+		}
+	}
+	return New(es, m)
+}
+
+//@title    build
+//@description
+//		以segmentTree线段树做接收者
+//		以es中[l,r)区间内的元素建立以p为根的子树
+//@receiver		st			*SegmentTree			接受者segmentTree的指针
+//@param    	p			int						子树根节点下标
+//@param    	l			int						区间左端点,包含
+//@param    	r			int						区间右端点,不包含
+//@param    	es			[]interface{}			初始序列
+//@return    	nil
+func (st *SegmentTree) build(p, l, r int, es []interface{}) {
+	if r-l == 1 {
+		st.tree[p].sum = es[l]
+		return
+	}
+	mid := (l + r) / 2
+	st.build(2*p, l, mid, es)
+	st.build(2*p+1, mid, r, es)
+	st.tree[p].sum = st.monoid.Op(st.tree[2*p].sum, st.tree[2*p+1].sum)
+}
+
+//@title    push
+//@description
+//		以segmentTree线段树做接收者
+//		将节点p上尚未下传的懒标记下传给左右子节点
+//		先下传赋值标记再下传增加标记
+//@receiver		st			*SegmentTree			接受者segmentTree的指针
+//@param    	p			int						节点下标
+//@param    	l			int						区间左端点,包含
+//@param    	mid			int						区间中点
+//@param    	r			int						区间右端点,不包含
+//@return    	nil
+func (st *SegmentTree) push(p, l, mid, r int) {
+	n := &st.tree[p]
+	if n.isAssign {
+		st.tree[2*p].applyAssign(n.assign, mid-l, st.monoid)
+		st.tree[2*p+1].applyAssign(n.assign, r-mid, st.monoid)
+		n.assign, n.isAssign = nil, false
+	}
+	if n.isAdd {
+		st.tree[2*p].applyAdd(n.add, mid-l, st.monoid)
+		st.tree[2*p+1].applyAdd(n.add, r-mid, st.monoid)
+		n.add, n.isAdd = nil, false
+	}
+}
+
+//@title    modify
+//@description
+//		以segmentTree线段树做接收者
+//		对以p为根、管辖[l,r)的子树中与[ql,qr)相交的部分执行修改
+//		完全覆盖的节点直接通过apply打上懒标记
+//@receiver		st			*SegmentTree			接受者segmentTree的指针
+//@param    	p			int						子树根节点下标
+//@param    	l			int						子树区间左端点,包含
+//@param    	r			int						子树区间右端点,不包含
+//@param    	ql			int						修改区间左端点,包含
+//@param    	qr			int						修改区间右端点,不包含
+//@param    	apply		func(n *node, length int)	对完全覆盖节点的修改函数
+//@return    	nil
+func (st *SegmentTree) modify(p, l, r, ql, qr int, apply func(n *node, length int)) {
+	if ql <= l && r <= qr {
+		apply(&st.tree[p], r-l)
+		return
+	}
+	mid := (l + r) / 2
+	st.push(p, l, mid, r)
+	if ql < mid {
+		st.modify(2*p, l, mid, ql, qr, apply)
+	}
+	if qr > mid {
+		st.modify(2*p+1, mid, r, ql, qr, apply)
+	}
+	st.tree[p].sum = st.monoid.Op(st.tree[2*p].sum, st.tree[2*p+1].sum)
+}
+
+//@title    query
+//@description
+//		以segmentTree线段树做接收者
+//		返回以p为根、管辖[l,r)的子树中与[ql,qr)相交部分的聚合值
+//@receiver		st			*SegmentTree			接受者segmentTree的指针
+//@param    	p			int						子树根节点下标
+//@param    	l			int						子树区间左端点,包含
+//@param    	r			int						子树区间右端点,不包含
+//@param    	ql			int						查询区间左端点,包含
+//@param    	qr			int						查询区间右端点,不包含
+//@return    	ans			interface{}				聚合值
+func (st *SegmentTree) query(p, l, r, ql, qr int) (ans interface{}) {
+	if ql <= l && r <= qr {
+		return st.tree[p].sum
+	}
+	mid := (l + r) / 2
+	st.push(p, l, mid, r)
+	if qr <= mid {
+		return st.query(2*p, l, mid, ql, qr)
+	}
+	if ql >= mid {
+		return st.query(2*p+1, mid, r, ql, qr)
+	}
+	return st.monoid.Op(st.query(2*p, l, mid, ql, qr), st.query(2*p+1, mid, r, ql, qr))
+}
+
+//@title    collect
+//@description
+//		以segmentTree线段树做接收者
+//		将以p为根、管辖[l,r)的子树中的所有元素按顺序放入es中
+//@receiver		st			*SegmentTree			接受者segmentTree的指针
+//@param    	p			int						子树根节点下标
+//@param    	l			int						子树区间左端点,包含
+//@param    	r			int						子树区间右端点,不包含
+//@param    	es			*[]interface{}			存放元素的切片指针
+//@return    	nil
+func (st *SegmentTree) collect(p, l, r int, es *[]interface{}) {
+	if r-l == 1 {
+		*es = append(*es, st.tree[p].sum)
+		return
+	}
+	mid := (l + r) / 2
+	st.push(p, l, mid, r)
+	st.collect(2*p, l, mid, es)
+	st.collect(2*p+1, mid, r, es)
+}
+
+//@title    Iterator
+//@description
+//		以segmentTree线段树做接收者
+//		将该线段树所表示的序列按顺序放入迭代器中
+//		放入前会将所有懒标记下传
+//@receiver		st			*SegmentTree			接受者segmentTree的指针
+//@param    	nil
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (st *SegmentTree) Iterator() (i *Iterator.Iterator) {
+	if st == nil {
+		return nil
+	}
+	st.mutex.Lock()
+	es := make([]interface{}, 0, st.size)
+	if st.size > 0 {
+		st.collect(1, 0, st.size, &es)
+	}
+	st.mutex.Unlock()
+	return Iterator.New(&es)
+}
+
+//@title    Size
+//@description
+//		以segmentTree线段树做接收者
+//		返回该线段树所表示序列的长度
+//		如果容器为nil返回0
+//@receiver		st			*SegmentTree			接受者segmentTree的指针
+//@param    	nil
+//@return    	num        	int						序列长度
+func (st *SegmentTree) Size() (num int) {
+	if st == nil {
+		return 0
+	}
+	return st.size
+}
+
+//@title    Empty
+//@description
+//		以segmentTree线段树做接收者
+//		判断该线段树所表示的序列是否为空
+//		如果容器不存在,返回true
+//@receiver		st			*SegmentTree			接受者segmentTree的指针
+//@param    	nil
+//@return    	b			bool					该容器是空的吗?
+func (st *SegmentTree) Empty() (b bool) {
+	if st == nil {
+		return true
+	}
+	return st.size == 0
+}
+
+//@title    At
+//@description
+//		以segmentTree线段树做接收者
+//		返回序列中下标idx处的元素
+//		若下标越界则返回nil
+//@receiver		st			*SegmentTree			接受者segmentTree的指针
+//@param    	idx			int						下标
+//@return    	e			interface{}				该下标处的元素
+func (st *SegmentTree) At(idx int) (e interface{}) {
+	if st == nil {
+		return nil
+	}
+	st.mutex.Lock()
+	if idx < 0 || idx >= st.size {
+		st.mutex.Unlock()
+		return nil
+	}
+	e = st.query(1, 0, st.size, idx, idx+1)
+	st.mutex.Unlock()
+	return e
+}
+
+//@title    Update
+//@description
+//		以segmentTree线段树做接收者
+//		将序列中下标idx处的元素修改为e
+//		若下标越界则修改失败
+//@receiver		st			*SegmentTree			接受者segmentTree的指针
+//@param    	idx			int						下标
+//@param    	e			interface{}				修改后的元素
+//@return    	b			bool					修改成功?
+func (st *SegmentTree) Update(idx int, e interface{}) (b bool) {
+	if st == nil {
+		return false
+	}
+	st.mutex.Lock()
+	if idx < 0 || idx >= st.size {
+		st.mutex.Unlock()
+		return false
+	}
+	st.modify(1, 0, st.size, idx, idx+1, func(n *node, length int) {
+		n.sum = e
+	})
+	st.mutex.Unlock()
+	return true
+}
+
+//@title    Query
+//@description
+//		以segmentTree线段树做接收者
+//		返回序列中[l,r)区间内元素按顺序通过幺半群运算得到的聚合值
+//		空区间返回幺半群的单位元
+//		若区间不合法则返回nil
+//@receiver		st			*SegmentTree			接受者segmentTree的指针
+//@param    	l			int						区间左端点,包含
+//@param    	r			int						区间右端点,不包含
+//@return    	ans			interface{}				区间聚合值
+func (st *SegmentTree) Query(l, r int) (ans interface{}) {
+	if st == nil {
+		return nil
+	}
+	st.mutex.Lock()
+	if l < 0 || r > st.size || l > r {
+		st.mutex.Unlock()
+		return nil
+	}
+	if l == r {
+		ans = st.monoid.Identity
+		st.mutex.Unlock()
+		return ans
+	}
+	ans = st.query(1, 0, st.size, l, r)
+	st.mutex.Unlock()
+	return ans
+}
+
+//@title    Assign
+//@description
+//		以segmentTree线段树做接收者
+//		将序列中[l,r)区间内的元素全部赋值为e
+//		通过懒标记实现,时间复杂度为O(log n)
+//		若区间不合法则赋值失败
+//@receiver		st			*SegmentTree			接受者segmentTree的指针
+//@param    	l			int						区间左端点,包含
+//@param    	r			int						区间右端点,不包含
+//@param    	e			interface{}				赋值的元素
+//@return    	b			bool					赋值成功?
+func (st *SegmentTree) Assign(l, r int, e interface{}) (b bool) {
+	if st == nil {
+		return false
+	}
+	st.mutex.Lock()
+	if l < 0 || r > st.size || l > r {
+		st.mutex.Unlock()
+		return false
+	}
+	if l < r {
+		st.modify(1, 0, st.size, l, r, func(n *node, length int) {
+			n.applyAssign(e, length, st.monoid)
+		})
+	}
+	st.mutex.Unlock()
+	return true
+}
+
+//@title    Add
+//@description
+//		以segmentTree线段树做接收者
+//		将序列中[l,r)区间内的元素全部增加delta
+//		通过懒标记实现,时间复杂度为O(log n)
+//		若创建时幺半群未提供Add函数或区间不合法则增加失败
+//@receiver		st			*SegmentTree			接受者segmentTree的指针
+//@param    	l			int						区间左端点,包含
+//@param    	r			int						区间右端点,不包含
+//@param    	delta		interface{}				增加的值
+//@return    	b			bool					增加成功?
+func (st *SegmentTree) Add(l, r int, delta interface{}) (b bool) {
+	if st == nil {
+		return false
+	}
+	st.mutex.Lock()
+	if st.monoid.Add == nil || l < 0 || r > st.size || l > r {
+		st.mutex.Unlock()
+		return false
+	}
+	if l < r {
+		st.modify(1, 0, st.size, l, r, func(n *node, length int) {
+			n.applyAdd(delta, length, st.monoid)
+		})
+	}
+	st.mutex.Unlock()
+	return true
+}
@@ -0,0 +1,287 @@
+package skipList
+
+//@Title		skipList
+//@Description
+//		并发跳表的节点
+//		节点的元素、数量和各层后继节点均通过原子操作读写,使得读操作无需加锁
+//		每个节点持有一把互斥锁,写操作仅锁住被修改的节点及其各层前驱节点
+//		marked表示节点已被逻辑删除,fullyLinked表示节点已在所有层中完成连接
+import (
+	"github.com/hlccd/goSTL/utils/comparator"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+//concurrentNode并发跳表节点结构体
+//该节点是并发跳表的节点
+//value指向承载的元素,next[i]指向该节点在第i层的后继节点
+//头节点的value为nil,其num记录跳表中元素的数量
+type concurrentNode struct {
+	value       unsafe.Pointer   //指向节点中存储的元素
+	num         int64            //该元素数量
+	next        []unsafe.Pointer //各层的后继节点
+	marked      int32            //是否已被逻辑删除
+	fullyLinked int32            //是否已在所有层中完成连接
+	mutex       sync.Mutex       //节点锁
+}
+
+//@title    newConcurrentNode
+//@description
+//		新建一个层数为level的并发跳表节点并返回
+//		将传入的元素e作为该节点的承载元素
+//		该节点的num默认为1,各层后继节点均为nil
+//@receiver		nil
+//@param    	e			interface{}				承载元素e
+//@param    	level		int						节点层数
+//@return    	n        	*concurrentNode			新建的节点的指针
+func newConcurrentNode(e interface{}, level int) (n *concurrentNode) {
+	return &concurrentNode{
+		value: unsafe.Pointer(&e),
+		num:   1,
+		next:  make([]unsafe.Pointer, level),
+	}
+}
+
+//@title    getValue
+//@description
+//		以concurrentNode并发跳表节点做接收者
+//		原子地读取该节点承载的元素
+//@receiver		n			*concurrentNode			接受者concurrentNode的指针
+//@param    	nil
+//@return    	e        	interface{}				节点承载的元素
+func (n *concurrentNode) getValue() (e interface{}) {
+	return *(*interface{})(atomic.LoadPointer(&n.value))
+}
+
+//@title    setValue
+//@description
+//		以concurrentNode并发跳表节点做接收者
+//		原子地将该节点承载的元素替换为e
+//@receiver		n			*concurrentNode			接受者concurrentNode的指针
+//@param    	e			interface{}				新的承载元素
+//@return    	nil
+func (n *concurrentNode) setValue(e interface{}) {
+	atomic.StorePointer(&n.value, unsafe.Pointer(&e))
+}
+
+//@title    getNum
+//@description
+//		以concurrentNode并发跳表节点做接收者
+//		原子地读取该节点承载元素的数量
+//@receiver		n			*concurrentNode			接受者concurrentNode的指针
+//@param    	nil
+//@return    	num        	int						元素的数量
+func (n *concurrentNode) getNum() (num int) {
+	return int(atomic.LoadInt64(&n.num))
+}
+
+//@title    getNext
+//@description
+//		以concurrentNode并发跳表节点做接收者
+//		原子地读取该节点在第i层的后继节点
+//@receiver		n			*concurrentNode			接受者concurrentNode的指针
+//@param    	i			int						层数
+//@return    	m        	*concurrentNode			第i层的后继节点
+func (n *concurrentNode) getNext(i int) (m *concurrentNode) {
+	return (*concurrentNode)(atomic.LoadPointer(&n.next[i]))
+}
+
+//@title    setNext
+//@description
+//		以concurrentNode并发跳表节点做接收者
+//		原子地将该节点在第i层的后继节点设为m
+//@receiver		n			*concurrentNode			接受者concurrentNode的指针
+//@param    	i			int						层数
+//@param    	m			*concurrentNode			新的后继节点
+//@return    	nil
+func (n *concurrentNode) setNext(i int, m *concurrentNode) {
+	atomic.StorePointer(&n.next[i], unsafe.Pointer(m))
+}
+
+//@title    isMarked
+//@description
+//		以concurrentNode并发跳表节点做接收者
+//		判断该节点是否已被逻辑删除
+//@receiver		n			*concurrentNode			接受者concurrentNode的指针
+//@param    	nil
+//@return    	b        	bool					已被逻辑删除?
+func (n *concurrentNode) isMarked() (b bool) {
+	return atomic.LoadInt32(&n.marked) == 1
+}
+
+//@title    isFullyLinked
+//@description
+//		以concurrentNode并发跳表节点做接收者
+//		判断该节点是否已在所有层中完成连接
+//@receiver		n			*concurrentNode			接受者concurrentNode的指针
+//@param    	nil
+//@return    	b        	bool					已完成连接?
+func (n *concurrentNode) isFullyLinked() (b bool) {
+	return atomic.LoadInt32(&n.fullyLinked) == 1
+}
+
+//@title    isValid
+//@description
+//		以concurrentNode并发跳表节点做接收者
+//		判断该节点是否对读操作可见
+//		仅已完成连接且未被逻辑删除的节点视为存在于跳表中
+//@receiver		n			*concurrentNode			接受者concurrentNode的指针
+//@param    	nil
+//@return    	b        	bool					对读操作可见?
+func (n *concurrentNode) isValid() (b bool) {
+	return n.isFullyLinked() && !n.isMarked()
+}
+
+//@title    find
+//@description
+//		以concurrentNode并发跳表头节点做接收者
+//		从最高层开始逐层查找元素e,记录每一层中最后一个小于e的节点和其后继节点
+//		返回找到与e相等的节点的最高层数,未找到时返回-1
+//		查找过程不加锁,记录的结果需在加锁后重新校验
+//@receiver		h			*concurrentNode			接受者并发跳表头节点的指针
+//@param    	e			interface{}				待查找元素
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@param    	preds		[]*concurrentNode		记录各层前驱节点的切片
+//@param    	succs		[]*concurrentNode		记录各层后继节点的切片
+//@return    	lFound     	int						找到与e相等的节点的最高层数
+func (h *concurrentNode) find(e interface{}, cmp comparator.Comparator, preds, succs []*concurrentNode) (lFound int) {
+	lFound = -1
+	pred := h
+	for i := len(h.next) - 1; i >= 0; i-- {
+		curr := pred.getNext(i)
+		for curr != nil && cmp(curr.getValue(), e) < 0 {
+			pred = curr
+			curr = pred.getNext(i)
+		}
+		if lFound == -1 && curr != nil && cmp(curr.getValue(), e) == 0 {
+			lFound = i
+		}
+		preds[i] = pred
+		succs[i] = curr
+	}
+	return lFound
+}
+
+//@title    search
+//@description
+//		以concurrentNode并发跳表头节点做接收者
+//		从最高层开始逐层查找与元素e相等的节点,找到即返回
+//		若不存在则返回nil,查找过程不加锁
+//@receiver		h			*concurrentNode			接受者并发跳表头节点的指针
+//@param    	e			interface{}				待查找元素
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	m        	*concurrentNode			与e相等的节点
+func (h *concurrentNode) search(e interface{}, cmp comparator.Comparator) (m *concurrentNode) {
+	pred := h
+	for i := len(h.next) - 1; i >= 0; i-- {
+		curr := pred.getNext(i)
+		for curr != nil && cmp(curr.getValue(), e) < 0 {
+			pred = curr
+			curr = pred.getNext(i)
+		}
+		if curr != nil && cmp(curr.getValue(), e) == 0 {
+			return curr
+		}
+	}
+	return nil
+}
+
+//@title    ceiling
+//@description
+//		以concurrentNode并发跳表头节点做接收者
+//		返回第0层中第一个不小于元素e且对读操作可见的节点
+//		若e为nil则从第0层的首个节点开始,不存在时返回nil
+//@receiver		h			*concurrentNode			接受者并发跳表头节点的指针
+//@param    	e			interface{}				待查找元素
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	m        	*concurrentNode			第一个不小于e的节点
+func (h *concurrentNode) ceiling(e interface{}, cmp comparator.Comparator) (m *concurrentNode) {
+	pred := h
+	if e != nil {
+		for i := len(h.next) - 1; i >= 0; i-- {
+			for curr := pred.getNext(i); curr != nil && cmp(curr.getValue(), e) < 0; curr = pred.getNext(i) {
+				pred = curr
+			}
+		}
+	}
+	m = pred.getNext(0)
+	for m != nil && !m.isValid() {
+		m = m.getNext(0)
+	}
+	return m
+}
+
+//@title    floor
+//@description
+//		以concurrentNode并发跳表头节点做接收者
+//		从最高层开始逐层查找,返回不大于元素e的最后一个节点
+//		若该节点正在被插入或删除则返回nil,由调用者重试
+//		ok为false表示需要重试,ok为true且m为nil表示不存在
+//@receiver		h			*concurrentNode			接受者并发跳表头节点的指针
+//@param    	e			interface{}				待查找元素
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	m        	*concurrentNode			不大于e的最后一个节点
+//@return    	ok        	bool					查找结果是否有效?
+func (h *concurrentNode) floor(e interface{}, cmp comparator.Comparator) (m *concurrentNode, ok bool) {
+	pred := h
+	for i := len(h.next) - 1; i >= 0; i-- {
+		for curr := pred.getNext(i); curr != nil && cmp(curr.getValue(), e) <= 0; curr = pred.getNext(i) {
+			pred = curr
+		}
+	}
+	if pred == h {
+		return nil, true
+	}
+	if !pred.isValid() {
+		return nil, false
+	}
+	return pred, true
+}
+
+//@title    lockPreds
+//@description
+//		自底向上依次锁住前level层的前驱节点,同一节点只加锁一次
+//		每锁住一层便校验该层前驱节点未被删除且其后继节点仍为succs中记录的节点
+//		插入时还需校验后继节点未被删除
+//		返回已处理的层数以便解锁,以及校验是否通过
+//@receiver		nil
+//@param    	preds		[]*concurrentNode		各层前驱节点
+//@param    	succs		[]*concurrentNode		各层后继节点
+//@param    	level		int						需加锁的层数
+//@param    	isInsert	bool					是否为插入操作?
+//@return    	locked     	int						已处理的层数
+//@return    	valid     	bool					校验是否通过?
+func lockPreds(preds, succs []*concurrentNode, level int, isInsert bool) (locked int, valid bool) {
+	var prev *concurrentNode
+	valid = true
+	for locked = 0; valid && locked < level; locked++ {
+		pred, succ := preds[locked], succs[locked]
+		if pred != prev {
+			pred.mutex.Lock()
+			prev = pred
+		}
+		valid = !pred.isMarked() && pred.getNext(locked) == succ
+		if isInsert {
+			valid = valid && (succ == nil || !succ.isMarked())
+		}
+	}
+	return locked, valid
+}
+
+//@title    unlockPreds
+//@description
+//		解锁前locked层中被lockPreds锁住的前驱节点
+//@receiver		nil
+//@param    	preds		[]*concurrentNode		各层前驱节点
+//@param    	locked		int						已处理的层数
+//@return    	nil
+func unlockPreds(preds []*concurrentNode, locked int) {
+	var prev *concurrentNode
+	for i := 0; i < locked; i++ {
+		if preds[i] != prev {
+			preds[i].mutex.Unlock()
+			prev = preds[i]
+		}
+	}
+}
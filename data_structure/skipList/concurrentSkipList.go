@@ -0,0 +1,465 @@
+package skipList
+
+//@Title		skipList
+//@Description
+//		并发跳表-Concurrent Skip List
+//		以惰性同步的多层有序链表实现
+//		读操作(查找、计数、上下界和遍历)不加锁,仅通过原子操作读取节点
+//		写操作先不加锁地定位,再只锁住待修改节点的各层前驱节点并校验,校验失败则重试
+//		删除时先将节点标记为逻辑删除,再从各层中摘除,读操作会跳过已标记的节点
+//		不同位置的写操作可以并行进行,适用于读多写少的场景
+//		遍历得到的是弱一致的结果,即遍历期间完成的修改可能被看到也可能不被看到
+
+import (
+	"github.com/hlccd/goSTL/utils/comparator"
+	"github.com/hlccd/goSTL/utils/iterator"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+//concurrentSkipList并发跳表结构体
+//该实例存储并发跳表的头节点和层数生成器,头节点的num记录元素数量
+//跳表中排序使用的比较器在创建时传入,若不传入则在插入首个节点时从默认比较器中寻找
+//创建时传入是否允许该跳表出现重复值,如果不允许则进行覆盖,允许则对节点数目增加即可
+type ConcurrentSkipList struct {
+	head       unsafe.Pointer //头节点指针
+	cmp        atomic.Value   //比较器
+	isMulti    bool           //是否允许重复
+	leveler    *leveler       //层数生成器
+	levelMutex sync.Mutex     //层数生成器的并发控制锁
+	cmpMutex   sync.Mutex     //设置比较器的并发控制锁
+}
+
+//@title    NewConcurrent
+//@description
+//		新建一个concurrentSkipList并发跳表容器并返回
+//		使用默认的层数生成配置,以当前时间作为随机数种子
+//		传入该跳表是否为可重复属性,如果为true则保存重复值,否则对原有相等元素进行覆盖
+//		若有传入的比较器,则将传入的第一个比较器设为该跳表的比较器
+//@receiver		nil
+//@param    	isMulti		bool						该跳表是否保存重复值?
+//@param    	Cmp			 ...comparator.Comparator	concurrentSkipList比较器集
+//@return    	sl        	*ConcurrentSkipList			新建的concurrentSkipList指针
+func NewConcurrent(isMulti bool, cmps ...comparator.Comparator) (sl *ConcurrentSkipList) {
+	return NewConcurrentWithConfig(isMulti, Config{}, cmps...)
+}
+
+//@title    NewConcurrentWithConfig
+//@description
+//		新建一个concurrentSkipList并发跳表容器并返回
+//		按传入的配置生成节点层数,配置中不合法的项使用默认值
+//		传入该跳表是否为可重复属性,如果为true则保存重复值,否则对原有相等元素进行覆盖
+//		若有传入的比较器,则将传入的第一个比较器设为该跳表的比较器
+//@receiver		nil
+//@param    	isMulti		bool						该跳表是否保存重复值?
+//@param    	cfg			Config						层数生成配置
+//@param    	Cmp			 ...comparator.Comparator	concurrentSkipList比较器集
+//@return    	sl        	*ConcurrentSkipList			新建的concurrentSkipList指针
+func NewConcurrentWithConfig(isMulti bool, cfg Config, cmps ...comparator.Comparator) (sl *ConcurrentSkipList) {
+	l := newLeveler(cfg)
+	sl = &ConcurrentSkipList{
+		head:    unsafe.Pointer(newConcurrentNode(nil, l.maxLevel)),
+		isMulti: isMulti,
+		leveler: l,
+	}
+	atomic.StoreInt64(&sl.getHead().num, 0)
+	//判断是否有传入比较器,若有则设为该跳表默认比较器
+	if len(cmps) > 0 && cmps[0] != nil {
+		sl.cmp.Store(cmps[0])
+	}
+	return sl
+}
+
+//@title    getHead
+//@description
+//		以concurrentSkipList并发跳表做接收者
+//		原子地读取该跳表当前的头节点
+//@receiver		sl			*ConcurrentSkipList		接受者concurrentSkipList的指针
+//@param    	nil
+//@return    	h        	*concurrentNode			当前的头节点
+func (sl *ConcurrentSkipList) getHead() (h *concurrentNode) {
+	return (*concurrentNode)(atomic.LoadPointer(&sl.head))
+}
+
+//@title    getCmp
+//@description
+//		以concurrentSkipList并发跳表做接收者
+//		返回该跳表的比较器
+//		若尚未设置比较器且e不为nil,则从默认比较器中为e寻找并设置,只会成功设置一次
+//@receiver		sl			*ConcurrentSkipList		接受者concurrentSkipList的指针
+//@param    	e			interface{}				用于寻找默认比较器的元素
+//@return    	cmp        	comparator.Comparator	该跳表的比较器
+func (sl *ConcurrentSkipList) getCmp(e interface{}) (cmp comparator.Comparator) {
+	if cmp, ok := sl.cmp.Load().(comparator.Comparator); ok {
+		return cmp
+	}
+	if e == nil {
+		return nil
+	}
+	sl.cmpMutex.Lock()
+	if cmp, ok := sl.cmp.Load().(comparator.Comparator); ok {
+		sl.cmpMutex.Unlock()
+		return cmp
+	}
+	if cmp = comparator.GetCmp(e); cmp != nil {
+		sl.cmp.Store(cmp)
+	}
+	sl.cmpMutex.Unlock()
+	return cmp
+}
+
+//@title    level
+//@description
+//		以concurrentSkipList并发跳表做接收者
+//		加锁后从层数生成器中获取一个新节点的层数
+//@receiver		sl			*ConcurrentSkipList		接受者concurrentSkipList的指针
+//@param    	nil
+//@return    	lv        	int						新节点的层数
+func (sl *ConcurrentSkipList) level() (lv int) {
+	sl.levelMutex.Lock()
+	lv = sl.leveler.level()
+	sl.levelMutex.Unlock()
+	return lv
+}
+
+//@title    Iterator
+//@description
+//		以concurrentSkipList并发跳表做接收者
+//		将该跳表中所有可见的元素按第0层的顺序放入迭代器中
+//		遍历过程不加锁,结果为弱一致的
+//		若允许重复存储则对于重复元素进行多次放入
+//@receiver		sl			*ConcurrentSkipList		接受者concurrentSkipList的指针
+//@param    	nil
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (sl *ConcurrentSkipList) Iterator() (i *Iterator.Iterator) {
+	if sl == nil {
+		return nil
+	}
+	return sl.RangeIterator(nil, nil, true, true)
+}
+
+//@title    Size
+//@description
+//		以concurrentSkipList并发跳表做接收者
+//		返回该容器当前含有元素的数量
+//		如果容器为nil返回0
+//@receiver		sl			*ConcurrentSkipList		接受者concurrentSkipList的指针
+//@param    	nil
+//@return    	num        	int						容器中实际使用元素所占空间大小
+func (sl *ConcurrentSkipList) Size() (num int) {
+	if sl == nil {
+		return 0
+	}
+	return sl.getHead().getNum()
+}
+
+//@title    Clear
+//@description
+//		以concurrentSkipList并发跳表做接收者
+//		将该容器中所承载的元素清空
+//		以原子地替换头节点的方式实现,与之并发进行的写操作可能作用于被替换的旧跳表
+//@receiver		sl			*ConcurrentSkipList		接受者concurrentSkipList的指针
+//@param    	nil
+//@return    	nil
+func (sl *ConcurrentSkipList) Clear() {
+	if sl == nil {
+		return
+	}
+	h := newConcurrentNode(nil, sl.leveler.maxLevel)
+	h.num = 0
+	atomic.StorePointer(&sl.head, unsafe.Pointer(h))
+}
+
+//@title    Empty
+//@description
+//		以concurrentSkipList并发跳表做接收者
+//		判断该跳表是否含有元素
+//		如果含有元素则不为空,返回false
+//		如果不含有元素则说明为空,返回true
+//		如果容器不存在,返回true
+//@receiver		sl			*ConcurrentSkipList		接受者concurrentSkipList的指针
+//@param    	nil
+//@return    	b			bool					该容器是空的吗?
+func (sl *ConcurrentSkipList) Empty() (b bool) {
+	if sl == nil {
+		return true
+	}
+	return sl.Size() == 0
+}
+
+//@title    Insert
+//@description
+//		以concurrentSkipList并发跳表做接收者
+//		向跳表插入元素e,若不允许重复则对相等元素进行覆盖
+//		若元素已存在则锁住该节点后修改其数量或元素
+//		否则锁住各层前驱节点并校验,校验通过后自底向上连接新节点,最后标记其完成连接
+//		定位期间若遇到正在删除的相等节点或校验失败则重新定位
+//@receiver		sl			*ConcurrentSkipList		接受者concurrentSkipList的指针
+//@param    	e			interface{}				待插入元素
+//@return    	b			bool					添加成功?
+func (sl *ConcurrentSkipList) Insert(e interface{}) (b bool) {
+	if sl == nil {
+		return false
+	}
+	cmp := sl.getCmp(e)
+	if cmp == nil {
+		return false
+	}
+	h := sl.getHead()
+	level := sl.level()
+	preds := make([]*concurrentNode, len(h.next))
+	succs := make([]*concurrentNode, len(h.next))
+	for {
+		if lFound := h.find(e, cmp, preds, succs); lFound != -1 {
+			f := succs[lFound]
+			if f.isMarked() {
+				//相等节点正在被删除,等待其摘除后重试
+				runtime.Gosched()
+				continue
+			}
+			for !f.isFullyLinked() {
+				runtime.Gosched()
+			}
+			f.mutex.Lock()
+			if f.isMarked() {
+				f.mutex.Unlock()
+				continue
+			}
+			if !sl.isMulti {
+				//不允许重复,对值进行覆盖
+				f.setValue(e)
+				f.mutex.Unlock()
+				return false
+			}
+			//允许重复,数目+1
+			atomic.AddInt64(&f.num, 1)
+			f.mutex.Unlock()
+			atomic.AddInt64(&h.num, 1)
+			return true
+		}
+		locked, valid := lockPreds(preds, succs, level, true)
+		if !valid {
+			unlockPreds(preds, locked)
+			continue
+		}
+		n := newConcurrentNode(e, level)
+		for i := 0; i < level; i++ {
+			n.setNext(i, succs[i])
+		}
+		for i := 0; i < level; i++ {
+			preds[i].setNext(i, n)
+		}
+		atomic.StoreInt32(&n.fullyLinked, 1)
+		unlockPreds(preds, locked)
+		atomic.AddInt64(&h.num, 1)
+		return true
+	}
+}
+
+//@title    Erase
+//@description
+//		以concurrentSkipList并发跳表做接收者
+//		从跳表中删除元素e
+//		若允许重复记录则锁住承载元素e的节点后将数量减一即可
+//		否则先将该节点标记为逻辑删除,再锁住各层前驱节点并校验,校验通过后自顶向下摘除该节点
+//		校验失败时重新定位前驱节点,已标记的节点不会被其他操作再次删除
+//@receiver		sl			*ConcurrentSkipList		接受者concurrentSkipList的指针
+//@param    	e			interface{}				待删除元素
+//@return    	b			bool					删除成功?
+func (sl *ConcurrentSkipList) Erase(e interface{}) (b bool) {
+	if sl == nil {
+		return false
+	}
+	cmp := sl.getCmp(nil)
+	if cmp == nil {
+		return false
+	}
+	h := sl.getHead()
+	preds := make([]*concurrentNode, len(h.next))
+	succs := make([]*concurrentNode, len(h.next))
+	var victim *concurrentNode
+	for {
+		lFound := h.find(e, cmp, preds, succs)
+		if victim == nil {
+			if lFound == -1 {
+				return false
+			}
+			victim = succs[lFound]
+			if !victim.isValid() || lFound != len(victim.next)-1 {
+				//节点尚未完成插入或已被删除
+				return false
+			}
+			victim.mutex.Lock()
+			if victim.isMarked() {
+				victim.mutex.Unlock()
+				return false
+			}
+			if victim.getNum() > 1 {
+				//有重复值,节点无需删除,直接-1即可
+				atomic.AddInt64(&victim.num, -1)
+				victim.mutex.Unlock()
+				atomic.AddInt64(&h.num, -1)
+				return true
+			}
+			atomic.StoreInt32(&victim.marked, 1)
+		}
+		level := len(victim.next)
+		for i := 0; i < level; i++ {
+			succs[i] = victim
+		}
+		locked, valid := lockPreds(preds, succs, level, false)
+		if !valid {
+			unlockPreds(preds, locked)
+			continue
+		}
+		for i := level - 1; i >= 0; i-- {
+			preds[i].setNext(i, victim.getNext(i))
+		}
+		victim.mutex.Unlock()
+		unlockPreds(preds, locked)
+		atomic.AddInt64(&h.num, -1)
+		return true
+	}
+}
+
+//@title    Count
+//@description
+//		以concurrentSkipList并发跳表做接收者
+//		从跳表中查找元素e的个数,查找过程不加锁
+//		如果找到则返回该跳表中和元素e相同元素的个数
+//		如果不允许重复则最多返回1
+//		如果未找到则返回0
+//@receiver		sl			*ConcurrentSkipList		接受者concurrentSkipList的指针
+//@param    	e			interface{}				待查找元素
+//@return    	num			int						待查找元素在跳表中存储的个数
+func (sl *ConcurrentSkipList) Count(e interface{}) (num int) {
+	if sl == nil {
+		return 0
+	}
+	cmp := sl.getCmp(nil)
+	if cmp == nil {
+		return 0
+	}
+	if n := sl.getHead().search(e, cmp); n != nil && n.isValid() {
+		return n.getNum()
+	}
+	return 0
+}
+
+//@title    Find
+//@description
+//		以concurrentSkipList并发跳表做接收者
+//		从跳表中查找与元素e相等的元素并返回,查找过程不加锁
+//		当比较器仅比较键时,可用于获取键对应的完整元素
+//		如果未找到则返回nil
+//@receiver		sl			*ConcurrentSkipList		接受者concurrentSkipList的指针
+//@param    	e			interface{}				待查找元素
+//@return    	ans			interface{}				跳表中与e相等的元素
+func (sl *ConcurrentSkipList) Find(e interface{}) (ans interface{}) {
+	if sl == nil {
+		return nil
+	}
+	cmp := sl.getCmp(nil)
+	if cmp == nil {
+		return nil
+	}
+	if n := sl.getHead().search(e, cmp); n != nil && n.isValid() {
+		return n.getValue()
+	}
+	return nil
+}
+
+//@title    Floor
+//@description
+//		以concurrentSkipList并发跳表做接收者
+//		返回跳表中不大于元素e的最大元素,查找过程不加锁
+//		若找到的节点正在被插入或删除则重新查找
+//		如果不存在则返回nil
+//@receiver		sl			*ConcurrentSkipList		接受者concurrentSkipList的指针
+//@param    	e			interface{}				待查找元素
+//@return    	ans			interface{}				不大于e的最大元素
+func (sl *ConcurrentSkipList) Floor(e interface{}) (ans interface{}) {
+	if sl == nil {
+		return nil
+	}
+	cmp := sl.getCmp(nil)
+	if cmp == nil {
+		return nil
+	}
+	h := sl.getHead()
+	for {
+		if n, ok := h.floor(e, cmp); ok {
+			if n == nil {
+				return nil
+			}
+			return n.getValue()
+		}
+		runtime.Gosched()
+	}
+}
+
+//@title    Ceiling
+//@description
+//		以concurrentSkipList并发跳表做接收者
+//		返回跳表中不小于元素e的最小元素,查找过程不加锁
+//		如果不存在则返回nil
+//@receiver		sl			*ConcurrentSkipList		接受者concurrentSkipList的指针
+//@param    	e			interface{}				待查找元素
+//@return    	ans			interface{}				不小于e的最小元素
+func (sl *ConcurrentSkipList) Ceiling(e interface{}) (ans interface{}) {
+	if sl == nil {
+		return nil
+	}
+	cmp := sl.getCmp(nil)
+	if cmp == nil {
+		return nil
+	}
+	if n := sl.getHead().ceiling(e, cmp); n != nil {
+		return n.getValue()
+	}
+	return nil
+}
+
+//@title    RangeIterator
+//@description
+//		以concurrentSkipList并发跳表做接收者
+//		将跳表中处于lo和hi之间的可见元素按从小到大的顺序放入迭代器中
+//		isLoIn和isHiIn分别表示区间是否包含lo和hi,lo或hi为nil时表示该侧无界
+//		遍历过程不加锁,结果为弱一致的
+//		若允许重复存储则对于重复元素进行多次放入
+//@receiver		sl			*ConcurrentSkipList		接受者concurrentSkipList的指针
+//@param    	lo			interface{}				区间下界
+//@param    	hi			interface{}				区间上界
+//@param    	isLoIn		bool					区间是否包含lo?
+//@param    	isHiIn		bool					区间是否包含hi?
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (sl *ConcurrentSkipList) RangeIterator(lo, hi interface{}, isLoIn, isHiIn bool) (i *Iterator.Iterator) {
+	if sl == nil {
+		return nil
+	}
+	es := make([]interface{}, 0, 0)
+	cmp := sl.getCmp(nil)
+	if cmp == nil {
+		return Iterator.New(&es)
+	}
+	for p := sl.getHead().ceiling(lo, cmp); p != nil; p = p.getNext(0) {
+		if !p.isValid() {
+			continue
+		}
+		v := p.getValue()
+		if lo != nil && !isLoIn && cmp(v, lo) == 0 {
+			continue
+		}
+		if hi != nil {
+			if c := cmp(v, hi); c > 0 || (c == 0 && !isHiIn) {
+				break
+			}
+		}
+		for j := p.getNum(); j > 0; j-- {
+			es = append(es, v)
+		}
+	}
+	return Iterator.New(&es)
+}
@@ -0,0 +1,75 @@
+package skipList
+
+//@Title		skipList
+//@Description
+//		跳表的层数生成器
+//		每个新节点的层数从1开始,以概率p逐层晋升,直至达到最高层数
+//		最高层数、晋升概率和随机数种子均可配置,相同的种子会生成相同的层数序列
+import (
+	"math/rand"
+	"time"
+)
+
+//Config跳表层数生成配置结构体
+//MaxLevel为节点的最高层数,不大于0时使用默认值
+//P为节点晋升到上一层的概率,不在(0,1)内时使用默认值
+//Seed为生成层数所用的随机数种子,为0时以当前时间作为种子
+type Config struct {
+	MaxLevel int     //最高层数
+	P        float64 //晋升概率
+	Seed     int64   //随机数种子
+}
+
+const (
+	defaultMaxLevel = 32   //默认最高层数
+	defaultP        = 0.25 //默认晋升概率
+)
+
+//leveler层数生成器结构体
+//该实例存储最高层数和晋升概率以及随机数生成器
+type leveler struct {
+	maxLevel int        //最高层数
+	p        float64    //晋升概率
+	rand     *rand.Rand //随机数生成器
+}
+
+//@title    newLeveler
+//@description
+//		根据配置新建一个层数生成器并返回
+//		配置中不合法的项使用默认值替代
+//@receiver		nil
+//@param    	cfg			Config					层数生成配置
+//@return    	l        	*leveler				新建的层数生成器指针
+func newLeveler(cfg Config) (l *leveler) {
+	if cfg.MaxLevel <= 0 {
+		cfg.MaxLevel = defaultMaxLevel
+	}
+	if cfg.P <= 0 || cfg.P >= 1 {
+		cfg.P = defaultP
+	}
+	if cfg.Seed == 0 {
+		cfg.Seed = time.Now().UnixNano()
+	}
+	return &leveler{
+		maxLevel: cfg.MaxLevel,
+		p:        cfg.P,
+		rand:     rand.New(rand.NewSource(cfg.Seed)),
+	}
+}
+
+//@title    level
+//@description
+//		以leveler层数生成器做接收者
+//		随机生成一个新节点的层数
+//		层数从1开始,每次以概率p晋升一层,最多不超过最高层数
+//		该函数不是并发安全的,需由调用者进行并发控制
+//@receiver		l			*leveler				接受者leveler的指针
+//@param    	nil
+//@return    	lv        	int						新节点的层数
+func (l *leveler) level() (lv int) {
+	lv = 1
+	for lv < l.maxLevel && l.rand.Float64() < l.p {
+		lv++
+	}
+	return lv
+}
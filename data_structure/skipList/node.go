@@ -0,0 +1,82 @@
+package skipList
+
+//@Title		skipList
+//@Description
+//		跳表的节点
+//		节点的层数在创建时确定,每一层保存该层的后继节点
+//		若跳表允许重复则对节点num+1即可,否则对value进行覆盖
+import (
+	"github.com/hlccd/goSTL/utils/comparator"
+)
+
+//node跳表节点结构体
+//该节点是跳表的节点
+//next[i]为该节点在第i层的后继节点,头节点的value为nil
+type node struct {
+	value interface{} //节点中存储的元素
+	num   int         //该元素数量
+	next  []*node     //各层的后继节点
+}
+
+//@title    newNode
+//@description
+//		新建一个层数为level的跳表节点并返回
+//		将传入的元素e作为该节点的承载元素
+//		该节点的num默认为1,各层后继节点均为nil
+//@receiver		nil
+//@param    	e			interface{}				承载元素e
+//@param    	level		int						节点层数
+//@return    	n        	*node					新建的节点的指针
+func newNode(e interface{}, level int) (n *node) {
+	return &node{
+		value: e,
+		num:   1,
+		next:  make([]*node, level),
+	}
+}
+
+//@title    search
+//@description
+//		以node跳表头节点做接收者
+//		从最高层开始逐层查找元素e,并记录每一层中最后一个小于e的节点
+//		若update为nil则不记录
+//		返回第0层中第一个不小于e的节点,不存在时返回nil
+//@receiver		n			*node					接受者跳表头节点的指针
+//@param    	e			interface{}				待查找元素
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@param    	update		[]*node					记录各层前驱节点的切片
+//@return    	m        	*node					第一个不小于e的节点
+func (n *node) search(e interface{}, cmp comparator.Comparator, update []*node) (m *node) {
+	p := n
+	for i := len(n.next) - 1; i >= 0; i-- {
+		for p.next[i] != nil && cmp(p.next[i].value, e) < 0 {
+			p = p.next[i]
+		}
+		if update != nil {
+			update[i] = p
+		}
+	}
+	return p.next[0]
+}
+
+//@title    floor
+//@description
+//		以node跳表头节点做接收者
+//		从最高层开始逐层查找,返回不大于元素e的最后一个节点
+//		若不存在则返回nil
+//@receiver		n			*node					接受者跳表头节点的指针
+//@param    	e			interface{}				待查找元素
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	m        	*node					不大于e的最后一个节点
+func (n *node) floor(e interface{}, cmp comparator.Comparator) (m *node) {
+	p := n
+	for i := len(n.next) - 1; i >= 0; i-- {
+		for p.next[i] != nil && cmp(p.next[i].value, e) <= 0 {
+			p = p.next[i]
+		}
+	}
+	if p == n {
+		return nil
+	}
+	return p
+}
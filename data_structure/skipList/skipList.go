@@ -0,0 +1,398 @@
+package skipList
+
+//@Title		skipList
+//@Description
+//		跳表-Skip List
+//		以多层有序链表的形式实现
+//		跳表实例保存头节点和比较器以及保存的数量
+//		可以在创建时设置节点是否可重复
+//		若节点可重复则增加节点中的数值,否则对节点存储元素进行覆盖
+//		节点的层数随机生成,期望的查找、插入和删除时间均为O(logn)
+//		层数生成的最高层数、晋升概率和随机数种子可在创建时配置
+//		使用互斥锁实现并发控制,读多写少的场景可使用ConcurrentSkipList
+
+import (
+	"github.com/hlccd/goSTL/utils/comparator"
+	"github.com/hlccd/goSTL/utils/iterator"
+	"sync"
+)
+
+//skipList跳表结构体
+//该实例存储跳表的头节点和层数生成器
+//同时保存该跳表已经存储了多少个元素
+//跳表中排序使用的比较器在创建时传入,若不传入则在插入首个节点时从默认比较器中寻找
+//创建时传入是否允许该跳表出现重复值,如果不允许则进行覆盖,允许则对节点数目增加即可
+type SkipList struct {
+	head    *node                 //头节点指针
+	size    int                   //存储元素数量
+	cmp     comparator.Comparator //比较器
+	isMulti bool                  //是否允许重复
+	leveler *leveler              //层数生成器
+	mutex   sync.Mutex            //并发控制锁
+}
+
+//skipList跳表容器接口
+//存放了skipList跳表可使用的函数
+//对应函数介绍见下方
+type skipLister interface {
+	Iterator() (i *Iterator.Iterator)                                             //返回包含该跳表的所有元素,重复则返回多个
+	Size() (num int)                                                              //返回该跳表中保存的元素个数
+	Clear()                                                                       //清空该跳表
+	Empty() (b bool)                                                              //判断该跳表是否为空
+	Insert(e interface{}) (b bool)                                                //向跳表中插入元素e
+	Erase(e interface{}) (b bool)                                                 //从跳表中删除元素e
+	Count(e interface{}) (num int)                                                //从跳表中寻找元素e并返回其个数
+	Find(e interface{}) (ans interface{})                                         //从跳表中寻找与元素e相等的元素并返回
+	Floor(e interface{}) (ans interface{})                                        //返回跳表中不大于e的最大元素
+	Ceiling(e interface{}) (ans interface{})                                      //返回跳表中不小于e的最小元素
+	RangeIterator(lo, hi interface{}, isLoIn, isHiIn bool) (i *Iterator.Iterator) //返回包含区间内所有元素的迭代器
+}
+
+//@title    New
+//@description
+//		新建一个skipList跳表容器并返回
+//		使用默认的层数生成配置,以当前时间作为随机数种子
+//		传入该跳表是否为可重复属性,如果为true则保存重复值,否则对原有相等元素进行覆盖
+//		若有传入的比较器,则将传入的第一个比较器设为该跳表的比较器
+//@receiver		nil
+//@param    	isMulti		bool						该跳表是否保存重复值?
+//@param    	Cmp			 ...comparator.Comparator	skipList比较器集
+//@return    	sl        	*SkipList					新建的skipList指针
+func New(isMulti bool, cmps ...comparator.Comparator) (sl *SkipList) {
+	return NewWithConfig(isMulti, Config{}, cmps...)
+}
+
+//@title    NewWithConfig
+//@description
+//		新建一个skipList跳表容器并返回
+//		按传入的配置生成节点层数,配置中不合法的项使用默认值
+//		传入该跳表是否为可重复属性,如果为true则保存重复值,否则对原有相等元素进行覆盖
+//		若有传入的比较器,则将传入的第一个比较器设为该跳表的比较器
+//@receiver		nil
+//@param    	isMulti		bool						该跳表是否保存重复值?
+//@param    	cfg			Config						层数生成配置
+//@param    	Cmp			 ...comparator.Comparator	skipList比较器集
+//@return    	sl        	*SkipList					新建的skipList指针
+func NewWithConfig(isMulti bool, cfg Config, cmps ...comparator.Comparator) (sl *SkipList) {
+	//判断是否有传入比较器,若有则设为该跳表默认比较器
+	var cmp comparator.Comparator
+	if len(cmps) == 0 {
+		cmp = nil
+	} else {
+		cmp = cmps[0]
+	}
+	l := newLeveler(cfg)
+	return &SkipList{
+		head:    newNode(nil, l.maxLevel),
+		size:    0,
+		cmp:     cmp,
+		isMulti: isMulti,
+		leveler: l,
+	}
+}
+
+//@title    Iterator
+//@description
+//		以skipList跳表做接收者
+//		将该跳表中所有保存的元素按第0层的顺序放入迭代器中
+//		若允许重复存储则对于重复元素进行多次放入
+//@receiver		sl			*SkipList				接受者skipList的指针
+//@param    	nil
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (sl *SkipList) Iterator() (i *Iterator.Iterator) {
+	if sl == nil {
+		return nil
+	}
+	sl.mutex.Lock()
+	es := make([]interface{}, 0, sl.size)
+	for p := sl.head.next[0]; p != nil; p = p.next[0] {
+		for j := 0; j < p.num; j++ {
+			es = append(es, p.value)
+		}
+	}
+	sl.mutex.Unlock()
+	return Iterator.New(&es)
+}
+
+//@title    Size
+//@description
+//		以skipList跳表做接收者
+//		返回该容器当前含有元素的数量
+//		如果容器为nil返回0
+//@receiver		sl			*SkipList				接受者skipList的指针
+//@param    	nil
+//@return    	num        	int						容器中实际使用元素所占空间大小
+func (sl *SkipList) Size() (num int) {
+	if sl == nil {
+		return 0
+	}
+	return sl.size
+}
+
+//@title    Clear
+//@description
+//		以skipList跳表做接收者
+//		将该容器中所承载的元素清空
+//		将该容器的size置0
+//@receiver		sl			*SkipList				接受者skipList的指针
+//@param    	nil
+//@return    	nil
+func (sl *SkipList) Clear() {
+	if sl == nil {
+		return
+	}
+	sl.mutex.Lock()
+	sl.head = newNode(nil, sl.leveler.maxLevel)
+	sl.size = 0
+	sl.mutex.Unlock()
+}
+
+//@title    Empty
+//@description
+//		以skipList跳表做接收者
+//		判断该跳表是否含有元素
+//		如果含有元素则不为空,返回false
+//		如果不含有元素则说明为空,返回true
+//		如果容器不存在,返回true
+//@receiver		sl			*SkipList				接受者skipList的指针
+//@param    	nil
+//@return    	b			bool					该容器是空的吗?
+func (sl *SkipList) Empty() (b bool) {
+	if sl == nil {
+		return true
+	}
+	return sl.size == 0
+}
+
+//@title    Insert
+//@description
+//		以skipList跳表做接收者
+//		向跳表插入元素e,若不允许重复则对相等元素进行覆盖
+//		从最高层开始查找插入位置,并记录每一层的前驱节点
+//		若元素不存在则随机生成新节点的层数,并在各层中将其连接到前驱节点之后
+//@receiver		sl			*SkipList				接受者skipList的指针
+//@param    	e			interface{}				待插入元素
+//@return    	b			bool					添加成功?
+func (sl *SkipList) Insert(e interface{}) (b bool) {
+	if sl == nil {
+		return false
+	}
+	sl.mutex.Lock()
+	if sl.cmp == nil {
+		sl.cmp = comparator.GetCmp(e)
+	}
+	if sl.cmp == nil {
+		sl.mutex.Unlock()
+		return false
+	}
+	update := make([]*node, sl.leveler.maxLevel)
+	if p := sl.head.search(e, sl.cmp, update); p != nil && sl.cmp(p.value, e) == 0 {
+		if sl.isMulti {
+			//允许重复,数目+1
+			p.num++
+			sl.size++
+			sl.mutex.Unlock()
+			return true
+		}
+		//不允许重复,对值进行覆盖
+		p.value = e
+		sl.mutex.Unlock()
+		return false
+	}
+	n := newNode(e, sl.leveler.level())
+	for i := range n.next {
+		n.next[i] = update[i].next[i]
+		update[i].next[i] = n
+	}
+	sl.size++
+	sl.mutex.Unlock()
+	return true
+}
+
+//@title    Erase
+//@description
+//		以skipList跳表做接收者
+//		从跳表中删除元素e
+//		若允许重复记录则对承载元素e的节点中数量记录减一即可
+//		否则在该节点所在的每一层中将其前驱节点指向其后继节点
+//@receiver		sl			*SkipList				接受者skipList的指针
+//@param    	e			interface{}				待删除元素
+//@return    	b			bool					删除成功?
+func (sl *SkipList) Erase(e interface{}) (b bool) {
+	if sl == nil {
+		return false
+	}
+	sl.mutex.Lock()
+	if sl.size == 0 {
+		sl.mutex.Unlock()
+		return false
+	}
+	update := make([]*node, sl.leveler.maxLevel)
+	p := sl.head.search(e, sl.cmp, update)
+	if p == nil || sl.cmp(p.value, e) != 0 {
+		sl.mutex.Unlock()
+		return false
+	}
+	if p.num > 1 {
+		//有重复值,节点无需删除,直接-1即可
+		p.num--
+	} else {
+		for i := range p.next {
+			update[i].next[i] = p.next[i]
+		}
+	}
+	sl.size--
+	sl.mutex.Unlock()
+	return true
+}
+
+//@title    Count
+//@description
+//		以skipList跳表做接收者
+//		从跳表中查找元素e的个数
+//		如果找到则返回该跳表中和元素e相同元素的个数
+//		如果不允许重复则最多返回1
+//		如果未找到则返回0
+//@receiver		sl			*SkipList				接受者skipList的指针
+//@param    	e			interface{}				待查找元素
+//@return    	num			int						待查找元素在跳表中存储的个数
+func (sl *SkipList) Count(e interface{}) (num int) {
+	if sl == nil {
+		return 0
+	}
+	sl.mutex.Lock()
+	if sl.size == 0 {
+		sl.mutex.Unlock()
+		return 0
+	}
+	if p := sl.head.search(e, sl.cmp, nil); p != nil && sl.cmp(p.value, e) == 0 {
+		num = p.num
+		sl.mutex.Unlock()
+		return num
+	}
+	sl.mutex.Unlock()
+	return 0
+}
+
+//@title    Find
+//@description
+//		以skipList跳表做接收者
+//		从跳表中查找与元素e相等的元素并返回
+//		当比较器仅比较键时,可用于获取键对应的完整元素
+//		如果未找到则返回nil
+//@receiver		sl			*SkipList				接受者skipList的指针
+//@param    	e			interface{}				待查找元素
+//@return    	ans			interface{}				跳表中与e相等的元素
+func (sl *SkipList) Find(e interface{}) (ans interface{}) {
+	if sl == nil {
+		return nil
+	}
+	sl.mutex.Lock()
+	if sl.size == 0 {
+		sl.mutex.Unlock()
+		return nil
+	}
+	if p := sl.head.search(e, sl.cmp, nil); p != nil && sl.cmp(p.value, e) == 0 {
+		ans = p.value
+		sl.mutex.Unlock()
+		return ans
+	}
+	sl.mutex.Unlock()
+	return nil
+}
+
+//@title    Floor
+//@description
+//		以skipList跳表做接收者
+//		返回跳表中不大于元素e的最大元素
+//		如果不存在则返回nil
+//@receiver		sl			*SkipList				接受者skipList的指针
+//@param    	e			interface{}				待查找元素
+//@return    	ans			interface{}				不大于e的最大元素
+func (sl *SkipList) Floor(e interface{}) (ans interface{}) {
+	if sl == nil {
+		return nil
+	}
+	sl.mutex.Lock()
+	if sl.size == 0 {
+		sl.mutex.Unlock()
+		return nil
+	}
+	if p := sl.head.floor(e, sl.cmp); p != nil {
+		ans = p.value
+		sl.mutex.Unlock()
+		return ans
+	}
+	sl.mutex.Unlock()
+	return nil
+}
+
+//@title    Ceiling
+//@description
+//		以skipList跳表做接收者
+//		返回跳表中不小于元素e的最小元素
+//		如果不存在则返回nil
+//@receiver		sl			*SkipList				接受者skipList的指针
+//@param    	e			interface{}				待查找元素
+//@return    	ans			interface{}				不小于e的最小元素
+func (sl *SkipList) Ceiling(e interface{}) (ans interface{}) {
+	if sl == nil {
+		return nil
+	}
+	sl.mutex.Lock()
+	if sl.size == 0 {
+		sl.mutex.Unlock()
+		return nil
+	}
+	if p := sl.head.search(e, sl.cmp, nil); p != nil {
+		ans = p.value
+		sl.mutex.Unlock()
+		return ans
+	}
+	sl.mutex.Unlock()
+	return nil
+}
+
+//@title    RangeIterator
+//@description
+//		以skipList跳表做接收者
+//		将跳表中处于lo和hi之间的元素按从小到大的顺序放入迭代器中
+//		isLoIn和isHiIn分别表示区间是否包含lo和hi,lo或hi为nil时表示该侧无界
+//		从lo处开始沿第0层遍历,遇到超出hi的元素即停止
+//		若允许重复存储则对于重复元素进行多次放入
+//@receiver		sl			*SkipList				接受者skipList的指针
+//@param    	lo			interface{}				区间下界
+//@param    	hi			interface{}				区间上界
+//@param    	isLoIn		bool					区间是否包含lo?
+//@param    	isHiIn		bool					区间是否包含hi?
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (sl *SkipList) RangeIterator(lo, hi interface{}, isLoIn, isHiIn bool) (i *Iterator.Iterator) {
+	if sl == nil {
+		return nil
+	}
+	sl.mutex.Lock()
+	es := make([]interface{}, 0, 0)
+	if sl.size > 0 {
+		p := sl.head.next[0]
+		if lo != nil {
+			p = sl.head.search(lo, sl.cmp, nil)
+			if !isLoIn {
+				for p != nil && sl.cmp(p.value, lo) == 0 {
+					p = p.next[0]
+				}
+			}
+		}
+		for ; p != nil; p = p.next[0] {
+			if hi != nil {
+				if c := sl.cmp(p.value, hi); c > 0 || (c == 0 && !isHiIn) {
+					break
+				}
+			}
+			for j := 0; j < p.num; j++ {
+				es = append(es, p.value)
+			}
+		}
+	}
+	sl.mutex.Unlock()
+	return Iterator.New(&es)
+}
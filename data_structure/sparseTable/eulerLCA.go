@@ -0,0 +1,154 @@
+package sparseTable
+
+//@Title		sparseTable
+//@Description
+//		基于欧拉序和稀疏表的最近公共祖先-LCA via Euler tour + RMQ
+//		对每棵树做深度优先遍历,每进入一个节点以及每从子节点返回时都记录当前节点,得到长度为2n-1的欧拉序
+//		两个节点的最近公共祖先即为它们在欧拉序中首次出现位置之间深度最小的节点
+//		借助稀疏表,预处理的时间复杂度为O(n log n),每次查询为O(1)
+//		建立后不可修改,因此不需要进行并发控制
+
+//eulerLCA结构体
+//该实例存储所有树的欧拉序以及欧拉序上的稀疏表
+//同时保存每个节点的深度、首次出现位置和所在树的根节点
+type EulerLCA struct {
+	first []int        //每个节点在欧拉序中首次出现的位置
+	depth []int        //每个节点的深度
+	root  []int        //每个节点所在树的根节点
+	st    *SparseTable //欧拉序上取深度最小节点的稀疏表
+}
+
+//eulerLCA容器接口
+//存放了eulerLCA可使用的函数
+//对应函数介绍见下方
+type eulerLCAer interface {
+	Size() (num int)              //返回节点数量
+	Depth(v int) (d int)          //返回节点v的深度
+	LCA(u, v int) (w int)         //返回节点u和v的最近公共祖先
+	Distance(u, v int) (dist int) //返回节点u和v之间的边数
+}
+
+//@title    NewEulerLCA
+//@description
+//		以父节点数组新建一个eulerLCA并返回
+//		parents[v]为节点v的父节点,根节点的父节点为-1,允许存在多个根节点
+//		若父节点数组越界或存在环则返回nil
+//@receiver		nil
+//@param    	parents		[]int					父节点数组
+//@return    	el        	*EulerLCA				新建的eulerLCA指针
+func NewEulerLCA(parents []int) (el *EulerLCA) {
+	children, order, depth, root, ok := prepare(parents)
+	if !ok {
+		return nil
+	}
+	n := len(parents)
+	el = &EulerLCA{
+		first: make([]int, n),
+		depth: depth,
+		root:  root,
+	}
+	tour := make([]interface{}, 0, 2*n)
+	//以栈模拟深度优先遍历,next[v]为v下一个待访问的子节点下标
+	next := make([]int, n)
+	stack := make([]int, 0)
+	for _, r := range order {
+		if parents[r] != -1 {
+			break
+		}
+		stack = append(stack, r)
+		el.first[r] = len(tour)
+		tour = append(tour, r)
+		for len(stack) > 0 {
+			u := stack[len(stack)-1]
+			if next[u] < len(children[u]) {
+				v := children[u][next[u]]
+				next[u]++
+				stack = append(stack, v)
+				el.first[v] = len(tour)
+				tour = append(tour, v)
+			} else {
+				stack = stack[:len(stack)-1]
+				if len(stack) > 0 {
+					tour = append(tour, stack[len(stack)-1])
+				}
+			}
+		}
+	}
+	el.st = New(tour, func(a, b interface{}) interface{} {
+		if depth[b.(int)] < depth[a.(int)] {
+			return b
+		}
+		return a
+	})
+	return el
+}
+
+//@title    Size
+//@description
+//		以eulerLCA做接收者
+//		返回节点数量
+//		如果容器为nil返回0
+//@receiver		el			*EulerLCA				接受者eulerLCA的指针
+//@param    	nil
+//@return    	num        	int						节点数量
+func (el *EulerLCA) Size() (num int) {
+	if el == nil {
+		return 0
+	}
+	return len(el.depth)
+}
+
+//@title    Depth
+//@description
+//		以eulerLCA做接收者
+//		返回节点v的深度,根节点深度为0
+//		若节点不存在则返回-1
+//@receiver		el			*EulerLCA				接受者eulerLCA的指针
+//@param    	v			int						节点
+//@return    	d			int						深度
+func (el *EulerLCA) Depth(v int) (d int) {
+	if el == nil || v < 0 || v >= len(el.depth) {
+		return -1
+	}
+	return el.depth[v]
+}
+
+//@title    LCA
+//@description
+//		以eulerLCA做接收者
+//		返回节点u和v的最近公共祖先
+//		若节点不存在或两节点不在同一棵树中则返回-1
+//@receiver		el			*EulerLCA				接受者eulerLCA的指针
+//@param    	u			int						节点
+//@param    	v			int						节点
+//@return    	w			int						最近公共祖先
+func (el *EulerLCA) LCA(u, v int) (w int) {
+	if el == nil || u < 0 || u >= len(el.depth) || v < 0 || v >= len(el.depth) {
+		return -1
+	}
+	if el.root[u] != el.root[v] {
+		return -1
+	}
+	l, r := el.first[u], el.first[v]
+	if l > r {
+		l, r = r, l
+	}
+	return el.st.Query(l, r+1).(int)
+}
+
+//@title    Distance
+//@description
+//		以eulerLCA做接收者
+//		返回节点u和v之间路径上的边数
+//		若节点不存在或两节点不在同一棵树中则返回-1
+//@receiver		el			*EulerLCA				接受者eulerLCA的指针
+//@param    	u			int						节点
+//@param    	v			int						节点
+//@return    	dist		int						路径上的边数
+func (el *EulerLCA) Distance(u, v int) (dist int) {
+	w := el.LCA(u, v)
+	if w == -1 {
+		return -1
+	}
+	return el.depth[u] + el.depth[v] - 2*el.depth[w]
+}
@@ -0,0 +1,182 @@
+package sparseTable
+
+//@Title		sparseTable
+//@Description
+//		基于倍增的最近公共祖先-LCA via binary lifting
+//		up[j][v]为节点v向上第2^j个祖先,超出根节点时为根节点自身
+//		先将较深的节点提升到与另一节点相同的深度,再从高到低同时提升两个节点直到其父节点相同
+//		预处理的时间和空间复杂度为O(n log n),每次查询为O(log n)
+//		相比欧拉序的做法,倍增还可以在O(log n)时间内求出任意节点的第k个祖先
+//		建立后不可修改,因此不需要进行并发控制
+
+//liftingLCA结构体
+//该实例存储每个节点的倍增祖先表
+//同时保存每个节点的深度和所在树的根节点
+type LiftingLCA struct {
+	up    [][]int //up[j][v]为节点v向上第2^j个祖先
+	depth []int   //每个节点的深度
+	root  []int   //每个节点所在树的根节点
+}
+
+//liftingLCA容器接口
+//存放了liftingLCA可使用的函数
+//对应函数介绍见下方
+type liftingLCAer interface {
+	Size() (num int)              //返回节点数量
+	Depth(v int) (d int)          //返回节点v的深度
+	LCA(u, v int) (w int)         //返回节点u和v的最近公共祖先
+	Distance(u, v int) (dist int) //返回节点u和v之间的边数
+	KthAncestor(v, k int) (w int) //返回节点v向上第k个祖先
+}
+
+//@title    NewLiftingLCA
+//@description
+//		以父节点数组新建一个liftingLCA并返回
+//		parents[v]为节点v的父节点,根节点的父节点为-1,允许存在多个根节点
+//		若父节点数组越界或存在环则返回nil
+//@receiver		nil
+//@param    	parents		[]int					父节点数组
+//@return    	ll        	*LiftingLCA				新建的liftingLCA指针
+func NewLiftingLCA(parents []int) (ll *LiftingLCA) {
+	_, order, depth, root, ok := prepare(parents)
+	if !ok {
+		return nil
+	}
+	n := len(parents)
+	ll = &LiftingLCA{
+		up:    make([][]int, 1),
+		depth: depth,
+		root:  root,
+	}
+	ll.up[0] = make([]int, n)
+	maxDepth := 0
+	for _, v := range order {
+		if parents[v] == -1 {
+			ll.up[0][v] = v
+		} else {
+			ll.up[0][v] = parents[v]
+		}
+		if depth[v] > maxDepth {
+			maxDepth = depth[v]
+		}
+	}
+	for j := 1; 1<<uint(j) <= maxDepth; j++ {
+		prev, level := ll.up[j-1], make([]int, n)
+		for v := range level {
+			level[v] = prev[prev[v]]
+		}
+		ll.up = append(ll.up, level)
+	}
+	return ll
+}
+
+//@title    Size
+//@description
+//		以liftingLCA做接收者
+//		返回节点数量
+//		如果容器为nil返回0
+//@receiver		ll			*LiftingLCA				接受者liftingLCA的指针
+//@param    	nil
+//@return    	num        	int						节点数量
+func (ll *LiftingLCA) Size() (num int) {
+	if ll == nil {
+		return 0
+	}
+	return len(ll.depth)
+}
+
+//@title    Depth
+//@description
+//		以liftingLCA做接收者
+//		返回节点v的深度,根节点深度为0
+//		若节点不存在则返回-1
+//@receiver		ll			*LiftingLCA				接受者liftingLCA的指针
+//@param    	v			int						节点
+//@return    	d			int						深度
+func (ll *LiftingLCA) Depth(v int) (d int) {
+	if ll == nil || v < 0 || v >= len(ll.depth) {
+		return -1
+	}
+	return ll.depth[v]
+}
+
+//@title    lift
+//@description
+//		以liftingLCA做接收者
+//		返回节点v向上第k个祖先,k不可超过v的深度
+//@receiver		ll			*LiftingLCA				接受者liftingLCA的指针
+//@param    	v			int						节点
+//@param    	k			int						向上的层数
+//@return    	w			int						祖先节点
+func (ll *LiftingLCA) lift(v, k int) (w int) {
+	for j := 0; k > 0; j, k = j+1, k>>1 {
+		if k&1 == 1 {
+			v = ll.up[j][v]
+		}
+	}
+	return v
+}
+
+//@title    KthAncestor
+//@description
+//		以liftingLCA做接收者
+//		返回节点v向上第k个祖先,k为0时返回v自身
+//		若节点不存在、k为负数或k超过v的深度则返回-1
+//@receiver		ll			*LiftingLCA				接受者liftingLCA的指针
+//@param    	v			int						节点
+//@param    	k			int						向上的层数
+//@return    	w			int						祖先节点
+func (ll *LiftingLCA) KthAncestor(v, k int) (w int) {
+	if ll == nil || v < 0 || v >= len(ll.depth) || k < 0 || k > ll.depth[v] {
+		return -1
+	}
+	return ll.lift(v, k)
+}
+
+//@title    LCA
+//@description
+//		以liftingLCA做接收者
+//		返回节点u和v的最近公共祖先
+//		若节点不存在或两节点不在同一棵树中则返回-1
+//@receiver		ll			*LiftingLCA				接受者liftingLCA的指针
+//@param    	u			int						节点
+//@param    	v			int						节点
+//@return    	w			int						最近公共祖先
+func (ll *LiftingLCA) LCA(u, v int) (w int) {
+	if ll == nil || u < 0 || u >= len(ll.depth) || v < 0 || v >= len(ll.depth) {
+		return -1
+	}
+	if ll.root[u] != ll.root[v] {
+		return -1
+	}
+	if ll.depth[u] < ll.depth[v] {
+		u, v = v, u
+	}
+	u = ll.lift(u, ll.depth[u]-ll.depth[v])
+	if u == v {
+		return u
+	}
+	for j := len(ll.up) - 1; j >= 0; j-- {
+		if ll.up[j][u] != ll.up[j][v] {
+			u, v = ll.up[j][u], ll.up[j][v]
+		}
+	}
+	return ll.up[0][u]
+}
+
+//@title    Distance
+//@description
+//		以liftingLCA做接收者
+//		返回节点u和v之间路径上的边数
+//		若节点不存在或两节点不在同一棵树中则返回-1
+//@receiver		ll			*LiftingLCA				接受者liftingLCA的指针
+//@param    	u			int						节点
+//@param    	v			int						节点
+//@return    	dist		int						路径上的边数
+func (ll *LiftingLCA) Distance(u, v int) (dist int) {
+	w := ll.LCA(u, v)
+	if w == -1 {
+		return -1
+	}
+	return ll.depth[u] + ll.depth[v] - 2*ll.depth[w]
+}
@@ -0,0 +1,246 @@
+package sparseTable
+
+//@Title		sparseTable
+//@Description
+//		稀疏表-Sparse Table
+//		用于静态序列上的区间查询,table[j][i]保存[i,i+2^j)区间的聚合值
+//		聚合运算需满足结合律和幂等性,即op(a,a)=a,如最小值、最大值、最大公约数等
+//		由于幂等性,任意区间都可以由两个可能重叠的2的幂长度区间覆盖,从而在O(1)时间内完成查询
+//		建表的时间和空间复杂度均为O(n log n)
+//		区间均为左闭右开区间[l,r)
+//		建立后不可修改,因此不需要进行并发控制
+
+import (
+	"github.com/hlccd/goSTL/data_structure/vector"
+	"github.com/hlccd/goSTL/utils/comparator"
+	"github.com/hlccd/goSTL/utils/iterator"
+)
+
+//Op聚合运算
+//需满足结合律和幂等性
+type Op func(a, b interface{}) interface{}
+
+//sparseTable稀疏表结构体
+//该实例存储各层的聚合值以及每个长度对应的层数
+type SparseTable struct {
+	table [][]interface{} //table[j][i]为[i,i+2^j)区间的聚合值
+	log   []int           //log[k]为不超过k的最大的2的幂的指数
+	size  int             //序列长度
+	op    Op              //聚合运算
+}
+
+//sparseTable稀疏表容器接口
+//存放了sparseTable稀疏表可使用的函数
+//对应函数介绍见下方
+type sparseTabler interface {
+	Iterator() (i *Iterator.Iterator) //返回包含该序列所有元素的迭代器
+	Size() (num int)                  //返回该序列的长度
+	Empty() (b bool)                  //判断该序列是否为空
+	At(idx int) (e interface{})       //返回下标idx处的元素
+	Query(l, r int) (ans interface{}) //返回[l,r)区间内元素的聚合值
+}
+
+//@title    Min
+//@description
+//		以比较函数生成取较小值的聚合运算
+//		若未传入比较函数则在比较时寻找默认比较器
+//		找不到比较器时(如元素不是内置类型)聚合结果为nil,New会据此返回nil
+//		相等时返回左侧的元素
+//@receiver		nil
+//@param    	Cmp			...comparator.Comparator	比较函数
+//@return    	op			Op						取较小值的聚合运算
+func Min(Cmp ...comparator.Comparator) (op Op) {
+	var cmp comparator.Comparator
+	if len(Cmp) > 0 {
+		cmp = Cmp[0]
+	}
+	return func(a, b interface{}) interface{} {
+		c := cmp
+		if c == nil {
+			c = comparator.GetCmp(a)
+		}
+		if c == nil {
+			return nil
+		}
+		if c(b, a) < 0 {
+			return b
+		}
+		return a
+	}
+}
+
+//@title    Max
+//@description
+//		以比较函数生成取较大值的聚合运算
+//		若未传入比较函数则在比较时寻找默认比较器
+//		找不到比较器时(如元素不是内置类型)聚合结果为nil,New会据此返回nil
+//		相等时返回左侧的元素
+//@receiver		nil
+//@param    	Cmp			...comparator.Comparator	比较函数
+//@return    	op			Op						取较大值的聚合运算
+func Max(Cmp ...comparator.Comparator) (op Op) {
+	var cmp comparator.Comparator
+	if len(Cmp) > 0 {
+		cmp = Cmp[0]
+	}
+	return func(a, b interface{}) interface{} {
+		c := cmp
+		if c == nil {
+			c = comparator.GetCmp(a)
+		}
+		if c == nil {
+			return nil
+		}
+		if c(b, a) > 0 {
+			return b
+		}
+		return a
+	}
+}
+
+//@title    New
+//@description
+//		新建一个sparseTable稀疏表容器并返回
+//		以切片es中的元素作为序列,在O(n log n)时间内建表
+//		传入的聚合运算不可为nil,否则返回nil
+//		若聚合运算对首个元素自身的聚合结果为nil(如Min/Max找不到比较器),同样返回nil
+//@receiver		nil
+//@param    	es			[]interface{}			序列
+//@param    	op			Op						满足结合律和幂等性的聚合运算
+//@return    	st        	*SparseTable			新建的sparseTable指针
+func New(es []interface{}, op Op) (st *SparseTable) {
+	if op == nil {
+		return nil
+	}
+	if len(es) > 0 && es[0] != nil && op(es[0], es[0]) == nil {
+		//聚合运算无法处理该类型的元素,提前返回而非在查询时出错
+		return nil
+	}
+	n := len(es)
+	st = &SparseTable{
+		table: make([][]interface{}, 0),
+		log:   make([]int, n+1),
+		size:  n,
+		op:    op,
+	}
+	for k := 2; k <= n; k++ {
+		st.log[k] = st.log[k/2] + 1
+	}
+	if n == 0 {
+		return st
+	}
+	level := make([]interface{}, n)
+	copy(level, es)
+	st.table = append(st.table, level)
+	//由上一层相邻的两个区间合并得到下一层
+	for j := 1; 1<<uint(j) <= n; j++ {
+		prev, half := st.table[j-1], 1<<uint(j-1)
+		level = make([]interface{}, n-(1<<uint(j))+1)
+		for i := range level {
+			level[i] = op(prev[i], prev[i+half])
+		}
+		st.table = append(st.table, level)
+	}
+	return st
+}
+
+//@title    NewFromVector
+//@description
+//		新建一个sparseTable稀疏表容器并返回
+//		以vector中的元素作为序列,在O(n log n)时间内建表
+//		传入的聚合运算不可为nil,否则返回nil
+//		若聚合运算对首个元素自身的聚合结果为nil(如Min/Max找不到比较器),同样返回nil
+//@receiver		nil
+//@param    	v			*vector.Vector			序列
+//@param    	op			Op						满足结合律和幂等性的聚合运算
+//@return    	st        	*SparseTable			新建的sparseTable指针
+func NewFromVector(v *vector.Vector, op Op) (st *SparseTable) {
+	es := make([]interface{}, 0, v.Size())
+	if !v.Empty() {
+		for i := v.Iterator().Begin(); i.HasNext(); i.Next() {
+			es = append(es, i.Value())
+		}
+	}
+	return New(es, op)
+}
+
+//@title    Iterator
+//@description
+//		以sparseTable稀疏表做接收者
+//		将序列中的所有元素按顺序放入迭代器中
+//@receiver		st			*SparseTable			接受者sparseTable的指针
+//@param    	nil
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (st *SparseTable) Iterator() (i *Iterator.Iterator) {
+	if st == nil {
+		return nil
+	}
+	es := make([]interface{}, st.size)
+	if st.size > 0 {
+		copy(es, st.table[0])
+	}
+	return Iterator.New(&es)
+}
+
+//@title    Size
+//@description
+//		以sparseTable稀疏表做接收者
+//		返回该序列的长度
+//		如果容器为nil返回0
+//@receiver		st			*SparseTable			接受者sparseTable的指针
+//@param    	nil
+//@return    	num        	int						序列长度
+func (st *SparseTable) Size() (num int) {
+	if st == nil {
+		return 0
+	}
+	return st.size
+}
+
+//@title    Empty
+//@description
+//		以sparseTable稀疏表做接收者
+//		判断该序列是否为空
+//		如果容器为nil返回true
+//@receiver		st			*SparseTable			接受者sparseTable的指针
+//@param    	nil
+//@return    	b			bool					该容器是空的吗?
+func (st *SparseTable) Empty() (b bool) {
+	if st == nil {
+		return true
+	}
+	return st.size == 0
+}
+
+//@title    At
+//@description
+//		以sparseTable稀疏表做接收者
+//		返回下标idx处的元素
+//		若下标越界则返回nil
+//@receiver		st			*SparseTable			接受者sparseTable的指针
+//@param    	idx			int						下标
+//@return    	e			interface{}				该下标处的元素
+func (st *SparseTable) At(idx int) (e interface{}) {
+	if st == nil || idx < 0 || idx >= st.size {
+		return nil
+	}
+	return st.table[0][idx]
+}
+
+//@title    Query
+//@description
+//		以sparseTable稀疏表做接收者
+//		返回[l,r)区间内元素的聚合值
+//		取不超过区间长度的最大的2的幂k,用[l,l+k)和[r-k,r)两个区间覆盖查询区间
+//		若区间为空或越界则返回nil
+//@receiver		st			*SparseTable			接受者sparseTable的指针
+//@param    	l			int						区间左端点,包含
+//@param    	r			int						区间右端点,不包含
+//@return    	ans			interface{}				区间聚合值
+func (st *SparseTable) Query(l, r int) (ans interface{}) {
+	if st == nil || l < 0 || r > st.size || l >= r {
+		return nil
+	}
+	j := st.log[r-l]
+	return st.op(st.table[j][l], st.table[j][r-(1<<uint(j))])
+}
@@ -0,0 +1,50 @@
+package sparseTable
+
+//@Title		sparseTable
+//@Description
+//		有根树(森林)的公共处理
+//		树以父节点数组的形式给出,parents[v]为节点v的父节点,根节点的父节点为-1
+//		节点编号为[0,n),可以存在多个根节点,即允许传入森林
+
+//@title    prepare
+//@description
+//		校验父节点数组并计算每个节点的子节点、深度和所在树的根节点
+//		order为按深度从小到大的遍历顺序,保证父节点在子节点之前出现
+//		若存在越界的父节点或环则返回false
+//@receiver		nil
+//@param    	parents		[]int					父节点数组
+//@return    	children	[][]int					每个节点的子节点
+//@return    	order		[]int					父节点先于子节点的遍历顺序
+//@return    	depth		[]int					每个节点的深度,根节点为0
+//@return    	root		[]int					每个节点所在树的根节点
+//@return    	b			bool					是合法的森林吗?
+func prepare(parents []int) (children [][]int, order, depth, root []int, b bool) {
+	n := len(parents)
+	children = make([][]int, n)
+	order = make([]int, 0, n)
+	depth = make([]int, n)
+	root = make([]int, n)
+	for v, p := range parents {
+		if p < -1 || p >= n || p == v {
+			return nil, nil, nil, nil, false
+		}
+		if p == -1 {
+			order = append(order, v)
+			root[v] = v
+		} else {
+			children[p] = append(children[p], v)
+		}
+	}
+	//从所有根节点出发逐层扩展,环上的节点不可能被访问到
+	for i := 0; i < len(order); i++ {
+		u := order[i]
+		for _, v := range children[u] {
+			depth[v], root[v] = depth[u]+1, root[u]
+			order = append(order, v)
+		}
+	}
+	if len(order) != n {
+		return nil, nil, nil, nil, false
+	}
+	return children, order, depth, root, true
+}
@@ -0,0 +1,242 @@
+package treap
+
+//@Title		treap
+//@Description
+//		隐式树堆的节点
+//		隐式树堆不以元素大小作为排序依据,而是以元素在序列中的位置作为隐式的键
+//		节点中记录子树大小,通过子树大小即可确定节点在序列中的位置
+//		节点中记录区间翻转的懒标记,以及正序和逆序两个方向的区间聚合值
+import (
+	"math/rand"
+)
+
+//implicitNode隐式树堆节点结构体
+//该节点是隐式树堆的树节点
+//节点的中缀序列即为该隐式树堆所表示的序列
+//isReverse为真时表示该节点的左右子树仍需进行翻转
+type implicitNode struct {
+	value     interface{}   //节点中存储的元素
+	priority  uint32        //该节点的优先级,随机生成
+	size      int           //以该节点为根的子树中的元素数量
+	isReverse bool          //子树是否待翻转的懒标记
+	sum       interface{}   //子树中缀序列的聚合值
+	rsum      interface{}   //子树逆序中缀序列的聚合值
+	left      *implicitNode //左节点指针
+	right     *implicitNode //右节点指针
+}
+
+//@title    newImplicitNode
+//@description
+//		新建一个隐式树堆节点并返回
+//		将传入的元素e作为该节点的承载元素
+//		该节点的size默认为1,聚合值即为该元素本身,左右子节点设为nil
+//		该节点优先级随机生成
+//@receiver		nil
+//@param    	e			interface{}				承载元素e
+//@param    	rand		*rand.Rand				随机数生成器
+//@return    	n        	*implicitNode			新建的隐式树堆节点的指针
+func newImplicitNode(e interface{}, rand *rand.Rand) (n *implicitNode) {
+	return &implicitNode{
+		value:    e,
+		priority: uint32(rand.Intn(4294967295)),
+		size:     1,
+		sum:      e,
+		rsum:     e,
+		left:     nil,
+		right:    nil,
+	}
+}
+
+//@title    getSize
+//@description
+//		以implicitNode隐式树堆节点做接收者
+//		返回以该节点为根的子树中的元素数量,节点不存在返回0
+//@receiver		n			*implicitNode			接受者implicitNode的指针
+//@param    	nil
+//@return    	size        int						子树中的元素数量
+func (n *implicitNode) getSize() (size int) {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+//@title    getSum
+//@description
+//		以implicitNode隐式树堆节点做接收者
+//		返回以该节点为根的子树的聚合值,节点不存在则返回幺半群的单位元
+//@receiver		n			*implicitNode			接受者implicitNode的指针
+//@param    	m			*Monoid					聚合所用的幺半群
+//@return    	sum         interface{}				子树的聚合值
+func (n *implicitNode) getSum(m *Monoid) (sum interface{}) {
+	if n == nil {
+		return m.Identity
+	}
+	return n.sum
+}
+
+//@title    getRsum
+//@description
+//		以implicitNode隐式树堆节点做接收者
+//		返回以该节点为根的子树逆序后的聚合值,节点不存在则返回幺半群的单位元
+//@receiver		n			*implicitNode			接受者implicitNode的指针
+//@param    	m			*Monoid					聚合所用的幺半群
+//@return    	rsum        interface{}				子树逆序后的聚合值
+func (n *implicitNode) getRsum(m *Monoid) (rsum interface{}) {
+	if n == nil {
+		return m.Identity
+	}
+	return n.rsum
+}
+
+//@title    reverse
+//@description
+//		以implicitNode隐式树堆节点做接收者
+//		翻转以n为根的子树所表示的序列
+//		仅交换n的左右子节点和两个方向的聚合值,并对懒标记取反
+//		子树内部的翻转推迟到访问时再下放
+//@receiver		n			*implicitNode			接受者implicitNode的指针
+//@param    	nil
+//@return    	nil
+func (n *implicitNode) reverse() {
+	if n == nil {
+		return
+	}
+	n.left, n.right = n.right, n.left
+	n.sum, n.rsum = n.rsum, n.sum
+	n.isReverse = !n.isReverse
+}
+
+//@title    pushDown
+//@description
+//		以implicitNode隐式树堆节点做接收者
+//		若n节点带有翻转懒标记,则将其下放至左右子节点
+//		在访问n节点的子节点前都需要先进行下放
+//@receiver		n			*implicitNode			接受者implicitNode的指针
+//@param    	nil
+//@return    	nil
+func (n *implicitNode) pushDown() {
+	if n.isReverse {
+		n.left.reverse()
+		n.right.reverse()
+		n.isReverse = false
+	}
+}
+
+//@title    update
+//@description
+//		以implicitNode隐式树堆节点做接收者
+//		根据左右子节点重新计算n节点的子树大小
+//		若传入了幺半群,则同时重新计算两个方向的聚合值
+//@receiver		n			*implicitNode			接受者implicitNode的指针
+//@param    	m			*Monoid					聚合所用的幺半群,可以为nil
+//@return    	nil
+func (n *implicitNode) update(m *Monoid) {
+	n.size = n.left.getSize() + n.right.getSize() + 1
+	if m == nil {
+		return
+	}
+	n.sum = m.Op(m.Op(n.left.getSum(m), n.value), n.right.getSum(m))
+	n.rsum = m.Op(m.Op(n.right.getRsum(m), n.value), n.left.getRsum(m))
+}
+
+//@title    inOrder
+//@description
+//		以implicitNode隐式树堆节点做接收者
+//		以中缀序列返回节点集合,即该子树所表示的序列
+//@receiver		n			*implicitNode			接受者implicitNode的指针
+//@param    	nil
+//@return    	es        	[]interface{}			以该节点为起点的中缀序列
+func (n *implicitNode) inOrder() (es []interface{}) {
+	if n == nil {
+		return es
+	}
+	n.pushDown()
+	if n.left != nil {
+		es = append(es, n.left.inOrder()...)
+	}
+	es = append(es, n.value)
+	if n.right != nil {
+		es = append(es, n.right.inOrder()...)
+	}
+	return es
+}
+
+//@title    at
+//@description
+//		以implicitNode隐式树堆节点做接收者
+//		返回以n为根的子树所表示的序列中下标为idx的节点
+//		调用前需保证idx在范围内
+//@receiver		n			*implicitNode			接受者implicitNode的指针
+//@param    	idx			int						待寻找节点的下标
+//@return    	m        	*implicitNode			下标为idx的节点
+func (n *implicitNode) at(idx int) (m *implicitNode) {
+	for n != nil {
+		n.pushDown()
+		l := n.left.getSize()
+		if idx < l {
+			n = n.left
+		} else if idx == l {
+			return n
+		} else {
+			idx -= l + 1
+			n = n.right
+		}
+	}
+	return nil
+}
+
+//@title    split
+//@description
+//		以implicitNode隐式树堆节点做接收者
+//		将以n为根的子树所表示的序列拆分为前k个元素和剩余元素两部分
+//@receiver		n			*implicitNode			接受者implicitNode的指针
+//@param    	k			int						左侧序列的元素数量
+//@param    	m			*Monoid					聚合所用的幺半群,可以为nil
+//@return    	l        	*implicitNode			前k个元素构成的子树
+//@return    	r        	*implicitNode			剩余元素构成的子树
+func (n *implicitNode) split(k int, m *Monoid) (l, r *implicitNode) {
+	if n == nil {
+		return nil, nil
+	}
+	n.pushDown()
+	if n.left.getSize() < k {
+		//n及其左子树均属于左侧序列,继续拆分右子树
+		n.right, r = n.right.split(k-n.left.getSize()-1, m)
+		n.update(m)
+		return n, r
+	}
+	//n及其右子树均属于右侧序列,继续拆分左子树
+	l, n.left = n.left.split(k, m)
+	n.update(m)
+	return l, n
+}
+
+//@title    mergeImplicit
+//@description
+//		将两棵隐式树堆子树首尾相接合并为一棵子树并返回其根节点
+//		合并后的序列为l所表示的序列后接r所表示的序列
+//		优先级较小的节点作为根节点,以保证合并后仍满足堆的性质
+//@receiver		nil
+//@param    	l			*implicitNode			位于前部的子树
+//@param    	r			*implicitNode			位于后部的子树
+//@param    	m			*Monoid					聚合所用的幺半群,可以为nil
+//@return    	n        	*implicitNode			合并后的子树根节点
+func mergeImplicit(l, r *implicitNode, m *Monoid) (n *implicitNode) {
+	if l == nil {
+		return r
+	}
+	if r == nil {
+		return l
+	}
+	if l.priority < r.priority {
+		l.pushDown()
+		l.right = mergeImplicit(l.right, r, m)
+		l.update(m)
+		return l
+	}
+	r.pushDown()
+	r.left = mergeImplicit(l, r.left, m)
+	r.update(m)
+	return r
+}
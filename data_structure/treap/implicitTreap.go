@@ -0,0 +1,390 @@
+package treap
+
+//@Title		treap
+//@Description
+//		隐式树堆-Implicit Treap
+//		隐式树堆以元素在序列中的位置作为隐式的键,从而将树堆作为一个序列容器使用
+//		通过按位置拆分和首尾合并,可在依概率O(log n)的时间内完成任意位置的插入删除
+//		同时支持区间翻转、区间剪切粘贴以及序列拼接
+//		若创建时传入幺半群,则可在依概率O(log n)的时间内求任意区间的聚合值,如区间和、最小值、最大值
+//		适用于协同编辑缓冲区等需要频繁在中间位置编辑的序列
+//		使用互斥锁实现并发控制
+
+import (
+	"github.com/hlccd/goSTL/utils/iterator"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+//Monoid幺半群结构体
+//包含一个满足结合律的二元运算和该运算的单位元
+//隐式树堆通过该幺半群对区间内的元素进行聚合
+//若需要对区间进行翻转,无需运算满足交换律,翻转后的聚合值仍然正确
+type Monoid struct {
+	Op       func(a, b interface{}) interface{} //满足结合律的二元运算
+	Identity interface{}                        //该运算的单位元
+}
+
+//implicitTreap隐式树堆结构体
+//该实例存储隐式树堆的根节点
+//同时保存该隐式树堆中已经存储了多少个元素
+//该隐式树堆实例中存储随机数生成器,用于后续新建节点时生成随机数
+//若创建时传入幺半群,则节点中同时维护区间聚合值
+type implicitTreap struct {
+	root   *implicitNode //根节点指针
+	size   int           //存储元素数量
+	monoid *Monoid       //聚合所用的幺半群,为nil时不维护聚合值
+	rand   *rand.Rand    //随机数生成器
+	mutex  sync.Mutex    //并发控制锁
+}
+
+//implicitTreap隐式树堆容器接口
+//存放了implicitTreap隐式树堆可使用的函数
+//对应函数介绍见下方
+type implicitTreaper interface {
+	Iterator() (i *Iterator.Iterator)         //返回包含该序列所有元素的迭代器
+	Size() (num int)                          //返回该序列中保存的元素个数
+	Clear()                                   //清空该序列
+	Empty() (b bool)                          //判断该序列是否为空
+	InsertAt(idx int, e interface{}) (b bool) //在下标idx处插入元素e
+	EraseAt(idx int) (b bool)                 //删除下标idx处的元素
+	At(idx int) (e interface{})               //返回下标idx处的元素
+	Reverse(l, r int) (b bool)                //翻转[l,r)区间内的元素
+	Query(l, r int) (ans interface{})         //返回[l,r)区间内元素的聚合值
+	Cut(l, r int) (s *implicitTreap)          //将[l,r)区间内的元素剪切为一个新序列
+	Paste(idx int, s *implicitTreap) (b bool) //将序列s粘贴到下标idx处
+	Concat(s *implicitTreap) (b bool)         //将序列s拼接到该序列末尾
+}
+
+//@title    NewImplicit
+//@description
+//		新建一个implicitTreap隐式树堆容器并返回
+//		初始根节点为nil
+//		若有传入的幺半群且其运算不为nil,则将传入的第一个幺半群设为该隐式树堆的聚合方式
+//@receiver		nil
+//@param    	ms			...Monoid				聚合所用的幺半群集
+//@return    	it        	*implicitTreap			新建的implicitTreap指针
+func NewImplicit(ms ...Monoid) (it *implicitTreap) {
+	var m *Monoid
+	if len(ms) > 0 && ms[0].Op != nil {
+		m = &Monoid{
+			Op:       ms[0].Op,
+			Identity: ms[0].Identity,
+		}
+	}
+	//创建随机数生成器
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	return &implicitTreap{
+		root:   nil,
+		size:   0,
+		monoid: m,
+		rand:   r,
+		mutex:  sync.Mutex{},
+	}
+}
+
+//@title    Iterator
+//@description
+//		以implicitTreap隐式树堆做接收者
+//		将该隐式树堆所表示的序列按顺序放入迭代器中
+//@receiver		it			*implicitTreap			接受者implicitTreap的指针
+//@param    	nil
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (it *implicitTreap) Iterator() (i *Iterator.Iterator) {
+	if it == nil {
+		return nil
+	}
+	it.mutex.Lock()
+	es := it.root.inOrder()
+	i = Iterator.New(&es)
+	it.mutex.Unlock()
+	return i
+}
+
+//@title    Size
+//@description
+//		以implicitTreap隐式树堆做接收者
+//		返回该容器当前含有元素的数量
+//		如果容器为nil返回0
+//@receiver		it			*implicitTreap			接受者implicitTreap的指针
+//@param    	nil
+//@return    	num        	int						容器中存储的元素个数
+func (it *implicitTreap) Size() (num int) {
+	if it == nil {
+		return 0
+	}
+	return it.size
+}
+
+//@title    Clear
+//@description
+//		以implicitTreap隐式树堆做接收者
+//		将该容器中所承载的元素清空
+//		将该容器的size置0
+//@receiver		it			*implicitTreap			接受者implicitTreap的指针
+//@param    	nil
+//@return    	nil
+func (it *implicitTreap) Clear() {
+	if it == nil {
+		return
+	}
+	it.mutex.Lock()
+	it.root = nil
+	it.size = 0
+	it.mutex.Unlock()
+}
+
+//@title    Empty
+//@description
+//		以implicitTreap隐式树堆做接收者
+//		判断该隐式树堆是否含有元素
+//		如果含有元素则不为空,返回false
+//		如果不含有元素则说明为空,返回true
+//		如果容器不存在,返回true
+//@receiver		it			*implicitTreap			接受者implicitTreap的指针
+//@param    	nil
+//@return    	b			bool					该容器是空的吗?
+func (it *implicitTreap) Empty() (b bool) {
+	if it == nil {
+		return true
+	}
+	return it.size == 0
+}
+
+//@title    InsertAt
+//@description
+//		以implicitTreap隐式树堆做接收者
+//		在序列的下标idx处插入元素e,原下标idx及之后的元素向后移动一位
+//		idx等于序列长度时即为在末尾插入
+//		先按下标拆分,再将新节点与两部分依次合并
+//		若下标超出范围则插入失败
+//@receiver		it			*implicitTreap			接受者implicitTreap的指针
+//@param    	idx			int						插入位置的下标
+//@param    	e			interface{}				待插入元素
+//@return    	b			bool					插入成功?
+func (it *implicitTreap) InsertAt(idx int, e interface{}) (b bool) {
+	if it == nil {
+		return false
+	}
+	it.mutex.Lock()
+	if idx < 0 || idx > it.size {
+		it.mutex.Unlock()
+		return false
+	}
+	l, r := it.root.split(idx, it.monoid)
+	it.root = mergeImplicit(mergeImplicit(l, newImplicitNode(e, it.rand), it.monoid), r, it.monoid)
+	it.size++
+	it.mutex.Unlock()
+	return true
+}
+
+//@title    EraseAt
+//@description
+//		以implicitTreap隐式树堆做接收者
+//		删除序列中下标为idx的元素,其后的元素向前移动一位
+//		先将该元素单独拆分出来,再将其两侧合并
+//		若下标超出范围则删除失败
+//@receiver		it			*implicitTreap			接受者implicitTreap的指针
+//@param    	idx			int						待删除元素的下标
+//@return    	b			bool					删除成功?
+func (it *implicitTreap) EraseAt(idx int) (b bool) {
+	if it == nil {
+		return false
+	}
+	it.mutex.Lock()
+	if idx < 0 || idx >= it.size {
+		it.mutex.Unlock()
+		return false
+	}
+	l, r := it.root.split(idx, it.monoid)
+	_, r = r.split(1, it.monoid)
+	it.root = mergeImplicit(l, r, it.monoid)
+	it.size--
+	it.mutex.Unlock()
+	return true
+}
+
+//@title    At
+//@description
+//		以implicitTreap隐式树堆做接收者
+//		返回序列中下标为idx的元素
+//		若下标超出范围则返回nil
+//@receiver		it			*implicitTreap			接受者implicitTreap的指针
+//@param    	idx			int						待查找元素的下标
+//@return    	e			interface{}				下标为idx的元素
+func (it *implicitTreap) At(idx int) (e interface{}) {
+	if it == nil {
+		return nil
+	}
+	it.mutex.Lock()
+	if idx < 0 || idx >= it.size {
+		it.mutex.Unlock()
+		return nil
+	}
+	e = it.root.at(idx).value
+	it.mutex.Unlock()
+	return e
+}
+
+//@title    Reverse
+//@description
+//		以implicitTreap隐式树堆做接收者
+//		翻转序列中[l,r)区间内的元素
+//		将该区间拆分出来后对其根节点打上翻转懒标记,再合并回去
+//		若区间不合法则翻转失败
+//@receiver		it			*implicitTreap			接受者implicitTreap的指针
+//@param    	l			int						区间左端点,包含
+//@param    	r			int						区间右端点,不包含
+//@return    	b			bool					翻转成功?
+func (it *implicitTreap) Reverse(l, r int) (b bool) {
+	if it == nil {
+		return false
+	}
+	it.mutex.Lock()
+	if l < 0 || r > it.size || l > r {
+		it.mutex.Unlock()
+		return false
+	}
+	a, bc := it.root.split(l, it.monoid)
+	m, c := bc.split(r-l, it.monoid)
+	m.reverse()
+	it.root = mergeImplicit(mergeImplicit(a, m, it.monoid), c, it.monoid)
+	it.mutex.Unlock()
+	return true
+}
+
+//@title    Query
+//@description
+//		以implicitTreap隐式树堆做接收者
+//		返回序列中[l,r)区间内元素按顺序通过幺半群运算得到的聚合值
+//		空区间返回幺半群的单位元
+//		若创建时未传入幺半群或区间不合法则返回nil
+//@receiver		it			*implicitTreap			接受者implicitTreap的指针
+//@param    	l			int						区间左端点,包含
+//@param    	r			int						区间右端点,不包含
+//@return    	ans			interface{}				区间聚合值
+func (it *implicitTreap) Query(l, r int) (ans interface{}) {
+	if it == nil {
+		return nil
+	}
+	it.mutex.Lock()
+	if it.monoid == nil || l < 0 || r > it.size || l > r {
+		it.mutex.Unlock()
+		return nil
+	}
+	a, bc := it.root.split(l, it.monoid)
+	m, c := bc.split(r-l, it.monoid)
+	ans = m.getSum(it.monoid)
+	it.root = mergeImplicit(mergeImplicit(a, m, it.monoid), c, it.monoid)
+	it.mutex.Unlock()
+	return ans
+}
+
+//@title    Cut
+//@description
+//		以implicitTreap隐式树堆做接收者
+//		将序列中[l,r)区间内的元素剪切出来,作为一个新的隐式树堆返回
+//		新的隐式树堆沿用原隐式树堆的幺半群
+//		剪切后原序列中该区间之后的元素向前移动
+//		若区间不合法则返回nil
+//@receiver		it			*implicitTreap			接受者implicitTreap的指针
+//@param    	l			int						区间左端点,包含
+//@param    	r			int						区间右端点,不包含
+//@return    	s			*implicitTreap			剪切得到的新序列
+func (it *implicitTreap) Cut(l, r int) (s *implicitTreap) {
+	if it == nil {
+		return nil
+	}
+	it.mutex.Lock()
+	if l < 0 || r > it.size || l > r {
+		it.mutex.Unlock()
+		return nil
+	}
+	a, bc := it.root.split(l, it.monoid)
+	m, c := bc.split(r-l, it.monoid)
+	it.root = mergeImplicit(a, c, it.monoid)
+	it.size -= r - l
+	s = &implicitTreap{
+		root:   m,
+		size:   r - l,
+		monoid: it.monoid,
+		rand:   rand.New(rand.NewSource(it.rand.Int63())),
+		mutex:  sync.Mutex{},
+	}
+	it.mutex.Unlock()
+	return s
+}
+
+//@title    giveBack
+//@description
+//		以implicitTreap隐式树堆做接收者
+//		将已取出但未能使用的序列放回该序列的开头
+//		取出期间该序列中可能已插入了新的元素,放回后这些元素位于被放回的序列之后
+//@receiver		it			*implicitTreap			接受者implicitTreap的指针
+//@param    	root		*implicitNode			待放回序列的根节点
+//@return    	nil
+func (it *implicitTreap) giveBack(root *implicitNode) {
+	it.mutex.Lock()
+	it.root = mergeImplicit(root, it.root, it.monoid)
+	it.size = it.root.getSize()
+	it.mutex.Unlock()
+}
+
+//@title    Paste
+//@description
+//		以implicitTreap隐式树堆做接收者
+//		将序列s整体插入到该序列的下标idx处,原下标idx及之后的元素向后移动
+//		要求两个序列使用相同的幺半群,否则聚合值将不再正确
+//		粘贴将直接移动s的节点,粘贴成功后s为空
+//		先对s加锁取出其节点后解锁,再对该序列加锁,不同时持有两把锁,避免并发调用a.Paste(0,b)和b.Paste(0,a)时相互等待
+//		若下标超出范围或s与该序列相同则粘贴失败,失败时s的节点会被放回s
+//@receiver		it			*implicitTreap			接受者implicitTreap的指针
+//@param    	idx			int						粘贴位置的下标
+//@param    	s			*implicitTreap			待粘贴的序列
+//@return    	b			bool					粘贴成功?
+func (it *implicitTreap) Paste(idx int, s *implicitTreap) (b bool) {
+	if it == nil || s == nil || it == s {
+		return false
+	}
+	s.mutex.Lock()
+	sr, ss := s.root, s.size
+	s.root, s.size = nil, 0
+	s.mutex.Unlock()
+	it.mutex.Lock()
+	if idx < 0 || idx > it.size {
+		it.mutex.Unlock()
+		s.giveBack(sr)
+		return false
+	}
+	l, r := it.root.split(idx, it.monoid)
+	it.root = mergeImplicit(mergeImplicit(l, sr, it.monoid), r, it.monoid)
+	it.size += ss
+	it.mutex.Unlock()
+	return true
+}
+
+//@title    Concat
+//@description
+//		以implicitTreap隐式树堆做接收者
+//		将序列s整体拼接到该序列的末尾
+//		要求两个序列使用相同的幺半群,否则聚合值将不再正确
+//		拼接将直接移动s的节点,拼接成功后s为空
+//		加锁方式同Paste,不同时持有两把锁
+//@receiver		it			*implicitTreap			接受者implicitTreap的指针
+//@param    	s			*implicitTreap			待拼接的序列
+//@return    	b			bool					拼接成功?
+func (it *implicitTreap) Concat(s *implicitTreap) (b bool) {
+	if it == nil || s == nil || it == s {
+		return false
+	}
+	s.mutex.Lock()
+	sr, ss := s.root, s.size
+	s.root, s.size = nil, 0
+	s.mutex.Unlock()
+	it.mutex.Lock()
+	it.root = mergeImplicit(it.root, sr, it.monoid)
+	it.size += ss
+	it.mutex.Unlock()
+	return true
+}
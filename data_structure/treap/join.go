@@ -0,0 +1,347 @@
+package treap
+
+//@Title		treap
+//@Description
+//		基于连接操作的树堆集合运算
+//		连接操作将两个树堆与一个中间节点按优先级合并为一个树堆,时间复杂度依概率为O(log n)
+//		拆分操作将一个树堆按元素拆分为两个树堆,时间复杂度依概率为O(log n)
+//		以连接和拆分为基础实现并集、交集、差集和过滤,对于大小为m和n(m<=n)的两个树堆时间复杂度依概率为O(m log(n/m + 1))
+//		左右子树的计算互不影响,当子树规模超过阈值时使用协程并行计算
+//		集合运算将直接移动参与运算的树堆的节点,运算后原树堆均为空
+
+import (
+	"github.com/hlccd/goSTL/utils/comparator"
+	"sync"
+)
+
+//并行计算的阈值
+//参与运算的两棵子树的元素数量之和超过该值时,左右子树将在不同协程中计算
+const parallelThreshold = 1 << 12
+
+//@title    join
+//@description
+//		将子树l、节点k和子树r连接为一棵子树并返回其根节点
+//		要求l中的元素均小于k中的元素,r中的元素均大于k中的元素
+//		k将先被置为单独的节点,随后依次与两侧按优先级合并
+//@receiver		nil
+//@param    	l			*node					元素较小的子树
+//@param    	k			*node					中间节点
+//@param    	r			*node					元素较大的子树
+//@return    	m        	*node					连接后的子树根节点
+func join(l, k, r *node) (m *node) {
+	k.left, k.right = nil, nil
+	k.size = k.num
+	return merge(merge(l, k), r)
+}
+
+//@title    partition
+//@description
+//		以node树堆节点做接收者
+//		将以n为根的子树按元素e拆分为小于e的子树、与e相等的节点和大于e的子树
+//		若不存在与e相等的节点则该节点返回nil
+//@receiver		n			*node					接受者node的指针
+//@param    	e			interface{}				拆分依据的元素
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	l        	*node					小于e的元素构成的子树
+//@return    	m        	*node					与e相等的节点
+//@return    	r        	*node					大于e的元素构成的子树
+func (n *node) partition(e interface{}, cmp comparator.Comparator) (l, m, r *node) {
+	if n == nil {
+		return nil, nil, nil
+	}
+	c := cmp(e, n.value)
+	if c < 0 {
+		l, m, n.left = n.left.partition(e, cmp)
+		n.size = n.left.getSize() + n.right.getSize() + n.num
+		return l, m, n
+	}
+	if c > 0 {
+		n.right, m, r = n.right.partition(e, cmp)
+		n.size = n.left.getSize() + n.right.getSize() + n.num
+		return n, m, r
+	}
+	l, r = n.left, n.right
+	n.left, n.right = nil, nil
+	n.size = n.num
+	return l, n, r
+}
+
+//@title    fork
+//@description
+//		执行左右两侧的计算
+//		若isParallel为true则左侧在新协程中计算,右侧在当前协程中计算,两者都结束后返回
+//		否则依次计算左右两侧
+//@receiver		nil
+//@param    	isParallel	bool					是否并行计算?
+//@param    	left		func()					左侧的计算
+//@param    	right		func()					右侧的计算
+//@return    	nil
+func fork(isParallel bool, left, right func()) {
+	if !isParallel {
+		left()
+		right()
+		return
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		left()
+		wg.Done()
+	}()
+	right()
+	wg.Wait()
+}
+
+//@title    union
+//@description
+//		求以a和b为根的两棵子树的并集并返回其根节点
+//		以b中与a根节点相等的元素为界拆分b,将两侧分别与a的左右子树求并集后连接
+//		若允许重复则相等元素的数量相加,否则以b中的元素覆盖a中的元素
+//@receiver		nil
+//@param    	a			*node					第一棵子树
+//@param    	b			*node					第二棵子树
+//@param    	isMulti		bool					是否允许重复?
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	n        	*node					并集的根节点
+func union(a, b *node, isMulti bool, cmp comparator.Comparator) (n *node) {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	isParallel := a.size+b.size > parallelThreshold
+	bl, m, br := b.partition(a.value, cmp)
+	al, ar := a.left, a.right
+	var l, r *node
+	fork(isParallel, func() {
+		l = union(al, bl, isMulti, cmp)
+	}, func() {
+		r = union(ar, br, isMulti, cmp)
+	})
+	if m != nil {
+		if isMulti {
+			a.num += m.num
+		} else {
+			a.value = m.value
+		}
+	}
+	return join(l, a, r)
+}
+
+//@title    intersect
+//@description
+//		求以a和b为根的两棵子树的交集并返回其根节点
+//		以b中与a根节点相等的元素为界拆分b,将两侧分别与a的左右子树求交集后连接
+//		若允许重复则相等元素的数量取两者中较小的一个
+//@receiver		nil
+//@param    	a			*node					第一棵子树
+//@param    	b			*node					第二棵子树
+//@param    	isMulti		bool					是否允许重复?
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	n        	*node					交集的根节点
+func intersect(a, b *node, isMulti bool, cmp comparator.Comparator) (n *node) {
+	if a == nil || b == nil {
+		return nil
+	}
+	isParallel := a.size+b.size > parallelThreshold
+	bl, m, br := b.partition(a.value, cmp)
+	al, ar := a.left, a.right
+	var l, r *node
+	fork(isParallel, func() {
+		l = intersect(al, bl, isMulti, cmp)
+	}, func() {
+		r = intersect(ar, br, isMulti, cmp)
+	})
+	if m == nil {
+		//a根节点的元素不在b中
+		return merge(l, r)
+	}
+	if isMulti && m.num < a.num {
+		a.num = m.num
+	}
+	return join(l, a, r)
+}
+
+//@title    difference
+//@description
+//		求以a为根的子树去掉以b为根的子树中元素后的差集并返回其根节点
+//		以b中与a根节点相等的元素为界拆分b,将两侧分别与a的左右子树求差集后连接
+//		若允许重复则相等元素的数量相减,减至0时删除该节点
+//@receiver		nil
+//@param    	a			*node					被减的子树
+//@param    	b			*node					减去的子树
+//@param    	isMulti		bool					是否允许重复?
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	n        	*node					差集的根节点
+func difference(a, b *node, isMulti bool, cmp comparator.Comparator) (n *node) {
+	if a == nil {
+		return nil
+	}
+	if b == nil {
+		return a
+	}
+	isParallel := a.size+b.size > parallelThreshold
+	bl, m, br := b.partition(a.value, cmp)
+	al, ar := a.left, a.right
+	var l, r *node
+	fork(isParallel, func() {
+		l = difference(al, bl, isMulti, cmp)
+	}, func() {
+		r = difference(ar, br, isMulti, cmp)
+	})
+	if m != nil {
+		if !isMulti || a.num <= m.num {
+			//a根节点的元素被全部减去
+			return merge(l, r)
+		}
+		a.num -= m.num
+	}
+	return join(l, a, r)
+}
+
+//@title    filter
+//@description
+//		过滤以n为根的子树,仅保留满足条件的元素,并返回其根节点
+//		左右子树分别过滤后,根据n节点是否满足条件选择是否以n为中间节点连接
+//@receiver		nil
+//@param    	n			*node					待过滤的子树
+//@param    	f			func(interface{}) bool	过滤条件,返回true的元素将被保留
+//@return    	m        	*node					过滤后的根节点
+func filter(n *node, f func(e interface{}) bool) (m *node) {
+	if n == nil {
+		return nil
+	}
+	isParallel := n.size > parallelThreshold
+	nl, nr := n.left, n.right
+	var l, r *node
+	fork(isParallel, func() {
+		l = filter(nl, f)
+	}, func() {
+		r = filter(nr, f)
+	})
+	if f(n.value) {
+		return join(l, n, r)
+	}
+	return merge(l, r)
+}
+
+//@title    takeOver
+//@description
+//		从a和b中取出根节点和比较器等信息,用于创建集合运算的结果
+//		结果沿用a是否允许重复的属性,比较器优先使用a的比较器
+//		取出后a和b均被置空
+//		依次对a和b加锁取出后立即解锁,不同时持有两把锁,避免并发调用Union(a,b)和Union(b,a)时相互等待
+//		若a和b为同一个树堆或任意一个不存在,则返回失败
+//@receiver		nil
+//@param    	a			*treap					第一个树堆
+//@param    	b			*treap					第二个树堆
+//@return    	ra        	*node					a的根节点
+//@return    	rb        	*node					b的根节点
+//@return    	t        	*treap					承载运算结果的空树堆
+func takeOver(a, b *treap) (ra, rb *node, t *treap) {
+	if a == nil || b == nil || a == b {
+		return nil, nil, nil
+	}
+	a.mutex.Lock()
+	cmp, isMulti := a.cmp, a.isMulti
+	ra = a.root
+	a.root, a.size = nil, 0
+	a.mutex.Unlock()
+	b.mutex.Lock()
+	if cmp == nil {
+		cmp = b.cmp
+	}
+	rb = b.root
+	b.root, b.size = nil, 0
+	b.mutex.Unlock()
+	return ra, rb, New(isMulti, cmp)
+}
+
+//@title    Union
+//@description
+//		求两个树堆的并集并以新的树堆返回
+//		若允许重复则相等元素的数量相加,否则以b中的元素覆盖a中的元素
+//		结果沿用a是否允许重复的属性,两个树堆应使用相同的比较器
+//		运算将直接移动a和b的节点,运算后a和b均为空
+//		若a和b为同一个树堆或任意一个不存在则返回nil
+//@receiver		nil
+//@param    	a			*treap					第一个树堆
+//@param    	b			*treap					第二个树堆
+//@return    	t        	*treap					并集
+func Union(a, b *treap) (t *treap) {
+	ra, rb, t := takeOver(a, b)
+	if t == nil {
+		return nil
+	}
+	t.root = union(ra, rb, t.isMulti, t.cmp)
+	t.size = t.root.getSize()
+	return t
+}
+
+//@title    Intersect
+//@description
+//		求两个树堆的交集并以新的树堆返回
+//		若允许重复则相等元素的数量取两者中较小的一个,元素取自a
+//		结果沿用a是否允许重复的属性,两个树堆应使用相同的比较器
+//		运算将直接移动a和b的节点,运算后a和b均为空
+//		若a和b为同一个树堆或任意一个不存在则返回nil
+//@receiver		nil
+//@param    	a			*treap					第一个树堆
+//@param    	b			*treap					第二个树堆
+//@return    	t        	*treap					交集
+func Intersect(a, b *treap) (t *treap) {
+	ra, rb, t := takeOver(a, b)
+	if t == nil {
+		return nil
+	}
+	t.root = intersect(ra, rb, t.isMulti, t.cmp)
+	t.size = t.root.getSize()
+	return t
+}
+
+//@title    Difference
+//@description
+//		求树堆a去掉树堆b中元素后的差集并以新的树堆返回
+//		若允许重复则相等元素的数量相减
+//		结果沿用a是否允许重复的属性,两个树堆应使用相同的比较器
+//		运算将直接移动a和b的节点,运算后a和b均为空
+//		若a和b为同一个树堆或任意一个不存在则返回nil
+//@receiver		nil
+//@param    	a			*treap					被减的树堆
+//@param    	b			*treap					减去的树堆
+//@return    	t        	*treap					差集
+func Difference(a, b *treap) (t *treap) {
+	ra, rb, t := takeOver(a, b)
+	if t == nil {
+		return nil
+	}
+	t.root = difference(ra, rb, t.isMulti, t.cmp)
+	t.size = t.root.getSize()
+	return t
+}
+
+//@title    Filter
+//@description
+//		过滤树堆a,将满足条件的元素以新的树堆返回
+//		过滤条件对每个不同的元素只调用一次,重复元素将被一并保留或删除
+//		过滤条件可能在多个协程中被同时调用
+//		运算将直接移动a的节点,运算后a为空
+//		若a不存在或过滤条件为nil则返回nil
+//@receiver		nil
+//@param    	a			*treap					待过滤的树堆
+//@param    	f			func(interface{}) bool	过滤条件,返回true的元素将被保留
+//@return    	t        	*treap					过滤结果
+func Filter(a *treap, f func(e interface{}) bool) (t *treap) {
+	if a == nil || f == nil {
+		return nil
+	}
+	a.mutex.Lock()
+	root := a.root
+	t = New(a.isMulti, a.cmp)
+	a.root, a.size = nil, 0
+	a.mutex.Unlock()
+	t.root = filter(root, f)
+	t.size = t.root.getSize()
+	return t
+}
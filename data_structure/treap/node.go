@@ -7,6 +7,7 @@ package treap
 //		也可通过节点返回整个二叉搜索树的所有元素
 import (
 	"github.com/hlccd/goSTL/utils/comparator"
+	"github.com/hlccd/goSTL/utils/cursor"
 	"math/rand"
 )
 
@@ -18,6 +19,7 @@ type node struct {
 	value    interface{} //节点中存储的元素
 	priority uint32      //该节点的优先级,随机生成
 	num      int         //该节点中存储的数量
+	size     int         //以该节点为根的子树中存储的元素数量,重复元素计入多次
 	left     *node       //左节点指针
 	right    *node       //右节点指针
 }
@@ -26,7 +28,7 @@ type node struct {
 //@description
 //		新建一个树堆节点并返回
 //		将传入的元素e作为该节点的承载元素
-//		该节点的num默认为1,左右子节点设为nil
+//		该节点的num和size默认为1,左右子节点设为nil
 //		该节点优先级随机生成,范围在0~2^16内
 //@receiver		nil
 //@param    	e			interface{}				承载元素e
@@ -37,6 +39,7 @@ func newNode(e interface{}, rand *rand.Rand) (n *node) {
 		value:    e,
 		priority: uint32(rand.Intn(4294967295)),
 		num:      1,
+		size:     1,
 		left:     nil,
 		right:    nil,
 	}
@@ -66,6 +69,19 @@ func (n *node) inOrder() (es []interface{}) {
 	return es
 }
 
+//@title    getSize
+//@description
+//		以node树堆节点做接收者
+//		返回以该节点为根的子树中存储的元素数量,节点不存在返回0
+//@receiver		n			*node					接受者node的指针
+//@param    	nil
+//@return    	size        int						子树中存储的元素数量
+func (n *node) getSize() (size int) {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
 
 //@title    rightRotate
 //@description
@@ -74,6 +90,7 @@ func (n *node) inOrder() (es []interface{}) {
 //		右转后的n节点的左节点是原n节点左节点的右节点,右转后的右节点保持不变
 //		原n节点改为原n节点的左节点,同时右节点指向新建的节点即右转后的n节点
 //		该右转方式可以保证n节点的双亲节点不用更换节点指向
+//		旋转前后以n为根的子树元素数量不变,只需重新计算新建节点的子树元素数量
 //@receiver		n			*node					接受者node的指针
 //@param    	nil
 //@return    	nil
@@ -92,6 +109,7 @@ func (n *node) rightRotate() {
 		left:     n.left.right,
 		right:    n.right,
 	}
+	tmp.size = tmp.left.getSize() + tmp.right.getSize() + tmp.num
 	//原n节点左节点上移到n节点位置
 	n.right = tmp
 	n.value = n.left.value
@@ -107,6 +125,7 @@ func (n *node) rightRotate() {
 //		左转后的n节点的右节点是原n节点右节点的左节点,左转后的左节点保持不变
 //		原n节点改为原n节点的右节点,同时左节点指向新建的节点即左转后的n节点
 //		该左转方式可以保证n节点的双亲节点不用更换节点指向
+//		旋转前后以n为根的子树元素数量不变,只需重新计算新建节点的子树元素数量
 //@receiver		n			*node					接受者node的指针
 //@param    	nil
 //@return    	nil
@@ -125,6 +144,7 @@ func (n *node) leftRotate() {
 		left:     n.left,
 		right:    n.right.left,
 	}
+	tmp.size = tmp.left.getSize() + tmp.right.getSize() + tmp.num
 	//原n节点右节点上移到n节点位置
 	n.left = tmp
 	n.value = n.right.value
@@ -155,8 +175,11 @@ func (n *node) insert(e *node, isMulti bool, cmp comparator.Comparator) (b bool)
 			//对左节点进行递归插入
 			b = n.left.insert(e, isMulti, cmp)
 		}
-		if n.priority > e.priority {
-			//对n节点进行右转
+		if b {
+			n.size++
+		}
+		if n.left.priority < n.priority {
+			//子节点优先级更小,对n节点进行右转
 			n.rightRotate()
 		}
 		return b
@@ -169,8 +192,11 @@ func (n *node) insert(e *node, isMulti bool, cmp comparator.Comparator) (b bool)
 			//对右节点进行递归插入
 			b = n.right.insert(e, isMulti, cmp)
 		}
-		if n.priority > e.priority {
-			//对n节点进行左转
+		if b {
+			n.size++
+		}
+		if n.right.priority < n.priority {
+			//子节点优先级更小,对n节点进行左转
 			n.leftRotate()
 		}
 		return b
@@ -178,6 +204,7 @@ func (n *node) insert(e *node, isMulti bool, cmp comparator.Comparator) (b bool)
 	if isMulti {
 		//允许重复
 		n.num++
+		n.size++
 		return true
 	}
 	//不允许重复,对值进行覆盖
@@ -213,11 +240,16 @@ func (n *node) delete(e interface{}, isMulti bool, cmp comparator.Comparator) (b
 			if n.right.left == nil && n.right.right == nil {
 				//右子树可直接删除
 				n.right = nil
+				n.size--
 				return true
 			}
 		}
 		//从右子树继续删除
-		return n.right.delete(e, isMulti, cmp)
+		b = n.right.delete(e, isMulti, cmp)
+		if b {
+			n.size--
+		}
+		return b
 	}
 	//n中承载元素大于e,从左子树继续删除
 	if cmp(n.value, e) > 0 {
@@ -230,21 +262,29 @@ func (n *node) delete(e interface{}, isMulti bool, cmp comparator.Comparator) (b
 			if n.left.left == nil && n.left.right == nil {
 				//左子树可直接删除
 				n.left = nil
+				n.size--
 				return true
 			}
 		}
 		//从左子树继续删除
-		return n.left.delete(e, isMulti, cmp)
+		b = n.left.delete(e, isMulti, cmp)
+		if b {
+			n.size--
+		}
+		return b
 	}
 	if isMulti && n.num > 1 {
 		//允许重复且数量超过1
 		n.num--
+		n.size--
 		return true
 	}
 	//删除该节点
 	tmp := n
 	//左右子节点都存在则选择优先级较小一个进行旋转
+	//待删除节点所经过的每一层子树元素数量都将减少
 	for tmp.left != nil && tmp.right != nil {
+		tmp.size -= tmp.num
 		if tmp.left.priority < tmp.right.priority {
 			tmp.rightRotate()
 			if tmp.right.left == nil && tmp.right.right == nil {
@@ -265,6 +305,7 @@ func (n *node) delete(e interface{}, isMulti bool, cmp comparator.Comparator) (b
 		//到左子树为nil时直接换为右子树即可
 		tmp.value = tmp.right.value
 		tmp.num = tmp.right.num
+		tmp.size = tmp.right.size
 		tmp.priority = tmp.right.priority
 		tmp.left = tmp.right.left
 		tmp.right = tmp.right.right
@@ -272,6 +313,7 @@ func (n *node) delete(e interface{}, isMulti bool, cmp comparator.Comparator) (b
 		//到右子树为nil时直接换为左子树即可
 		tmp.value = tmp.left.value
 		tmp.num = tmp.left.num
+		tmp.size = tmp.left.size
 		tmp.priority = tmp.left.priority
 		tmp.right = tmp.left.right
 		tmp.left = tmp.left.left
@@ -301,4 +343,236 @@ func (n *node) search(e interface{}, cmp comparator.Comparator) (num int) {
 		return n.right.search(e, cmp)
 	}
 	return n.num
-}
\ No newline at end of file
+}
+
+//@title    rank
+//@description
+//		以node树堆节点做接收者
+//		从n节点开始统计比元素e小的元素个数
+//		若isEqual为true则同时统计与e相等的元素
+//		通过子树元素数量,每层只需选择一侧继续统计
+//@receiver		n			*node					接受者node的指针
+//@param    	e			interface{}				待统计元素
+//@param    	isEqual		bool					是否统计相等的元素?
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	num        	int						满足条件的元素个数
+func (n *node) rank(e interface{}, isEqual bool, cmp comparator.Comparator) (num int) {
+	for n != nil {
+		c := cmp(e, n.value)
+		if c < 0 || (c == 0 && !isEqual) {
+			//n及其右子树均不满足条件,从左子树继续统计
+			n = n.left
+		} else {
+			//n及其左子树均满足条件,从右子树继续统计
+			num += n.left.getSize() + n.num
+			n = n.right
+		}
+	}
+	return num
+}
+
+//@title    kth
+//@description
+//		以node树堆节点做接收者
+//		从n节点开始寻找中缀序列中下标为k的元素,下标从0开始
+//		重复元素占据多个下标
+//		若k超出范围则返回nil
+//@receiver		n			*node					接受者node的指针
+//@param    	k			int						待寻找元素的下标
+//@return    	e        	interface{}				下标为k的元素
+func (n *node) kth(k int) (e interface{}) {
+	if k < 0 || k >= n.getSize() {
+		return nil
+	}
+	for n != nil {
+		l := n.left.getSize()
+		if k < l {
+			n = n.left
+		} else if k < l+n.num {
+			return n.value
+		} else {
+			k -= l + n.num
+			n = n.right
+		}
+	}
+	return nil
+}
+
+//@title    getMin
+//@description
+//		以node树堆节点做接收者
+//		返回以n为根的子树中的最小元素
+//		若节点不存在则返回nil
+//@receiver		n			*node					接受者node的指针
+//@param    	nil
+//@return    	e        	interface{}				子树中的最小元素
+func (n *node) getMin() (e interface{}) {
+	if n == nil {
+		return nil
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n.value
+}
+
+//@title    getMax
+//@description
+//		以node树堆节点做接收者
+//		返回以n为根的子树中的最大元素
+//		若节点不存在则返回nil
+//@receiver		n			*node					接受者node的指针
+//@param    	nil
+//@return    	e        	interface{}				子树中的最大元素
+func (n *node) getMax() (e interface{}) {
+	if n == nil {
+		return nil
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.value
+}
+
+//@title    split
+//@description
+//		以node树堆节点做接收者
+//		将以n为根的子树按元素e拆分为两棵子树
+//		左子树中的元素均小于e,右子树中的元素均不小于e
+//		拆分过程沿查找路径自上而下进行,不改变节点优先级,拆分后的两棵子树仍满足堆的性质
+//@receiver		n			*node					接受者node的指针
+//@param    	e			interface{}				拆分依据的元素
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	l        	*node					小于e的元素构成的子树
+//@return    	r        	*node					不小于e的元素构成的子树
+func (n *node) split(e interface{}, cmp comparator.Comparator) (l, r *node) {
+	if n == nil {
+		return nil, nil
+	}
+	if cmp(n.value, e) < 0 {
+		//n及其左子树均小于e,继续拆分右子树
+		n.right, r = n.right.split(e, cmp)
+		n.size = n.left.getSize() + n.right.getSize() + n.num
+		return n, r
+	}
+	//n及其右子树均不小于e,继续拆分左子树
+	l, n.left = n.left.split(e, cmp)
+	n.size = n.left.getSize() + n.right.getSize() + n.num
+	return l, n
+}
+
+//@title    merge
+//@description
+//		将两棵子树合并为一棵子树并返回其根节点
+//		要求l中的元素均小于r中的元素
+//		优先级较小的节点作为根节点,以保证合并后仍满足堆的性质
+//@receiver		nil
+//@param    	l			*node					元素较小的子树
+//@param    	r			*node					元素较大的子树
+//@return    	n        	*node					合并后的子树根节点
+func merge(l, r *node) (n *node) {
+	if l == nil {
+		return r
+	}
+	if r == nil {
+		return l
+	}
+	if l.priority < r.priority {
+		//l作为根节点,将其右子树与r合并
+		l.right = merge(l.right, r)
+		l.size = l.left.getSize() + l.right.getSize() + l.num
+		return l
+	}
+	//r作为根节点,将其左子树与l合并
+	r.left = merge(l, r.left)
+	r.size = r.left.getSize() + r.right.getSize() + r.num
+	return r
+}
+
+//@title    lowerBound
+//@description
+//		以node树堆节点做接收者
+//		从n节点开始寻找承载不小于元素e的最小元素的节点
+//		返回从n节点到该节点的路径,若不存在则返回空路径
+//@receiver		n			*node					接受者node的指针
+//@param    	e			interface{}				待查找元素
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	path        []cursor.Node				从n节点到目标节点的路径
+func (n *node) lowerBound(e interface{}, cmp comparator.Comparator) (path []cursor.Node) {
+	depth := 0
+	for n != nil {
+		path = append(path, n)
+		if cmp(n.value, e) >= 0 {
+			//n满足条件,继续从左子树寻找更小的满足条件的节点
+			depth = len(path)
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return path[:depth]
+}
+
+//@title    upperBound
+//@description
+//		以node树堆节点做接收者
+//		从n节点开始寻找承载大于元素e的最小元素的节点
+//		返回从n节点到该节点的路径,若不存在则返回空路径
+//@receiver		n			*node					接受者node的指针
+//@param    	e			interface{}				待查找元素
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@return    	path        []cursor.Node				从n节点到目标节点的路径
+func (n *node) upperBound(e interface{}, cmp comparator.Comparator) (path []cursor.Node) {
+	depth := 0
+	for n != nil {
+		path = append(path, n)
+		if cmp(n.value, e) > 0 {
+			//n满足条件,继续从左子树寻找更小的满足条件的节点
+			depth = len(path)
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return path[:depth]
+}
+
+//@title    rangeOrder
+//@description
+//		以node树堆节点做接收者
+//		以中缀序列将处于区间内的元素放入es中
+//		lo或hi为nil时表示该侧无界,isLoIn和isHiIn表示区间是否包含对应端点
+//		仅当子树中可能存在区间内的元素时才进入该子树,时间复杂度为O(h + k),h为树高
+//@receiver		n			*node					接受者node的指针
+//@param    	lo			interface{}				区间下界
+//@param    	hi			interface{}				区间上界
+//@param    	isLoIn		bool					是否包含下界?
+//@param    	isHiIn		bool					是否包含上界?
+//@param    	cmp			comparator.Comparator	判断大小的比较器
+//@param    	es			*[]interface{}			承载结果的切片指针
+//@return    	nil
+func (n *node) rangeOrder(lo, hi interface{}, isLoIn, isHiIn bool, cmp comparator.Comparator, es *[]interface{}) {
+	if n == nil {
+		return
+	}
+	cl, ch := 1, -1
+	if lo != nil {
+		cl = cmp(n.value, lo)
+	}
+	if hi != nil {
+		ch = cmp(n.value, hi)
+	}
+	if cl > 0 {
+		//n大于下界,左子树中可能存在区间内的元素
+		n.left.rangeOrder(lo, hi, isLoIn, isHiIn, cmp, es)
+	}
+	if (cl > 0 || (cl == 0 && isLoIn)) && (ch < 0 || (ch == 0 && isHiIn)) {
+		for i := int(0); i < n.num; i++ {
+			*es = append(*es, n.value)
+		}
+	}
+	if ch < 0 {
+		//n小于上界,右子树中可能存在区间内的元素
+		n.right.rangeOrder(lo, hi, isLoIn, isHiIn, cmp, es)
+	}
+}
@@ -12,6 +12,7 @@ package treap
 
 import (
 	"github.com/hlccd/goSTL/utils/comparator"
+	"github.com/hlccd/goSTL/utils/cursor"
 	"github.com/hlccd/goSTL/utils/iterator"
 	"math/rand"
 	"sync"
@@ -37,13 +38,21 @@ type treap struct {
 //存放了treap树堆可使用的函数
 //对应函数介绍见下方
 type treaper interface {
-	Iterator() (i *Iterator.Iterator) //返回包含该树堆的所有元素,重复则返回多个
-	Size() (num int)                  //返回该树堆中保存的元素个数
-	Clear()                           //清空该树堆
-	Empty() (b bool)                  //判断该树堆是否为空
-	Insert(e interface{})             //向树堆中插入元素e
-	Erase(e interface{})              //从树堆中删除元素e
-	Count(e interface{}) (num int)    //从树堆中寻找元素e并返回其个数
+	Iterator() (i *Iterator.Iterator)                                             //返回包含该树堆的所有元素,重复则返回多个
+	Size() (num int)                                                              //返回该树堆中保存的元素个数
+	Clear()                                                                       //清空该树堆
+	Empty() (b bool)                                                              //判断该树堆是否为空
+	Insert(e interface{})                                                         //向树堆中插入元素e
+	Erase(e interface{})                                                          //从树堆中删除元素e
+	Count(e interface{}) (num int)                                                //从树堆中寻找元素e并返回其个数
+	Rank(e interface{}) (num int)                                                 //返回树堆中比元素e小的元素个数
+	Select(k int) (e interface{})                                                 //返回树堆中序下标为k的元素,下标从0开始
+	Kth(k int, isBack bool) (e interface{})                                       //返回树堆中第k小或第k大的元素,k从1开始
+	CountRange(lo, hi interface{}) (num int)                                      //返回树堆中处于[lo,hi]内的元素个数
+	Split(e interface{}) (l, r *treap)                                            //将树堆拆分为小于e和不小于e的两个树堆
+	LowerBound(e interface{}) (c *Cursor)                                         //返回指向不小于e的最小元素的游标
+	UpperBound(e interface{}) (c *Cursor)                                         //返回指向大于e的最小元素的游标
+	RangeIterator(lo, hi interface{}, isLoIn, isHiIn bool) (i *Iterator.Iterator) //返回包含区间内所有元素的迭代器
 }
 
 //@title    New
@@ -239,4 +248,309 @@ func (t *treap) Count(e interface{}) (num int) {
 	t.mutex.Unlock()
 	//树堆存在,从根节点开始查找该元素
 	return num
-}
\ No newline at end of file
+}
+
+//@title    Rank
+//@description
+//		以treap树堆做接收者
+//		返回树堆中比元素e小的元素个数,即元素e在中缀序列中的首个下标
+//		重复元素计入多次
+//		借助节点中记录的子树元素数量,时间复杂度依概率为O(log n)
+//@receiver		t			*treap					接受者treap的指针
+//@param    	e			interface{}				待统计元素
+//@return    	num			int						比元素e小的元素个数
+func (t *treap) Rank(e interface{}) (num int) {
+	if t == nil {
+		return 0
+	}
+	if t.Empty() {
+		return 0
+	}
+	t.mutex.Lock()
+	num = t.root.rank(e, false, t.cmp)
+	t.mutex.Unlock()
+	return num
+}
+
+//@title    Select
+//@description
+//		以treap树堆做接收者
+//		返回树堆中缀序列中下标为k的元素,下标从0开始
+//		重复元素占据多个下标,与Rank互为逆运算
+//		若k超出范围则返回nil
+//@receiver		t			*treap					接受者treap的指针
+//@param    	k			int						待寻找元素的下标
+//@return    	e			interface{}				下标为k的元素
+func (t *treap) Select(k int) (e interface{}) {
+	if t == nil {
+		return nil
+	}
+	if t.Empty() {
+		return nil
+	}
+	t.mutex.Lock()
+	e = t.root.kth(k)
+	t.mutex.Unlock()
+	return e
+}
+
+//@title    Kth
+//@description
+//		以treap树堆做接收者
+//		返回树堆中第k小的元素,若isBack为true则返回第k大的元素
+//		k从1开始计数,重复元素计入多次
+//		若k超出范围则返回nil
+//@receiver		t			*treap					接受者treap的指针
+//@param    	k			int						待寻找元素的位次
+//@param    	isBack		bool					是否从大到小计数?
+//@return    	e			interface{}				第k小或第k大的元素
+func (t *treap) Kth(k int, isBack bool) (e interface{}) {
+	if t == nil {
+		return nil
+	}
+	if t.Empty() {
+		return nil
+	}
+	t.mutex.Lock()
+	if isBack {
+		e = t.root.kth(t.size - k)
+	} else {
+		e = t.root.kth(k - 1)
+	}
+	t.mutex.Unlock()
+	return e
+}
+
+//@title    CountRange
+//@description
+//		以treap树堆做接收者
+//		返回树堆中不小于lo且不大于hi的元素个数,重复元素计入多次
+//		通过两次统计之差得到,时间复杂度依概率为O(log n)
+//		若lo大于hi则返回0
+//@receiver		t			*treap					接受者treap的指针
+//@param    	lo			interface{}				区间下界
+//@param    	hi			interface{}				区间上界
+//@return    	num			int						处于[lo,hi]内的元素个数
+func (t *treap) CountRange(lo, hi interface{}) (num int) {
+	if t == nil {
+		return 0
+	}
+	if t.Empty() {
+		return 0
+	}
+	t.mutex.Lock()
+	if t.cmp(lo, hi) <= 0 {
+		num = t.root.rank(hi, true, t.cmp) - t.root.rank(lo, false, t.cmp)
+	}
+	t.mutex.Unlock()
+	return num
+}
+
+//@title    Split
+//@description
+//		以treap树堆做接收者
+//		将该树堆按元素e拆分为两个树堆并返回
+//		左树堆承载所有小于e的元素,右树堆承载所有不小于e的元素
+//		拆分后的树堆沿用原树堆的比较器和是否允许重复的属性
+//		拆分将直接移动原树堆的节点,拆分后原树堆为空
+//		时间复杂度依概率为O(log n)
+//@receiver		t			*treap					接受者treap的指针
+//@param    	e			interface{}				拆分依据的元素
+//@return    	l			*treap					小于e的元素构成的树堆
+//@return    	r			*treap					不小于e的元素构成的树堆
+func (t *treap) Split(e interface{}) (l, r *treap) {
+	if t == nil {
+		return nil, nil
+	}
+	t.mutex.Lock()
+	l, r = New(t.isMulti, t.cmp), New(t.isMulti, t.cmp)
+	if t.Empty() {
+		t.mutex.Unlock()
+		return l, r
+	}
+	l.root, r.root = t.root.split(e, t.cmp)
+	l.size, r.size = l.root.getSize(), r.root.getSize()
+	t.root = nil
+	t.size = 0
+	t.mutex.Unlock()
+	return l, r
+}
+
+//@title    giveBack
+//@description
+//		以treap树堆做接收者
+//		将已取出但未能使用的节点放回该树堆
+//		取出期间该树堆中可能已插入了新的元素,此时以并集的形式放回,新插入的元素优先
+//@receiver		t			*treap					接受者treap的指针
+//@param    	root		*node					待放回的根节点
+//@return    	nil
+func (t *treap) giveBack(root *node) {
+	t.mutex.Lock()
+	if t.root == nil {
+		t.root = root
+	} else if root != nil {
+		t.root = union(root, t.root, t.isMulti, t.cmp)
+	}
+	t.size = t.root.getSize()
+	t.mutex.Unlock()
+}
+
+//@title    Merge
+//@description
+//		将两个树堆合并为一个新的树堆并返回
+//		要求l中的元素均小于r中的元素,否则合并失败并返回nil
+//		合并后的树堆沿用l的比较器和是否允许重复的属性,若l没有比较器则沿用r的比较器
+//		合并将直接移动两个树堆的节点,合并成功后l和r均为空
+//		依次对l和r加锁取出根节点后立即解锁,不同时持有两把锁,避免并发调用Merge(l,r)和Merge(r,l)时相互等待
+//		合并失败时l的节点会被放回l
+//		时间复杂度依概率为O(log n)
+//@receiver		nil
+//@param    	l			*treap					元素较小的树堆
+//@param    	r			*treap					元素较大的树堆
+//@return    	t			*treap					合并后的树堆
+func Merge(l, r *treap) (t *treap) {
+	if l == nil || r == nil || l == r {
+		return nil
+	}
+	l.mutex.Lock()
+	cmp, isMulti := l.cmp, l.isMulti
+	lr, ls := l.root, l.size
+	l.root, l.size = nil, 0
+	l.mutex.Unlock()
+	r.mutex.Lock()
+	if cmp == nil {
+		cmp = r.cmp
+	}
+	if lr != nil && r.root != nil && cmp(lr.getMax(), r.root.getMin()) >= 0 {
+		//l中存在不小于r中元素的元素,无法直接合并
+		r.mutex.Unlock()
+		l.giveBack(lr)
+		return nil
+	}
+	rr, rs := r.root, r.size
+	r.root, r.size = nil, 0
+	r.mutex.Unlock()
+	t = New(isMulti, cmp)
+	t.root = merge(lr, rr)
+	t.size = ls + rs
+	return t
+}
+
+//@title    Join
+//@description
+//		以元素e为分界将两个树堆连接为一个新的树堆并返回
+//		要求l中的元素均小于e且r中的元素均大于e,否则连接失败并返回nil
+//		连接后的树堆沿用l的比较器和是否允许重复的属性,若均没有比较器则从默认比较器中寻找
+//		连接将直接移动两个树堆的节点,连接成功后l和r均为空
+//		加锁方式同Merge,连接失败时l的节点会被放回l
+//		时间复杂度依概率为O(log n)
+//@receiver		nil
+//@param    	l			*treap					元素较小的树堆
+//@param    	e			interface{}				分界元素
+//@param    	r			*treap					元素较大的树堆
+//@return    	t			*treap					连接后的树堆
+func Join(l *treap, e interface{}, r *treap) (t *treap) {
+	if l == nil || r == nil || l == r {
+		return nil
+	}
+	l.mutex.Lock()
+	cmp, isMulti := l.cmp, l.isMulti
+	lr, ls := l.root, l.size
+	l.root, l.size = nil, 0
+	l.mutex.Unlock()
+	r.mutex.Lock()
+	if cmp == nil {
+		cmp = r.cmp
+	}
+	if cmp == nil {
+		cmp = comparator.GetCmp(e)
+	}
+	if cmp == nil || (lr != nil && cmp(lr.getMax(), e) >= 0) || (r.root != nil && cmp(e, r.root.getMin()) >= 0) {
+		//比较器不存在或元素不满足连接条件
+		r.mutex.Unlock()
+		l.giveBack(lr)
+		return nil
+	}
+	rr, rs := r.root, r.size
+	r.root, r.size = nil, 0
+	r.mutex.Unlock()
+	t = New(isMulti, cmp)
+	t.root = merge(merge(lr, newNode(e, t.rand)), rr)
+	t.size = ls + rs + 1
+	return t
+}
+
+//@title    LowerBound
+//@description
+//		以treap树堆做接收者
+//		返回指向树堆中不小于元素e的最小元素的游标
+//		若存在重复元素则指向其中第一个
+//		若不存在则返回一个无效游标
+//		查找的时间复杂度为O(log n),创建游标后对树堆进行增删将使游标失效
+//@receiver		t			*treap					接受者treap的指针
+//@param    	e			interface{}				待查找元素
+//@return    	c			*Cursor					指向下界的游标
+func (t *treap) LowerBound(e interface{}) (c *Cursor) {
+	if t == nil {
+		return nil
+	}
+	t.mutex.Lock()
+	var path []cursor.Node
+	if t.root != nil && t.cmp != nil {
+		path = t.root.lowerBound(e, t.cmp)
+	}
+	c = cursor.New(&t.mutex, path)
+	t.mutex.Unlock()
+	return c
+}
+
+//@title    UpperBound
+//@description
+//		以treap树堆做接收者
+//		返回指向树堆中大于元素e的最小元素的游标
+//		若存在重复元素则指向其中第一个,LowerBound(lo)到UpperBound(hi)之前的元素即为[lo,hi]区间内的元素
+//		若不存在则返回一个无效游标
+//		查找的时间复杂度为O(log n),创建游标后对树堆进行增删将使游标失效
+//@receiver		t			*treap					接受者treap的指针
+//@param    	e			interface{}				待查找元素
+//@return    	c			*Cursor					指向上界的游标
+func (t *treap) UpperBound(e interface{}) (c *Cursor) {
+	if t == nil {
+		return nil
+	}
+	t.mutex.Lock()
+	var path []cursor.Node
+	if t.root != nil && t.cmp != nil {
+		path = t.root.upperBound(e, t.cmp)
+	}
+	c = cursor.New(&t.mutex, path)
+	t.mutex.Unlock()
+	return c
+}
+
+//@title    RangeIterator
+//@description
+//		以treap树堆做接收者
+//		将树堆中处于区间内的元素以中缀序列的形式放入迭代器中
+//		lo或hi为nil时表示该侧无界,isLoIn和isHiIn表示区间是否包含对应端点
+//		若允许重复存储则对于重复元素进行多次放入
+//		仅访问区间内的节点及其查找路径,时间复杂度为O(log n + k),迭代器可双向移动
+//@receiver		t			*treap					接受者treap的指针
+//@param    	lo			interface{}				区间下界
+//@param    	hi			interface{}				区间上界
+//@param    	isLoIn		bool					是否包含下界?
+//@param    	isHiIn		bool					是否包含上界?
+//@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
+func (t *treap) RangeIterator(lo, hi interface{}, isLoIn, isHiIn bool) (i *Iterator.Iterator) {
+	if t == nil {
+		return nil
+	}
+	t.mutex.Lock()
+	es := make([]interface{}, 0)
+	if t.root != nil && t.cmp != nil {
+		t.root.rangeOrder(lo, hi, isLoIn, isHiIn, t.cmp, &es)
+	}
+	i = Iterator.New(&es)
+	t.mutex.Unlock()
+	return i
+}
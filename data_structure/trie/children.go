@@ -0,0 +1,293 @@
+package trie
+
+//@Title		trie
+//@Description
+//		单词查找树节点的分叉
+//		分叉较少时以按标签升序排列的小数组存储,查找时进行二分
+//		分叉超过sparseLimit个且标签跨度不超过denseSpan时提升为以base为起点的稠密数组,查找时直接下标定位
+//		稠密数组中的分叉减少到sparseLimit/2以下或标签跨度过大时退回为有序小数组
+//		以此避免在字节和Unicode字符这样较大的字母表下为每个节点分配完整的分叉数组
+
+import (
+	"math"
+)
+
+//sparseLimit有序小数组中最多存放的分叉数量
+const sparseLimit = 8
+
+//denseSpan稠密数组允许覆盖的最大标签跨度
+const denseSpan = 256
+
+//children分叉结构体
+//稀疏时labels与sons一一对应且按标签升序排列
+//稠密时sons[i]为标签base+i对应的分叉,可能为nil
+type children struct {
+	labels []rune  //稀疏时各分叉的标签
+	sons   []*node //分叉
+	base   rune    //稠密时首个分叉的标签
+	dense  bool    //是否为稠密存储
+	num    int     //非nil的分叉数量
+}
+
+//@title    search
+//@description
+//		以children分叉做接收者
+//		在稀疏存储的标签中二分查找第一个不小于c的位置
+//@receiver		cs			*children				接受者children的指针
+//@param    	c			rune					标签
+//@return    	idx			int						第一个不小于c的位置
+func (cs *children) search(c rune) (idx int) {
+	l, r := 0, len(cs.labels)
+	for l < r {
+		m := (l + r) / 2
+		if cs.labels[m] < c {
+			l = m + 1
+		} else {
+			r = m
+		}
+	}
+	return l
+}
+
+//@title    get
+//@description
+//		以children分叉做接收者
+//		返回标签c对应的分叉,不存在时返回nil
+//@receiver		cs			*children				接受者children的指针
+//@param    	c			rune					标签
+//@return    	n			*node					对应的分叉
+func (cs *children) get(c rune) (n *node) {
+	if cs.dense {
+		if c < cs.base || int(c-cs.base) >= len(cs.sons) {
+			return nil
+		}
+		return cs.sons[c-cs.base]
+	}
+	if idx := cs.search(c); idx < len(cs.labels) && cs.labels[idx] == c {
+		return cs.sons[idx]
+	}
+	return nil
+}
+
+//@title    set
+//@description
+//		以children分叉做接收者
+//		将标签c对应的分叉设置为n,n不可为nil
+//		稀疏存储的分叉过多时尝试提升为稠密存储,稠密存储无法容纳c时退回为稀疏存储
+//@receiver		cs			*children				接受者children的指针
+//@param    	c			rune					标签
+//@param    	n			*node					分叉
+//@return    	nil
+func (cs *children) set(c rune, n *node) {
+	if cs.dense {
+		lo, hi := cs.base, cs.base+rune(len(cs.sons))-1
+		if c < lo {
+			lo = c
+		}
+		if c > hi {
+			hi = c
+		}
+		if int(hi-lo) >= denseSpan {
+			cs.toSparse()
+		} else {
+			if lo < cs.base || int(hi-lo) >= len(cs.sons) {
+				sons := make([]*node, hi-lo+1)
+				copy(sons[cs.base-lo:], cs.sons)
+				cs.sons, cs.base = sons, lo
+			}
+			if cs.sons[c-cs.base] == nil {
+				cs.num++
+			}
+			cs.sons[c-cs.base] = n
+			return
+		}
+	}
+	idx := cs.search(c)
+	if idx < len(cs.labels) && cs.labels[idx] == c {
+		cs.sons[idx] = n
+		return
+	}
+	cs.labels = append(cs.labels, 0)
+	cs.sons = append(cs.sons, nil)
+	copy(cs.labels[idx+1:], cs.labels[idx:])
+	copy(cs.sons[idx+1:], cs.sons[idx:])
+	cs.labels[idx], cs.sons[idx] = c, n
+	cs.num++
+	if cs.num > sparseLimit && int(cs.labels[cs.num-1]-cs.labels[0]) < denseSpan {
+		cs.toDense()
+	}
+}
+
+//@title    remove
+//@description
+//		以children分叉做接收者
+//		删除标签c对应的分叉
+//		稠密存储的分叉过少时退回为稀疏存储
+//@receiver		cs			*children				接受者children的指针
+//@param    	c			rune					标签
+//@return    	nil
+func (cs *children) remove(c rune) {
+	if cs.dense {
+		if c < cs.base || int(c-cs.base) >= len(cs.sons) || cs.sons[c-cs.base] == nil {
+			return
+		}
+		cs.sons[c-cs.base] = nil
+		cs.num--
+		if cs.num < sparseLimit/2 {
+			cs.toSparse()
+		}
+		return
+	}
+	idx := cs.search(c)
+	if idx == len(cs.labels) || cs.labels[idx] != c {
+		return
+	}
+	copy(cs.labels[idx:], cs.labels[idx+1:])
+	copy(cs.sons[idx:], cs.sons[idx+1:])
+	cs.labels = cs.labels[:len(cs.labels)-1]
+	cs.sons[len(cs.sons)-1] = nil
+	cs.sons = cs.sons[:len(cs.sons)-1]
+	cs.num--
+}
+
+//@title    toDense
+//@description
+//		以children分叉做接收者
+//		将稀疏存储转换为稠密存储
+//@receiver		cs			*children				接受者children的指针
+//@param    	nil
+//@return    	nil
+func (cs *children) toDense() {
+	base := cs.labels[0]
+	sons := make([]*node, cs.labels[len(cs.labels)-1]-base+1)
+	for i, c := range cs.labels {
+		sons[c-base] = cs.sons[i]
+	}
+	cs.labels, cs.sons, cs.base, cs.dense = nil, sons, base, true
+}
+
+//@title    toSparse
+//@description
+//		以children分叉做接收者
+//		将稠密存储转换为稀疏存储
+//@receiver		cs			*children				接受者children的指针
+//@param    	nil
+//@return    	nil
+func (cs *children) toSparse() {
+	labels, sons := make([]rune, 0, cs.num), make([]*node, 0, cs.num)
+	for i, n := range cs.sons {
+		if n != nil {
+			labels = append(labels, cs.base+rune(i))
+			sons = append(sons, n)
+		}
+	}
+	cs.labels, cs.sons, cs.base, cs.dense = labels, sons, 0, false
+}
+
+//@title    each
+//@description
+//		以children分叉做接收者
+//		按标签升序对每个分叉调用f,当f返回false时停止
+//@receiver		cs			*children				接受者children的指针
+//@param    	f			func(c rune, n *node) bool	对分叉调用的函数
+//@return    	b			bool					是否遍历了全部分叉?
+func (cs *children) each(f func(c rune, n *node) bool) (b bool) {
+	for i, n := range cs.sons {
+		if n == nil {
+			continue
+		}
+		c := cs.base + rune(i)
+		if !cs.dense {
+			c = cs.labels[i]
+		}
+		if !f(c, n) {
+			return false
+		}
+	}
+	return true
+}
+
+//@title    next
+//@description
+//		以children分叉做接收者
+//		返回标签大于c的分叉中标签最小的一个
+//		不存在时返回nil
+//@receiver		cs			*children				接受者children的指针
+//@param    	c			rune					标签
+//@return    	label		rune					找到的分叉的标签
+//@return    	n			*node					找到的分叉
+func (cs *children) next(c rune) (label rune, n *node) {
+	if cs.dense {
+		i := 0
+		if c >= cs.base {
+			i = int(c-cs.base) + 1
+		}
+		for ; i < len(cs.sons); i++ {
+			if cs.sons[i] != nil {
+				return cs.base + rune(i), cs.sons[i]
+			}
+		}
+		return 0, nil
+	}
+	if idx := cs.search(c + 1); idx < len(cs.labels) {
+		return cs.labels[idx], cs.sons[idx]
+	}
+	return 0, nil
+}
+
+//@title    first
+//@description
+//		以children分叉做接收者
+//		返回标签最小的分叉
+//		不存在时返回nil
+//@receiver		cs			*children				接受者children的指针
+//@param    	nil
+//@return    	label		rune					找到的分叉的标签
+//@return    	n			*node					找到的分叉
+func (cs *children) first() (label rune, n *node) {
+	return cs.next(-1)
+}
+
+//@title    prev
+//@description
+//		以children分叉做接收者
+//		返回标签小于c的分叉中标签最大的一个
+//		不存在时返回nil
+//@receiver		cs			*children				接受者children的指针
+//@param    	c			rune					标签
+//@return    	label		rune					找到的分叉的标签
+//@return    	n			*node					找到的分叉
+func (cs *children) prev(c rune) (label rune, n *node) {
+	if cs.dense {
+		i := len(cs.sons) - 1
+		if c < cs.base {
+			return 0, nil
+		}
+		if int(c-cs.base) <= i {
+			i = int(c-cs.base) - 1
+		}
+		for ; i >= 0; i-- {
+			if cs.sons[i] != nil {
+				return cs.base + rune(i), cs.sons[i]
+			}
+		}
+		return 0, nil
+	}
+	if idx := cs.search(c); idx > 0 {
+		return cs.labels[idx-1], cs.sons[idx-1]
+	}
+	return 0, nil
+}
+
+//@title    last
+//@description
+//		以children分叉做接收者
+//		返回标签最大的分叉
+//		不存在时返回nil
+//@receiver		cs			*children				接受者children的指针
+//@param    	nil
+//@return    	label		rune					找到的分叉的标签
+//@return    	n			*node					找到的分叉
+func (cs *children) last() (label rune, n *node) {
+	return cs.prev(math.MaxInt32)
+}
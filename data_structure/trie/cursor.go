@@ -0,0 +1,333 @@
+package trie
+
+//@Title		trie
+//@Description
+//		单词查找树的游标
+//		游标保存从前缀节点到当前节点的路径,按字典序在以该前缀开头的所有string之间双向移动
+//		游标在移动时才查找下一个string,不会预先生成全部结果,适合只需要前若干个结果的场景
+//		游标不持有string的副本,在创建游标后对单词查找树进行增删将使游标失效
+
+//Cursor游标结构体
+//该游标指向单词查找树中以某一前缀开头的某个string
+//当游标移出前缀的范围后即失效,可通过First、Last或Seek重新定位
+type Cursor struct {
+	trie  *trie   //游标所属的单词查找树
+	ks    []rune  //当前string的标签
+	begin int     //前缀的标签长度
+	stack []*node //从前缀节点到当前节点的路径,栈顶为当前节点
+}
+
+//Cursor游标接口
+//存放了Cursor游标可使用的函数
+//对应函数介绍见下方
+type cursorer interface {
+	Valid() (b bool)        //判断该游标是否指向有效的string
+	Key() (s string)        //返回该游标指向的string
+	Value() (e interface{}) //返回该游标指向的string所携带的元素
+	Next() (b bool)         //将该游标移动到字典序的下一个string
+	Pre() (b bool)          //将该游标移动到字典序的上一个string
+	First() (b bool)        //将该游标移动到范围内字典序最小的string
+	Last() (b bool)         //将该游标移动到范围内字典序最大的string
+	Seek(s string) (b bool) //将该游标移动到范围内第一个不小于s的string
+}
+
+//@title    newCursor
+//@description
+//		新建一个遍历以ks为前缀的所有string的游标并返回
+//		游标初始指向其中字典序最小的string,不存在时游标无效
+//@receiver		nil
+//@param    	t			*trie					游标所属的单词查找树
+//@param    	ks			[]rune					前缀的标签
+//@return    	c			*Cursor					新建的游标指针
+func newCursor(t *trie, ks []rune) (c *Cursor) {
+	c = &Cursor{
+		trie:  t,
+		ks:    ks,
+		begin: len(ks),
+		stack: make([]*node, 0),
+	}
+	if c.reset() {
+		c.down()
+	}
+	return c
+}
+
+//@title    reset
+//@description
+//		以Cursor游标做接收者
+//		将游标置于前缀节点,范围内不存在string时游标失效
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					游标有效吗?
+func (c *Cursor) reset() (b bool) {
+	c.ks, c.stack = c.ks[:c.begin], c.stack[:0]
+	if n := c.trie.root.locate(c.ks); n != nil && n.num > 0 {
+		c.stack = append(c.stack, n)
+		return true
+	}
+	return false
+}
+
+//@title    down
+//@description
+//		以Cursor游标做接收者
+//		从栈顶节点开始沿标签最小的分叉向下,直到抵达某个string的终点
+//		栈顶节点的子树中必须存在string
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	nil
+func (c *Cursor) down() {
+	for n := c.stack[len(c.stack)-1]; !n.isEnd; {
+		var label rune
+		label, n = n.son.first()
+		c.ks = append(c.ks, label)
+		c.stack = append(c.stack, n)
+	}
+}
+
+//@title    bottom
+//@description
+//		以Cursor游标做接收者
+//		从栈顶节点开始沿标签最大的分叉一直向下,抵达的节点即子树中字典序最大的string的终点
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	nil
+func (c *Cursor) bottom() {
+	for {
+		label, n := c.stack[len(c.stack)-1].son.last()
+		if n == nil {
+			return
+		}
+		c.ks = append(c.ks, label)
+		c.stack = append(c.stack, n)
+	}
+}
+
+//@title    up
+//@description
+//		以Cursor游标做接收者
+//		跳过栈顶节点的子树,移动到其后字典序最小的string
+//		向上回溯,找到首个存在更大标签的分叉的祖先节点,并进入该分叉
+//		回溯到前缀节点仍未找到时游标失效并返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					移动后游标仍有效吗?
+func (c *Cursor) up() (b bool) {
+	for len(c.stack) > 1 {
+		last := c.ks[len(c.ks)-1]
+		c.ks = c.ks[:len(c.ks)-1]
+		c.stack = c.stack[:len(c.stack)-1]
+		if label, n := c.stack[len(c.stack)-1].son.next(last); n != nil {
+			c.ks = append(c.ks, label)
+			c.stack = append(c.stack, n)
+			c.down()
+			return true
+		}
+	}
+	c.ks, c.stack = c.ks[:c.begin], c.stack[:0]
+	return false
+}
+
+//@title    Valid
+//@description
+//		以Cursor游标做接收者
+//		判断该游标是否指向有效的string
+//		游标不存在或已移出范围时返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					该游标有效吗?
+func (c *Cursor) Valid() (b bool) {
+	if c == nil {
+		return false
+	}
+	return len(c.stack) > 0
+}
+
+//@title    Key
+//@description
+//		以Cursor游标做接收者
+//		返回该游标当前指向的string
+//		若游标无效则返回空串
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	s			string					游标指向的string
+func (c *Cursor) Key() (s string) {
+	if !c.Valid() {
+		return ""
+	}
+	return c.trie.decode(c.ks)
+}
+
+//@title    Value
+//@description
+//		以Cursor游标做接收者
+//		返回该游标当前指向的string所携带的元素
+//		若游标无效则返回nil
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	e			interface{}				游标指向的元素
+func (c *Cursor) Value() (e interface{}) {
+	if !c.Valid() {
+		return nil
+	}
+	return c.stack[len(c.stack)-1].value
+}
+
+//@title    Next
+//@description
+//		以Cursor游标做接收者
+//		将该游标移动到字典序的下一个string
+//		当前节点存在分叉时下一个string在标签最小的分叉中
+//		否则跳过当前节点的子树,回溯到前缀节点仍未找到时游标失效并返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					移动后游标仍有效吗?
+func (c *Cursor) Next() (b bool) {
+	if !c.Valid() {
+		return false
+	}
+	c.trie.mutex.Lock()
+	if label, n := c.stack[len(c.stack)-1].son.first(); n != nil {
+		c.ks = append(c.ks, label)
+		c.stack = append(c.stack, n)
+		c.down()
+		c.trie.mutex.Unlock()
+		return true
+	}
+	b = c.up()
+	c.trie.mutex.Unlock()
+	return b
+}
+
+//@title    Pre
+//@description
+//		以Cursor游标做接收者
+//		将该游标移动到字典序的上一个string
+//		向上回溯,若父节点存在更小标签的分叉,则上一个string为该分叉中字典序最大的string
+//		否则若父节点本身是string的终点则上一个string即为父节点
+//		回溯到前缀节点仍未找到时游标失效并返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					移动后游标仍有效吗?
+func (c *Cursor) Pre() (b bool) {
+	if !c.Valid() {
+		return false
+	}
+	c.trie.mutex.Lock()
+	for len(c.stack) > 1 {
+		last := c.ks[len(c.ks)-1]
+		c.ks = c.ks[:len(c.ks)-1]
+		c.stack = c.stack[:len(c.stack)-1]
+		n := c.stack[len(c.stack)-1]
+		if label, son := n.son.prev(last); son != nil {
+			c.ks = append(c.ks, label)
+			c.stack = append(c.stack, son)
+			c.bottom()
+			c.trie.mutex.Unlock()
+			return true
+		}
+		if n.isEnd {
+			c.trie.mutex.Unlock()
+			return true
+		}
+	}
+	c.ks, c.stack = c.ks[:c.begin], c.stack[:0]
+	c.trie.mutex.Unlock()
+	return false
+}
+
+//@title    First
+//@description
+//		以Cursor游标做接收者
+//		将该游标移动到范围内字典序最小的string
+//		范围内不存在string时游标失效并返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					移动后游标有效吗?
+func (c *Cursor) First() (b bool) {
+	if c == nil || c.trie == nil {
+		return false
+	}
+	c.trie.mutex.Lock()
+	if b = c.reset(); b {
+		c.down()
+	}
+	c.trie.mutex.Unlock()
+	return b
+}
+
+//@title    Last
+//@description
+//		以Cursor游标做接收者
+//		将该游标移动到范围内字典序最大的string
+//		范围内不存在string时游标失效并返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					移动后游标有效吗?
+func (c *Cursor) Last() (b bool) {
+	if c == nil || c.trie == nil {
+		return false
+	}
+	c.trie.mutex.Lock()
+	if b = c.reset(); b {
+		c.bottom()
+	}
+	c.trie.mutex.Unlock()
+	return b
+}
+
+//@title    Seek
+//@description
+//		以Cursor游标做接收者
+//		将该游标移动到范围内第一个不小于s的string
+//		s小于范围内所有string时移动到第一个string,s大于范围内所有string时游标失效
+//		从前缀节点开始沿s的标签向下,某一标签不存在时进入标签更大的第一个分叉,不存在更大的分叉时跳过当前子树
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	s			string					待定位的string
+//@return    	b			bool					移动后游标有效吗?
+func (c *Cursor) Seek(s string) (b bool) {
+	if c == nil || c.trie == nil {
+		return false
+	}
+	ks, ok := c.trie.encode(s)
+	c.trie.mutex.Lock()
+	if !c.reset() || !ok {
+		c.ks, c.stack = c.ks[:c.begin], c.stack[:0]
+		c.trie.mutex.Unlock()
+		return false
+	}
+	//与前缀进行比较
+	for i := 0; i < c.begin; i++ {
+		if i == len(ks) || ks[i] < c.ks[i] {
+			c.down()
+			c.trie.mutex.Unlock()
+			return true
+		}
+		if ks[i] > c.ks[i] {
+			c.ks, c.stack = c.ks[:c.begin], c.stack[:0]
+			c.trie.mutex.Unlock()
+			return false
+		}
+	}
+	for i := c.begin; i < len(ks); i++ {
+		n := c.stack[len(c.stack)-1]
+		if son := n.son.get(ks[i]); son != nil {
+			c.ks = append(c.ks, ks[i])
+			c.stack = append(c.stack, son)
+			continue
+		}
+		if label, son := n.son.next(ks[i]); son != nil {
+			c.ks = append(c.ks, label)
+			c.stack = append(c.stack, son)
+			c.down()
+			c.trie.mutex.Unlock()
+			return true
+		}
+		b = c.up()
+		c.trie.mutex.Unlock()
+		return b
+	}
+	c.down()
+	c.trie.mutex.Unlock()
+	return true
+}
@@ -0,0 +1,137 @@
+package trie
+
+//@Title		trie
+//@Description
+//		单词查找树的模糊查找
+//		查找与给定string的编辑距离不超过上限的所有string
+//		编辑距离可以是Levenshtein距离(插入、删除、替换),或在其基础上允许交换相邻两个标签的Damerau距离
+//		沿单词查找树向下时,每个节点维护其前缀与查询串各前缀之间编辑距离的一行动态规划
+//		子节点的一行只依赖父节点的一行(Damerau距离还需祖父节点的一行),共享前缀的string只需计算一次
+//		当一行中的最小值已超过上限时,其子树中不可能存在满足条件的string,直接剪枝
+
+import (
+	"github.com/hlccd/goSTL/utils/comparator"
+)
+
+//FuzzyResult模糊查找结果结构体
+//包含找到的string、其携带的元素、权重以及与查询串的编辑距离
+type FuzzyResult struct {
+	Key      string      //找到的string
+	Value    interface{} //携带的元素
+	Weight   float64     //权重
+	Distance int         //与查询串的编辑距离
+}
+
+//@title    fuzzy
+//@description
+//		以node单词查找树节点做接收者
+//		对n的每个分叉计算其动态规划行并继续向下查找
+//		row为n的前缀ks与查询串qs各前缀的编辑距离,prev为n的父节点的一行,label为到达n的标签
+//		以子节点结尾的string若与查询串的编辑距离不超过上限则调用visit
+//@receiver		n			*node					接受者node的指针
+//@param    	ks			[]rune					到达该节点的标签
+//@param    	qs			[]rune					查询串的标签
+//@param    	prev		[]int					父节点的动态规划行,不存在时为nil
+//@param    	row			[]int					该节点的动态规划行
+//@param    	label		rune					到达该节点的标签
+//@param    	max			int						编辑距离上限
+//@param    	isDamerau	bool					是否允许交换相邻标签?
+//@param    	visit		func(n *node, ks []rune, d int)	对满足条件的string调用的函数
+//@return    	nil
+func (n *node) fuzzy(ks, qs []rune, prev, row []int, label rune, max int, isDamerau bool, visit func(n *node, ks []rune, d int)) {
+	n.son.each(func(c rune, son *node) bool {
+		cur := make([]int, len(qs)+1)
+		cur[0] = row[0] + 1
+		low := cur[0]
+		for j := 1; j <= len(qs); j++ {
+			cost := 1
+			if qs[j-1] == c {
+				cost = 0
+			}
+			cur[j] = row[j-1] + cost
+			if row[j]+1 < cur[j] {
+				cur[j] = row[j] + 1
+			}
+			if cur[j-1]+1 < cur[j] {
+				cur[j] = cur[j-1] + 1
+			}
+			if isDamerau && prev != nil && j > 1 && qs[j-1] == label && qs[j-2] == c && prev[j-2]+1 < cur[j] {
+				//交换相邻的两个标签
+				cur[j] = prev[j-2] + 1
+			}
+			if cur[j] < low {
+				low = cur[j]
+			}
+		}
+		if low > max {
+			return true
+		}
+		sks := append(ks, c)
+		if son.isEnd && cur[len(qs)] <= max {
+			visit(son, sks, cur[len(qs)])
+		}
+		son.fuzzy(sks, qs, row, cur, c, max, isDamerau, visit)
+		return true
+	})
+}
+
+//@title    FuzzySearch
+//@description
+//		以trie单词查找树做接收者
+//		返回trie中与s的编辑距离不超过maxDistance的string
+//		isDamerau为true时使用允许交换相邻标签的Damerau距离,否则使用Levenshtein距离
+//		编辑距离以标签计算,即按字节索引时以字节为单位,按字符索引时以Unicode字符为单位
+//		结果按编辑距离升序排列,距离相同时按权重降序排列,再相同时按字典序排列
+//		limit为正数时最多返回limit个结果
+//@receiver		t			*trie					接受者trie的指针
+//@param    	s			string					查询串
+//@param    	maxDistance	int						编辑距离上限
+//@param    	limit		int						结果数量上限,不为正数时不限制
+//@param    	isDamerau	bool					是否允许交换相邻标签?
+//@return    	rs			[]FuzzyResult			查找结果
+func (t *trie) FuzzySearch(s string, maxDistance, limit int, isDamerau bool) (rs []FuzzyResult) {
+	rs = make([]FuzzyResult, 0)
+	if t == nil || t.root == nil || maxDistance < 0 {
+		return rs
+	}
+	qs, ok := t.encode(s)
+	if !ok {
+		return rs
+	}
+	t.mutex.Lock()
+	es := make([]interface{}, 0)
+	//根节点对应空前缀,与查询串前缀的距离即为其长度
+	row := make([]int, len(qs)+1)
+	for j := range row {
+		row[j] = j
+	}
+	t.root.fuzzy(make([]rune, 0), qs, nil, row, 0, maxDistance, isDamerau, func(n *node, ks []rune, d int) {
+		es = append(es, FuzzyResult{Key: t.decode(ks), Value: n.value, Weight: n.weight, Distance: d})
+	})
+	t.mutex.Unlock()
+	comparator.Sort(&es, func(a, b interface{}) int {
+		x, y := a.(FuzzyResult), b.(FuzzyResult)
+		if x.Distance != y.Distance {
+			return x.Distance - y.Distance
+		}
+		if x.Weight != y.Weight {
+			if x.Weight > y.Weight {
+				return -1
+			}
+			return 1
+		}
+		if x.Key < y.Key {
+			return -1
+		} else if x.Key > y.Key {
+			return 1
+		}
+		return 0
+	})
+	if limit > 0 && len(es) > limit {
+		es = es[:limit]
+	}
+	for _, e := range es {
+		rs = append(rs, e.(FuzzyResult))
+	}
+	return rs
+}
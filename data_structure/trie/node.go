@@ -6,16 +6,21 @@ package trie
 //		可通过节点的分叉对string进行查找
 //		增添string时候只需要增删结点即可
 //		当string到终点时存储元素
+//		分叉以标签区分,按字节索引时标签为单个字节,按字符索引时标签为单个Unicode字符
+//		每个string可以带有一个权重,节点中缓存以其为根的子树中string的最大权重,用于快速找到权重最高的补全
 
 //node树节点结构体
 //该节点是trie的树节点
 //结点存储到此时的string的前缀数量
 //以son为分叉存储下属的string
-//该节点同时存储其元素
+//该节点同时存储其元素及其权重
 type node struct {
-	num   int         //以当前结点为前缀的string的数量
-	son   [64]*node   //分叉
-	value interface{} //当前结点承载的元素
+	num       int         //以当前结点为前缀的string的数量
+	son       children    //分叉
+	isEnd     bool        //是否有string以当前结点为终点
+	value     interface{} //当前结点承载的元素
+	weight    float64     //以当前结点为终点的string的权重
+	maxWeight float64     //以当前结点为根的子树中string的最大权重
 }
 
 //@title    newNode
@@ -32,97 +37,80 @@ func newNode(e interface{}) (n *node) {
 	}
 }
 
+//@title    update
+//@description
+//		以node单词查找树节点做接收者
+//		根据自身和各分叉重新计算子树中string的最大权重
+//		子树中不存在string时最大权重无意义
+//@receiver		n			*node					接受者node的指针
+//@param    	nil
+//@return    	nil
+func (n *node) update() {
+	has := n.isEnd
+	n.maxWeight = n.weight
+	n.son.each(func(c rune, son *node) bool {
+		if !has || son.maxWeight > n.maxWeight {
+			n.maxWeight, has = son.maxWeight, true
+		}
+		return true
+	})
+}
+
 //@title    inOrder
 //@description
 //		以node单词查找树节点做接收者
-//		遍历其分叉以找到其存储的所有string
+//		按标签升序遍历其分叉以找到其存储的所有string
 //@receiver		n			*node					接受者node的指针
-//@param    	s			string					到该结点时的前缀string
-//@return    	es        	[]interface{}			以该前缀s为前缀的所有string的集合
-func (n *node) inOrder(s string) (es []interface{}) {
+//@param    	ks			[]rune					到该结点时的前缀标签
+//@param    	decode		func(ks []rune) string	将标签还原为string的函数
+//@return    	es        	[]interface{}			以该前缀为前缀的所有string的集合
+func (n *node) inOrder(ks []rune, decode func(ks []rune) string) (es []interface{}) {
 	if n == nil {
 		return es
 	}
-	if n.value != nil {
-		es = append(es, s)
-	}
-	for i, p := 0, 0; i < 62 && p < n.num; i++ {
-		if n.son[i] != nil {
-			if i < 26 {
-				es = append(es, n.son[i].inOrder(s+string(i+'a'))...)
-			} else if i < 52 {
-				es = append(es, n.son[i].inOrder(s+string(i-26+'A'))...)
-			} else {
-				es = append(es, n.son[i].inOrder(s+string(i-52+'0'))...)
-			}
-			p++
-		}
+	if n.isEnd {
+		es = append(es, decode(ks))
 	}
+	n.son.each(func(c rune, son *node) bool {
+		es = append(es, son.inOrder(append(ks, c), decode)...)
+		return true
+	})
 	return es
 }
 
-//@title    getIdx
-//@description
-//		传入一个byte并根据其值返回其映射到分叉的值
-//		当不属于'a'~'z','A'~'Z','0'~'9','+','/'时返回-1
-//@receiver		nil
-//@param    	c			byte					待映射的ASCII码
-//@return    	idx        	int						以c映射出的分叉下标
-func getIdx(c byte) (idx int) {
-	if c >= 'a' && c <= 'z' {
-		idx = int(c - 'a')
-	} else if c >= 'A' && c <= 'Z' {
-		idx = int(c-'A') + 26
-	} else if c >= '0' && c <= '9' {
-		idx = int(c-'0') + 52
-	} else if c == '+' {
-		idx = 62
-	} else if c == '/' {
-		idx = 63
-	} else {
-		idx = -1
-	}
-	return idx
-}
-
 //@title    insert
 //@description
 //		以node单词查找树节点做接收者
-//		从n节点中继续插入以s为索引的元素e,且当前抵达的string位置为p
-//		当到达s终点时进行插入,如果此时node承载了元素则插入失败,否则成功
+//		从n节点中继续插入以ks为索引的元素e,且当前抵达的标签位置为p
+//		当到达ks终点时进行插入,如果此时node已是某个string的终点则插入失败,否则成功
 //		当未到达终点时,根据当前抵达的位置去寻找其子结点继续遍历即可
 //@receiver		n			*node					接受者node的指针
-//@param    	s			string					待插入元素的索引s
+//@param    	ks			[]rune					待插入元素的索引标签
 //@param    	p			int						索引当前抵达的位置
 //@param    	e			interface{}				待插入元素e
 //@return    	b        	bool					是否插入成功?
-func (n *node) insert(s string, p int, e interface{}) (b bool) {
-	if p == len(s) {
-		if n.value != nil {
+func (n *node) insert(ks []rune, p int, e interface{}) (b bool) {
+	if p == len(ks) {
+		if n.isEnd {
 			return false
 		}
+		n.isEnd = true
 		n.value = e
+		n.weight = 0
 		n.num++
+		n.update()
 		return true
 	}
-	idx := getIdx(s[p])
-	if idx == -1 {
-		return false
-	}
-	ok := true
-	if n.son[idx] == nil {
-		//判断该子结点是否存在
-		n.son[idx] = newNode(nil)
-		ok = false
+	son := n.son.get(ks[p])
+	if son == nil {
+		//该子结点不存在时新建,由于终点必然不存在,插入一定成功
+		son = newNode(nil)
+		n.son.set(ks[p], son)
 	}
-	b = n.son[idx].insert(s, p+1, e)
+	b = son.insert(ks, p+1, e)
 	if b {
 		n.num++
-	} else {
-		if !ok {
-			//插入失败且该子节点为新建结点则需要删除该子结点
-			n.son[idx] = nil
-		}
+		n.update()
 	}
 	return b
 }
@@ -130,35 +118,36 @@ func (n *node) insert(s string, p int, e interface{}) (b bool) {
 //@title    erase
 //@description
 //		以node单词查找树节点做接收者
-//		从n节点中继续删除以s为索引的元素e,且当前抵达的string位置为p
-//		当到达s终点时进行删除,如果此时node未承载元素则删除失败,否则成功
+//		从n节点中继续删除以ks为索引的元素e,且当前抵达的标签位置为p
+//		当到达ks终点时进行删除,如果此时node不是某个string的终点则删除失败,否则成功
 //		当未到达终点时,根据当前抵达的位置去寻找其子结点继续遍历即可,若其分叉为nil则直接失败
 //@receiver		n			*node					接受者node的指针
-//@param    	s			string					待删除元素的索引s
+//@param    	ks			[]rune					待删除元素的索引标签
 //@param    	p			int						索引当前抵达的位置
 //@return    	b        	bool					是否删除成功?
-func (n *node) erase(s string, p int) (b bool) {
-	if p == len(s) {
-		if n.value != nil {
+func (n *node) erase(ks []rune, p int) (b bool) {
+	if p == len(ks) {
+		if n.isEnd {
+			n.isEnd = false
 			n.value = nil
+			n.weight = 0
 			n.num--
+			n.update()
 			return true
 		}
 		return false
 	}
-	idx := getIdx(s[p])
-	if idx == -1 {
+	son := n.son.get(ks[p])
+	if son == nil {
 		return false
 	}
-	if n.son[idx] == nil {
-		return false
-	}
-	b = n.son[idx].erase(s, p+1)
+	b = son.erase(ks, p+1)
 	if b {
 		n.num--
-		if n.son[idx].num == 0 {
-			n.son[idx] = nil
+		if son.num == 0 {
+			n.son.remove(ks[p])
 		}
+		n.update()
 	}
 	return b
 }
@@ -166,30 +155,28 @@ func (n *node) erase(s string, p int) (b bool) {
 //@title    delete
 //@description
 //		以node单词查找树节点做接收者
-//		从n节点中继续删除以s为索引的元素e,且当前抵达的string位置为p
-//		当到达s终点时进行删除,删除所有后续元素,并返回其后续元素的数量
+//		从n节点中继续删除以ks为前缀的元素,且当前抵达的标签位置为p
+//		当到达ks终点时进行删除,删除所有后续元素,并返回其后续元素的数量
 //		当未到达终点时,根据当前抵达的位置去寻找其子结点继续遍历即可,若其分叉为nil则直接返回0
 //@receiver		n			*node					接受者node的指针
-//@param    	s			string					待删除元素的索引s
+//@param    	ks			[]rune					待删除元素的前缀标签
 //@param    	p			int						索引当前抵达的位置
 //@return    	num        	int						被删除元素的数量
-func (n *node) delete(s string, p int) (num int) {
-	if p == len(s) {
+func (n *node) delete(ks []rune, p int) (num int) {
+	if p == len(ks) {
 		return n.num
 	}
-	idx := getIdx(s[p])
-	if idx == -1 {
-		return 0
-	}
-	if n.son[idx] == nil {
+	son := n.son.get(ks[p])
+	if son == nil {
 		return 0
 	}
-	num = n.son[idx].delete(s, p+1)
+	num = son.delete(ks, p+1)
 	if num > 0 {
 		n.num -= num
-		if n.son[idx].num <= 0 {
-			n.son[idx] = nil
+		if son.num <= 0 || p+1 == len(ks) {
+			n.son.remove(ks[p])
 		}
+		n.update()
 	}
 	return num
 }
@@ -197,47 +184,90 @@ func (n *node) delete(s string, p int) (num int) {
 //@title    count
 //@description
 //		以node单词查找树节点做接收者
-//		从n节点中继续查找以s为前缀索引的元素e,且当前抵达的string位置为p
-//		当到达s终点时返回其值即可
+//		从n节点中继续查找以ks为前缀索引的元素e,且当前抵达的标签位置为p
+//		当到达ks终点时返回其值即可
 //		当未到达终点时,根据当前抵达的位置去寻找其子结点继续遍历即可,当其分叉为nil则直接返回0
 //@receiver		n			*node					接受者node的指针
-//@param    	s			string					待查找元素的前缀索引
+//@param    	ks			[]rune					待查找元素的前缀标签
 //@param    	p			int						索引当前抵达的位置
-//@return    	num        	int						以该s为前缀的string的数量
-func (n *node) count(s string, p int) (num int) {
-	if p == len(s) {
+//@return    	num        	int						以该前缀的string的数量
+func (n *node) count(ks []rune, p int) (num int) {
+	if p == len(ks) {
 		return n.num
 	}
-	idx := getIdx(s[p])
-	if idx == -1 {
-		return 0
-	}
-	if n.son[idx] == nil {
+	son := n.son.get(ks[p])
+	if son == nil {
 		return 0
 	}
-	return n.son[idx].count(s, p+1)
+	return son.count(ks, p+1)
 }
 
 //@title    find
 //@description
 //		以node单词查找树节点做接收者
-//		从n节点中继续查找以s为前缀索引的元素e,且当前抵达的string位置为p
-//		当到达s终点时返回其承载的元素即可
+//		从n节点中继续查找以ks为索引的元素e,且当前抵达的标签位置为p
+//		当到达ks终点时返回其承载的元素即可
 //		当未到达终点时,根据当前抵达的位置去寻找其子结点继续遍历即可,当其分叉为nil则直接返回nil
 //@receiver		n			*node					接受者node的指针
-//@param    	s			string					待查找元素的前缀索引
+//@param    	ks			[]rune					待查找元素的索引标签
 //@param    	p			int						索引当前抵达的位置
 //@return    	e			interface{}				该索引所指向的元素e
-func (n *node) find(s string, p int) (e interface{}) {
-	if p == len(s) {
+func (n *node) find(ks []rune, p int) (e interface{}) {
+	if p == len(ks) {
 		return n.value
 	}
-	idx := getIdx(s[p])
-	if idx == -1 {
+	son := n.son.get(ks[p])
+	if son == nil {
 		return nil
 	}
-	if n.son[idx] == nil {
-		return nil
+	return son.find(ks, p+1)
+}
+
+//@title    setWeight
+//@description
+//		以node单词查找树节点做接收者
+//		从n节点中继续查找以ks为索引的string并将其权重设置为w,且当前抵达的标签位置为p
+//		设置成功后沿路径更新子树中的最大权重
+//		若不存在该string则设置失败
+//@receiver		n			*node					接受者node的指针
+//@param    	ks			[]rune					待设置权重的string的索引标签
+//@param    	p			int						索引当前抵达的位置
+//@param    	w			float64					权重
+//@return    	b        	bool					是否设置成功?
+func (n *node) setWeight(ks []rune, p int, w float64) (b bool) {
+	if p == len(ks) {
+		if !n.isEnd {
+			return false
+		}
+		n.weight = w
+		n.update()
+		return true
+	}
+	son := n.son.get(ks[p])
+	if son == nil {
+		return false
+	}
+	b = son.setWeight(ks, p+1, w)
+	if b {
+		n.update()
+	}
+	return b
+}
+
+//@title    locate
+//@description
+//		以node单词查找树节点做接收者
+//		从n节点开始沿ks逐层向下,返回ks所对应的节点
+//		若中途分叉为nil则返回nil
+//@receiver		n			*node					接受者node的指针
+//@param    	ks			[]rune					待查找的标签
+//@return    	m			*node					ks所对应的节点
+func (n *node) locate(ks []rune) (m *node) {
+	for _, c := range ks {
+		if n == nil {
+			return nil
+		}
+		n = n.son.get(c)
 	}
-	return n.son[idx].find(s, p+1)
+	return n
 }
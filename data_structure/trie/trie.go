@@ -3,61 +3,145 @@ package trie
 //@Title		trie
 //@Description
 //		单词查找树-Trie
-//		以多叉树的形式实现,本次实现中有64叉,即a~z,A~Z,0~9,'+','/'共64个,对应base64的字符
-//		当存储的string中出现其他字符则无法存储
+//		以多叉树的形式实现,可以按字节或按Unicode字符对string进行索引
+//		按字节索引时可以存储任意字节序列,按字符索引时string必须是合法的UTF-8编码
+//		分叉较少时以有序小数组存储,较多时提升为稠密数组,避免字母表较大时内存膨胀
 //		存储的string可以携带一个元素
 //		结点不允许覆盖,即插入值已经存在时会插入失败,需要先删除原值
+//		每个string带有一个权重,插入时为0,可用于按权重从高到低获取补全结果
 //		使用互斥锁实现并发控制
 
 import (
+	"github.com/hlccd/goSTL/data_structure/priority_queue"
 	"github.com/hlccd/goSTL/utils/iterator"
 	"sync"
+	"unicode/utf8"
 )
 
 //trie单词查找树结构体
 //该实例存储单词查找树的根节点
 //同时保存该树已经存储了多少个元素
 //整个树不允许重复插入,若出现重复插入则直接失败
+//isRune为true时按Unicode字符索引,否则按字节索引
 type trie struct {
-	root  *node      //根节点指针
-	size  int        //存放的元素数量
-	mutex sync.Mutex //并发控制锁
+	root   *node      //根节点指针
+	size   int        //存放的元素数量
+	isRune bool       //是否按Unicode字符索引
+	mutex  sync.Mutex //并发控制锁
 }
 
 //trie单词查找树容器接口
 //存放了trie单词查找树可使用的函数
 //对应函数介绍见下方
 type trieer interface {
-	Iterator() (i *Iterator.Iterator)        //返回包含该trie的所有string
-	Size() (num int)                         //返回该trie中保存的元素个数
-	Clear()                                  //清空该trie
-	Empty() (b bool)                         //判断该trie是否为空
-	Insert(s string, e interface{}) (b bool) //向trie中插入string并携带元素e
-	Erase(s string) (b bool)                 //从trie中删除以s为索引的元素e
-	Delete(s string) (num int)               //从trie中删除以s为前缀的所有元素
-	Count(s string) (num int)                //从trie中寻找以s为前缀的string单词数
-	Find(s string) (e interface{})           //从trie中寻找以s为索引的元素e
+	Iterator() (i *Iterator.Iterator)                                                //返回包含该trie的所有string
+	Size() (num int)                                                                 //返回该trie中保存的元素个数
+	Clear()                                                                          //清空该trie
+	Empty() (b bool)                                                                 //判断该trie是否为空
+	Insert(s string, e interface{}) (b bool)                                         //向trie中插入string并携带元素e
+	Erase(s string) (b bool)                                                         //从trie中删除以s为索引的元素e
+	Delete(s string) (num int)                                                       //从trie中删除以s为前缀的所有元素
+	Count(s string) (num int)                                                        //从trie中寻找以s为前缀的string单词数
+	Find(s string) (e interface{})                                                   //从trie中寻找以s为索引的元素e
+	SetWeight(s string, w float64) (b bool)                                          //将trie中s的权重设置为w
+	KeysWithPrefix(s string) (c *Cursor)                                             //返回按字典序遍历以s为前缀的所有string的游标
+	Seek(s string) (c *Cursor)                                                       //返回指向第一个不小于s的string的游标
+	LongestPrefixOf(s string) (p string, b bool)                                     //返回trie中是s的前缀的最长string
+	ShortestPrefixOf(s string) (p string, b bool)                                    //返回trie中是s的前缀的最短string
+	TopK(s string, k int) (ss []string)                                              //返回以s为前缀的权重最高的k个string
+	FuzzySearch(s string, maxDistance, limit int, isDamerau bool) (rs []FuzzyResult) //返回与s的编辑距离不超过maxDistance的string
+}
+
+//candidate补全候选结构体
+//在寻找权重最高的补全时使用
+//isKey为true时表示以n为终点的string本身,权重为weight
+//否则表示以n为根的子树,weight为子树中的最大权重
+type candidate struct {
+	n      *node   //节点
+	ks     []rune  //到达该节点的标签
+	isKey  bool    //是否为string本身
+	weight float64 //权重
 }
 
 //@title    New
 //@description
-//		新建一个trie单词查找树容器并返回
-//		初始根节点为nil
+//		新建一个按字节索引的trie单词查找树容器并返回
+//		可以存储任意字节序列
 //@receiver		nil
 //@param    	nil
 //@return    	t        	*trie						新建的trie指针
 func New() (t *trie) {
 	return &trie{
-		root:  newNode(nil),
-		size:  0,
-		mutex: sync.Mutex{},
+		root:   newNode(nil),
+		size:   0,
+		isRune: false,
+		mutex:  sync.Mutex{},
+	}
+}
+
+//@title    NewRune
+//@description
+//		新建一个按Unicode字符索引的trie单词查找树容器并返回
+//		存储的string必须是合法的UTF-8编码
+//		相比按字节索引,非ASCII字符只占用一层节点
+//@receiver		nil
+//@param    	nil
+//@return    	t        	*trie						新建的trie指针
+func NewRune() (t *trie) {
+	return &trie{
+		root:   newNode(nil),
+		size:   0,
+		isRune: true,
+		mutex:  sync.Mutex{},
+	}
+}
+
+//@title    encode
+//@description
+//		以trie单词查找树做接收者
+//		将string转换为逐层查找所用的标签
+//		按字节索引时每个字节为一个标签,按字符索引时每个Unicode字符为一个标签
+//		按字符索引而s不是合法的UTF-8编码时返回false
+//@receiver		t			*trie					接受者trie的指针
+//@param    	s			string					待转换的string
+//@return    	ks			[]rune					标签
+//@return    	b			bool					转换成功?
+func (t *trie) encode(s string) (ks []rune, b bool) {
+	if t.isRune {
+		if !utf8.ValidString(s) {
+			return nil, false
+		}
+		return []rune(s), true
+	}
+	ks = make([]rune, len(s))
+	for i := 0; i < len(s); i++ {
+		ks[i] = rune(s[i])
+	}
+	return ks, true
+}
+
+//@title    decode
+//@description
+//		以trie单词查找树做接收者
+//		将标签还原为string
+//@receiver		t			*trie					接受者trie的指针
+//@param    	ks			[]rune					标签
+//@return    	s			string					还原的string
+func (t *trie) decode(ks []rune) (s string) {
+	if t.isRune {
+		return string(ks)
+	}
+	bs := make([]byte, len(ks))
+	for i, c := range ks {
+		bs[i] = byte(c)
 	}
+	return string(bs)
 }
 
 //@title    Iterator
 //@description
 //		以trie单词查找树做接收者
-//		将该trie中所有存放的string放入迭代器中并返回
+//		将该trie中所有存放的string按字典序放入迭代器中并返回,迭代器可双向移动
 //@receiver		t			*trie					接受者trie的指针
 //@param    	nil
 //@return    	i        	*iterator.Iterator		新建的Iterator迭代器指针
@@ -67,7 +151,7 @@ func (t *trie) Iterator() (i *Iterator.Iterator) {
 	}
 	t.mutex.Lock()
 	//找到trie中存在的所有string
-	es := t.root.inOrder("")
+	es := t.root.inOrder(make([]rune, 0), t.decode)
 	i = Iterator.New(&es)
 	t.mutex.Unlock()
 	return i
@@ -137,7 +221,8 @@ func (t *trie) Insert(s string, e interface{}) (b bool) {
 	if t == nil {
 		return
 	}
-	if len(s) == 0 {
+	ks, ok := t.encode(s)
+	if !ok || len(ks) == 0 {
 		return false
 	}
 	t.mutex.Lock()
@@ -146,7 +231,7 @@ func (t *trie) Insert(s string, e interface{}) (b bool) {
 		t.root = newNode(nil)
 	}
 	//从根节点开始插入
-	b = t.root.insert(s, 0, e)
+	b = t.root.insert(ks, 0, e)
 	if b {
 		//插入成功,size+1
 		t.size++
@@ -169,7 +254,8 @@ func (t *trie) Erase(s string) (b bool) {
 	if t.Empty() {
 		return false
 	}
-	if len(s) == 0 {
+	ks, ok := t.encode(s)
+	if !ok || len(ks) == 0 {
 		//长度为0无法删除
 		return false
 	}
@@ -179,7 +265,7 @@ func (t *trie) Erase(s string) (b bool) {
 	}
 	t.mutex.Lock()
 	//从根节点开始删除
-	b = t.root.erase(s, 0)
+	b = t.root.erase(ks, 0)
 	if b {
 		//删除成功,size-1
 		t.size--
@@ -206,7 +292,8 @@ func (t *trie) Delete(s string) (num int) {
 	if t.Empty() {
 		return 0
 	}
-	if len(s) == 0 {
+	ks, ok := t.encode(s)
+	if !ok || len(ks) == 0 {
 		//长度为0无法删除
 		return 0
 	}
@@ -216,7 +303,7 @@ func (t *trie) Delete(s string) (num int) {
 	}
 	t.mutex.Lock()
 	//从根节点开始删除
-	num = t.root.delete(s, 0)
+	num = t.root.delete(ks, 0)
 	if num > 0 {
 		//删除成功
 		t.size -= num
@@ -248,9 +335,13 @@ func (t *trie) Count(s string) (num int) {
 	if t.root == nil {
 		return 0
 	}
+	ks, ok := t.encode(s)
+	if !ok {
+		return 0
+	}
 	t.mutex.Lock()
 	//统计所有以s为前缀的string的数量并返回
-	num = int(t.root.count(s, 0))
+	num = int(t.root.count(ks, 0))
 	t.mutex.Unlock()
 	return num
 }
@@ -273,9 +364,214 @@ func (t *trie) Find(s string) (e interface{}) {
 	if t.root == nil {
 		return nil
 	}
+	ks, ok := t.encode(s)
+	if !ok {
+		return nil
+	}
 	t.mutex.Lock()
 	//从根节点开始查找以s为索引的元素e
-	e = t.root.find(s, 0)
+	e = t.root.find(ks, 0)
 	t.mutex.Unlock()
 	return e
 }
+
+//@title    SetWeight
+//@description
+//		以trie单词查找树做接收者
+//		将trie中以s为索引的string的权重设置为w
+//		同时更新路径上各节点缓存的子树最大权重
+//		若不存在该string则设置失败
+//@receiver		t			*trie					接受者trie的指针
+//@param    	s			string					待设置权重的string
+//@param    	w			float64					权重
+//@return    	b			bool					设置成功?
+func (t *trie) SetWeight(s string, w float64) (b bool) {
+	if t == nil || t.root == nil {
+		return false
+	}
+	ks, ok := t.encode(s)
+	if !ok || len(ks) == 0 {
+		return false
+	}
+	t.mutex.Lock()
+	b = t.root.setWeight(ks, 0, w)
+	t.mutex.Unlock()
+	return b
+}
+
+//@title    KeysWithPrefix
+//@description
+//		以trie单词查找树做接收者
+//		返回按字典序遍历trie中以s为前缀的所有string的游标
+//		游标初始指向其中字典序最小的string,不存在时返回无效游标
+//		游标在移动时才查找下一个string,创建游标后对trie进行增删将使游标失效
+//@receiver		t			*trie					接受者trie的指针
+//@param    	s			string					前缀
+//@return    	c			*Cursor					新建的游标指针
+func (t *trie) KeysWithPrefix(s string) (c *Cursor) {
+	if t == nil {
+		return nil
+	}
+	ks, ok := t.encode(s)
+	if !ok {
+		return &Cursor{}
+	}
+	t.mutex.Lock()
+	c = newCursor(t, ks)
+	t.mutex.Unlock()
+	return c
+}
+
+//@title    Seek
+//@description
+//		以trie单词查找树做接收者
+//		返回可在trie中所有string之间双向移动的游标
+//		游标初始指向字典序第一个不小于s的string,以s为前缀的string紧随其后连续出现
+//		不存在时返回无效游标,可通过游标的First或Last重新定位
+//@receiver		t			*trie					接受者trie的指针
+//@param    	s			string					待定位的string
+//@return    	c			*Cursor					新建的游标指针
+func (t *trie) Seek(s string) (c *Cursor) {
+	if t == nil {
+		return nil
+	}
+	t.mutex.Lock()
+	c = newCursor(t, nil)
+	t.mutex.Unlock()
+	c.Seek(s)
+	return c
+}
+
+//@title    prefixOf
+//@description
+//		以trie单词查找树做接收者
+//		沿s从根节点向下,寻找trie中是s的前缀的string
+//		isShortest为true时返回遇到的第一个,否则返回遇到的最后一个
+//@receiver		t			*trie					接受者trie的指针
+//@param    	s			string					待查找的string
+//@param    	isShortest	bool					是否寻找最短的前缀?
+//@return    	p			string					找到的前缀
+//@return    	b			bool					找到了吗?
+func (t *trie) prefixOf(s string, isShortest bool) (p string, b bool) {
+	if t == nil || t.root == nil {
+		return "", false
+	}
+	ks, ok := t.encode(s)
+	if !ok {
+		return "", false
+	}
+	t.mutex.Lock()
+	length := -1
+	for i, n := 0, t.root; i < len(ks); i++ {
+		if n = n.son.get(ks[i]); n == nil {
+			break
+		}
+		if n.isEnd {
+			length = i + 1
+			if isShortest {
+				break
+			}
+		}
+	}
+	if length == -1 {
+		t.mutex.Unlock()
+		return "", false
+	}
+	p, b = t.decode(ks[:length]), true
+	t.mutex.Unlock()
+	return p, b
+}
+
+//@title    LongestPrefixOf
+//@description
+//		以trie单词查找树做接收者
+//		返回trie中是s的前缀的最长string,s本身也视为自身的前缀
+//		若不存在则返回false
+//@receiver		t			*trie					接受者trie的指针
+//@param    	s			string					待查找的string
+//@return    	p			string					最长的前缀
+//@return    	b			bool					找到了吗?
+func (t *trie) LongestPrefixOf(s string) (p string, b bool) {
+	return t.prefixOf(s, false)
+}
+
+//@title    ShortestPrefixOf
+//@description
+//		以trie单词查找树做接收者
+//		返回trie中是s的前缀的最短string,s本身也视为自身的前缀
+//		若不存在则返回false
+//@receiver		t			*trie					接受者trie的指针
+//@param    	s			string					待查找的string
+//@return    	p			string					最短的前缀
+//@return    	b			bool					找到了吗?
+func (t *trie) ShortestPrefixOf(s string) (p string, b bool) {
+	return t.prefixOf(s, true)
+}
+
+//@title    TopK
+//@description
+//		以trie单词查找树做接收者
+//		返回trie中以s为前缀的权重最高的k个string,按权重从高到低排列,权重相同时按字典序排列
+//		以优先队列进行最佳优先搜索,子树以其缓存的最大权重参与排序
+//		由于子树的最大权重不小于其中任一string的权重,出队的string必然是剩余string中权重最高的
+//		只会展开最大权重足够高的子树,无需遍历以s为前缀的全部string
+//@receiver		t			*trie					接受者trie的指针
+//@param    	s			string					前缀
+//@param    	k			int						需要的string数量
+//@return    	ss			[]string				权重最高的k个string
+func (t *trie) TopK(s string, k int) (ss []string) {
+	ss = make([]string, 0)
+	if t == nil || t.root == nil || k <= 0 {
+		return ss
+	}
+	ks, ok := t.encode(s)
+	if !ok {
+		return ss
+	}
+	t.mutex.Lock()
+	n := t.root.locate(ks)
+	if n == nil || n.num == 0 {
+		t.mutex.Unlock()
+		return ss
+	}
+	pq := priority_queue.New(func(a, b interface{}) int {
+		x, y := a.(candidate), b.(candidate)
+		if x.weight != y.weight {
+			if x.weight > y.weight {
+				return -1
+			}
+			return 1
+		}
+		//权重相同时按标签的字典序,前缀排在前面
+		for i := 0; i < len(x.ks) && i < len(y.ks); i++ {
+			if x.ks[i] != y.ks[i] {
+				if x.ks[i] < y.ks[i] {
+					return -1
+				}
+				return 1
+			}
+		}
+		return len(x.ks) - len(y.ks)
+	})
+	pq.Push(candidate{n: n, ks: ks, isKey: false, weight: n.maxWeight})
+	for !pq.Empty() && len(ss) < k {
+		c := pq.Top().(candidate)
+		pq.Pop()
+		if c.isKey {
+			ss = append(ss, t.decode(c.ks))
+			continue
+		}
+		if c.n.isEnd {
+			pq.Push(candidate{n: c.n, ks: c.ks, isKey: true, weight: c.n.weight})
+		}
+		c.n.son.each(func(label rune, son *node) bool {
+			sks := make([]rune, len(c.ks)+1)
+			copy(sks, c.ks)
+			sks[len(c.ks)] = label
+			pq.Push(candidate{n: son, ks: sks, isKey: false, weight: son.maxWeight})
+			return true
+		})
+	}
+	t.mutex.Unlock()
+	return ss
+}
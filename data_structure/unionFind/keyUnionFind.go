@@ -0,0 +1,291 @@
+package unionFind
+
+//@Title		unionFind
+//@Description
+//		以任意key为元素的并查集
+//		通过hashMap将每个key映射为一个从0开始的编号,再在编号上使用并查集
+//		key的hash方式与hashMap一致,若为基本数据类型可不用传入hash函数,否则需要传入自定义hash函数
+//		使用互斥锁实现并发控制
+
+import (
+	"github.com/hlccd/goSTL/algorithm"
+	"github.com/hlccd/goSTL/data_structure/hashMap"
+	"sync"
+)
+
+//indexer索引接口
+//即hashMap所提供的函数中本结构所需的部分
+type indexer interface {
+	Insert(key, value interface{}) (b bool)
+	Get(key interface{}) (value interface{})
+}
+
+//keyUnionFind并查集结构体
+//index保存key到编号的映射,keys保存编号到key的映射
+//uf为以编号为元素的并查集
+type KeyUnionFind struct {
+	index indexer            //key到编号的映射
+	keys  []interface{}      //编号到key的映射
+	hash  []algorithm.Hasher //创建时传入的hash函数集
+	uf    *UnionFind         //编号上的并查集
+	mutex sync.Mutex         //并发控制锁
+}
+
+//keyUnionFind并查集容器接口
+//存放了keyUnionFind并查集可使用的函数
+//对应函数介绍见下方
+type keyUnionFinder interface {
+	Size() (num int)                         //返回并查集中的元素个数
+	Count() (num int)                        //返回并查集中的集合个数
+	Clear()                                  //清空并查集
+	Empty() (b bool)                         //判断并查集是否为空
+	Insert(key interface{}) (b bool)         //增加一个单独成集合的元素key
+	Find(key interface{}) (root interface{}) //返回元素key所在集合的代表元素
+	Union(x, y interface{}) (b bool)         //合并元素x和元素y所在的集合
+	Connected(x, y interface{}) (b bool)     //判断元素x和元素y是否在同一集合中
+	SetSize(key interface{}) (num int)       //返回元素key所在集合的大小
+	Sets() (sets [][]interface{})            //返回所有集合
+}
+
+//@title    NewKey
+//@description
+//		新建一个keyUnionFind并查集容器并返回
+//		初始时不含有元素
+//		若有传入的hash函数,则将传入的第一个hash函数设为key的hash函数
+//@receiver		nil
+//@param    	hash		...algorithm.Hasher		key的hash函数集
+//@return    	kuf        	*KeyUnionFind			新建的keyUnionFind指针
+func NewKey(hash ...algorithm.Hasher) (kuf *KeyUnionFind) {
+	return &KeyUnionFind{
+		index: hashMap.New(hash...),
+		keys:  make([]interface{}, 0),
+		hash:  hash,
+		uf:    New(0),
+		mutex: sync.Mutex{},
+	}
+}
+
+//@title    id
+//@description
+//		以keyUnionFind并查集做接收者
+//		返回元素key的编号
+//		若key不存在且isInsert为真则为其分配新的编号,否则返回-1
+//@receiver		kuf			*KeyUnionFind			接受者keyUnionFind的指针
+//@param    	key			interface{}				元素
+//@param    	isInsert	bool					不存在时是否插入?
+//@return    	x			int						元素编号
+func (kuf *KeyUnionFind) id(key interface{}, isInsert bool) (x int) {
+	if v := kuf.index.Get(key); v != nil {
+		return v.(int)
+	}
+	if !isInsert || !kuf.index.Insert(key, len(kuf.keys)) {
+		return -1
+	}
+	kuf.keys = append(kuf.keys, key)
+	return kuf.uf.add()
+}
+
+//@title    Size
+//@description
+//		以keyUnionFind并查集做接收者
+//		返回该并查集中的元素个数
+//		如果容器为nil返回0
+//@receiver		kuf			*KeyUnionFind			接受者keyUnionFind的指针
+//@param    	nil
+//@return    	num        	int						元素个数
+func (kuf *KeyUnionFind) Size() (num int) {
+	if kuf == nil {
+		return 0
+	}
+	return len(kuf.keys)
+}
+
+//@title    Count
+//@description
+//		以keyUnionFind并查集做接收者
+//		返回该并查集中的集合个数
+//		如果容器为nil返回0
+//@receiver		kuf			*KeyUnionFind			接受者keyUnionFind的指针
+//@param    	nil
+//@return    	num        	int						集合个数
+func (kuf *KeyUnionFind) Count() (num int) {
+	if kuf == nil {
+		return 0
+	}
+	return kuf.uf.count
+}
+
+//@title    Clear
+//@description
+//		以keyUnionFind并查集做接收者
+//		将该并查集中的所有元素清空
+//@receiver		kuf			*KeyUnionFind			接受者keyUnionFind的指针
+//@param    	nil
+//@return    	nil
+func (kuf *KeyUnionFind) Clear() {
+	if kuf == nil {
+		return
+	}
+	kuf.mutex.Lock()
+	kuf.index = hashMap.New(kuf.hash...)
+	kuf.keys = make([]interface{}, 0)
+	kuf.uf = New(0)
+	kuf.mutex.Unlock()
+}
+
+//@title    Empty
+//@description
+//		以keyUnionFind并查集做接收者
+//		判断该并查集中是否含有元素
+//		如果容器不存在,返回true
+//@receiver		kuf			*KeyUnionFind			接受者keyUnionFind的指针
+//@param    	nil
+//@return    	b			bool					该容器是空的吗?
+func (kuf *KeyUnionFind) Empty() (b bool) {
+	if kuf == nil {
+		return true
+	}
+	return len(kuf.keys) == 0
+}
+
+//@title    Insert
+//@description
+//		以keyUnionFind并查集做接收者
+//		增加一个单独成集合的元素key
+//		若key已存在或无法对key进行hash则返回false
+//@receiver		kuf			*KeyUnionFind			接受者keyUnionFind的指针
+//@param    	key			interface{}				待插入的元素
+//@return    	b			bool					插入成功?
+func (kuf *KeyUnionFind) Insert(key interface{}) (b bool) {
+	if kuf == nil || key == nil {
+		return false
+	}
+	kuf.mutex.Lock()
+	if kuf.id(key, false) != -1 {
+		kuf.mutex.Unlock()
+		return false
+	}
+	b = kuf.id(key, true) != -1
+	kuf.mutex.Unlock()
+	return b
+}
+
+//@title    Find
+//@description
+//		以keyUnionFind并查集做接收者
+//		返回元素key所在集合的代表元素
+//		若key不在并查集中则返回nil
+//@receiver		kuf			*KeyUnionFind			接受者keyUnionFind的指针
+//@param    	key			interface{}				待查找的元素
+//@return    	root		interface{}				代表元素
+func (kuf *KeyUnionFind) Find(key interface{}) (root interface{}) {
+	if kuf == nil || key == nil {
+		return nil
+	}
+	kuf.mutex.Lock()
+	x := kuf.id(key, false)
+	if x == -1 {
+		kuf.mutex.Unlock()
+		return nil
+	}
+	root = kuf.keys[kuf.uf.find(x)]
+	kuf.mutex.Unlock()
+	return root
+}
+
+//@title    Union
+//@description
+//		以keyUnionFind并查集做接收者
+//		合并元素x和元素y所在的集合
+//		若元素不在并查集中则先将其插入
+//		若两者已在同一集合中则返回false
+//@receiver		kuf			*KeyUnionFind			接受者keyUnionFind的指针
+//@param    	x			interface{}				待合并的元素
+//@param    	y			interface{}				待合并的元素
+//@return    	b			bool					合并成功?
+func (kuf *KeyUnionFind) Union(x, y interface{}) (b bool) {
+	if kuf == nil || x == nil || y == nil {
+		return false
+	}
+	kuf.mutex.Lock()
+	a, c := kuf.id(x, true), kuf.id(y, true)
+	if a == -1 || c == -1 {
+		kuf.mutex.Unlock()
+		return false
+	}
+	b = kuf.uf.union(a, c)
+	kuf.mutex.Unlock()
+	return b
+}
+
+//@title    Connected
+//@description
+//		以keyUnionFind并查集做接收者
+//		判断元素x和元素y是否在同一集合中
+//		若有元素不在并查集中则返回false
+//@receiver		kuf			*KeyUnionFind			接受者keyUnionFind的指针
+//@param    	x			interface{}				待判断的元素
+//@param    	y			interface{}				待判断的元素
+//@return    	b			bool					在同一集合中吗?
+func (kuf *KeyUnionFind) Connected(x, y interface{}) (b bool) {
+	if kuf == nil || x == nil || y == nil {
+		return false
+	}
+	kuf.mutex.Lock()
+	a, c := kuf.id(x, false), kuf.id(y, false)
+	if a == -1 || c == -1 {
+		kuf.mutex.Unlock()
+		return false
+	}
+	b = kuf.uf.find(a) == kuf.uf.find(c)
+	kuf.mutex.Unlock()
+	return b
+}
+
+//@title    SetSize
+//@description
+//		以keyUnionFind并查集做接收者
+//		返回元素key所在集合的大小
+//		若key不在并查集中则返回0
+//@receiver		kuf			*KeyUnionFind			接受者keyUnionFind的指针
+//@param    	key			interface{}				待查找的元素
+//@return    	num			int						集合大小
+func (kuf *KeyUnionFind) SetSize(key interface{}) (num int) {
+	if kuf == nil || key == nil {
+		return 0
+	}
+	kuf.mutex.Lock()
+	x := kuf.id(key, false)
+	if x == -1 {
+		kuf.mutex.Unlock()
+		return 0
+	}
+	num = kuf.uf.size[kuf.uf.find(x)]
+	kuf.mutex.Unlock()
+	return num
+}
+
+//@title    Sets
+//@description
+//		以keyUnionFind并查集做接收者
+//		返回所有集合,每个集合内的元素按插入顺序排列
+//		集合之间按其最早插入的元素排列
+//@receiver		kuf			*KeyUnionFind			接受者keyUnionFind的指针
+//@param    	nil
+//@return    	sets		[][]interface{}			所有集合
+func (kuf *KeyUnionFind) Sets() (sets [][]interface{}) {
+	if kuf == nil {
+		return nil
+	}
+	kuf.mutex.Lock()
+	ids := kuf.uf.sets()
+	sets = make([][]interface{}, len(ids))
+	for i := range ids {
+		sets[i] = make([]interface{}, len(ids[i]))
+		for j, x := range ids[i] {
+			sets[i][j] = kuf.keys[x]
+		}
+	}
+	kuf.mutex.Unlock()
+	return sets
+}
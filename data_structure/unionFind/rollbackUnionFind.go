@@ -0,0 +1,317 @@
+package unionFind
+
+//@Title		unionFind
+//@Description
+//		可撤销并查集-Rollback Disjoint Set Union
+//		仅按集合大小合并而不进行路径压缩,从而使每次合并只修改常数个位置,单次查找的时间复杂度为O(log n)
+//		每次合并都会被记录在栈中,可以撤销最近的合并或回滚到此前的某个版本
+//		适用于线段树分治等离线动态连通性算法
+//		使用互斥锁实现并发控制
+
+import (
+	"github.com/hlccd/goSTL/data_structure/stack"
+	"sync"
+)
+
+//record合并记录结构体
+//保存一次合并中被挂到其他根下的根元素以及其新的父元素
+//若该次合并前两者已在同一集合中则child为-1
+type record struct {
+	child  int //被合并的根元素
+	parent int //合并后的根元素
+}
+
+//rollbackUnionFind可撤销并查集结构体
+//parent保存每个元素的父元素,根元素的父元素为其自身
+//size保存以每个元素为根的集合大小,仅对根元素有效
+//history以栈的形式保存所有合并记录
+type RollbackUnionFind struct {
+	parent  []int        //父元素
+	size    []int        //集合大小
+	count   int          //集合个数
+	history *stack.Stack //合并记录
+	mutex   sync.Mutex   //并发控制锁
+}
+
+//rollbackUnionFind可撤销并查集容器接口
+//存放了rollbackUnionFind可撤销并查集可使用的函数
+//对应函数介绍见下方
+type rollbackUnionFinder interface {
+	Size() (num int)               //返回并查集中的元素个数
+	Count() (num int)              //返回并查集中的集合个数
+	Empty() (b bool)               //判断并查集是否为空
+	Find(x int) (root int)         //返回元素x所在集合的代表元素
+	Union(x, y int) (b bool)       //合并元素x和元素y所在的集合
+	Connected(x, y int) (b bool)   //判断元素x和元素y是否在同一集合中
+	SetSize(x int) (num int)       //返回元素x所在集合的大小
+	Version() (version int)        //返回当前版本,即已记录的合并次数
+	Undo() (b bool)                //撤销最近一次合并
+	Rollback(version int) (b bool) //回滚到版本version
+}
+
+//@title    NewRollback
+//@description
+//		新建一个rollbackUnionFind可撤销并查集容器并返回
+//		初始时含有0到n-1共n个元素,每个元素单独成一个集合
+//@receiver		nil
+//@param    	n			int						初始元素个数
+//@return    	ruf        	*RollbackUnionFind		新建的rollbackUnionFind指针
+func NewRollback(n int) (ruf *RollbackUnionFind) {
+	if n < 0 {
+		n = 0
+	}
+	ruf = &RollbackUnionFind{
+		parent:  make([]int, n),
+		size:    make([]int, n),
+		count:   n,
+		history: stack.New(),
+		mutex:   sync.Mutex{},
+	}
+	for i := 0; i < n; i++ {
+		ruf.parent[i] = i
+		ruf.size[i] = 1
+	}
+	return ruf
+}
+
+//@title    find
+//@description
+//		以rollbackUnionFind可撤销并查集做接收者
+//		返回元素x所在集合的根元素
+//		为保证可以撤销,查找时不进行路径压缩
+//@receiver		ruf			*RollbackUnionFind		接受者rollbackUnionFind的指针
+//@param    	x			int						待查找的元素
+//@return    	root		int						根元素
+func (ruf *RollbackUnionFind) find(x int) (root int) {
+	for ruf.parent[x] != x {
+		x = ruf.parent[x]
+	}
+	return x
+}
+
+//@title    undo
+//@description
+//		以rollbackUnionFind可撤销并查集做接收者
+//		弹出最近一次合并记录并撤销该次合并
+//@receiver		ruf			*RollbackUnionFind		接受者rollbackUnionFind的指针
+//@param    	nil
+//@return    	nil
+func (ruf *RollbackUnionFind) undo() {
+	r := ruf.history.Top().(record)
+	ruf.history.Pop()
+	if r.child == -1 {
+		return
+	}
+	ruf.parent[r.child] = r.child
+	ruf.size[r.parent] -= ruf.size[r.child]
+	ruf.count++
+}
+
+//@title    Size
+//@description
+//		以rollbackUnionFind可撤销并查集做接收者
+//		返回该并查集中的元素个数
+//		如果容器为nil返回0
+//@receiver		ruf			*RollbackUnionFind		接受者rollbackUnionFind的指针
+//@param    	nil
+//@return    	num        	int						元素个数
+func (ruf *RollbackUnionFind) Size() (num int) {
+	if ruf == nil {
+		return 0
+	}
+	return len(ruf.parent)
+}
+
+//@title    Count
+//@description
+//		以rollbackUnionFind可撤销并查集做接收者
+//		返回该并查集中的集合个数
+//		如果容器为nil返回0
+//@receiver		ruf			*RollbackUnionFind		接受者rollbackUnionFind的指针
+//@param    	nil
+//@return    	num        	int						集合个数
+func (ruf *RollbackUnionFind) Count() (num int) {
+	if ruf == nil {
+		return 0
+	}
+	return ruf.count
+}
+
+//@title    Empty
+//@description
+//		以rollbackUnionFind可撤销并查集做接收者
+//		判断该并查集中是否含有元素
+//		如果容器不存在,返回true
+//@receiver		ruf			*RollbackUnionFind		接受者rollbackUnionFind的指针
+//@param    	nil
+//@return    	b			bool					该容器是空的吗?
+func (ruf *RollbackUnionFind) Empty() (b bool) {
+	if ruf == nil {
+		return true
+	}
+	return len(ruf.parent) == 0
+}
+
+//@title    Find
+//@description
+//		以rollbackUnionFind可撤销并查集做接收者
+//		返回元素x所在集合的代表元素
+//		若x不在并查集中则返回-1
+//@receiver		ruf			*RollbackUnionFind		接受者rollbackUnionFind的指针
+//@param    	x			int						待查找的元素
+//@return    	root		int						代表元素
+func (ruf *RollbackUnionFind) Find(x int) (root int) {
+	if ruf == nil {
+		return -1
+	}
+	ruf.mutex.Lock()
+	if x < 0 || x >= len(ruf.parent) {
+		ruf.mutex.Unlock()
+		return -1
+	}
+	root = ruf.find(x)
+	ruf.mutex.Unlock()
+	return root
+}
+
+//@title    Union
+//@description
+//		以rollbackUnionFind可撤销并查集做接收者
+//		合并元素x和元素y所在的集合,较小的集合挂到较大的集合之下
+//		无论是否真正发生合并都会记录一次,从而使每次Union都对应一次Undo
+//		若两者已在同一集合中或有元素不在并查集中则返回false,元素不在并查集中时不做记录
+//@receiver		ruf			*RollbackUnionFind		接受者rollbackUnionFind的指针
+//@param    	x			int						待合并的元素
+//@param    	y			int						待合并的元素
+//@return    	b			bool					合并成功?
+func (ruf *RollbackUnionFind) Union(x, y int) (b bool) {
+	if ruf == nil {
+		return false
+	}
+	ruf.mutex.Lock()
+	if x < 0 || x >= len(ruf.parent) || y < 0 || y >= len(ruf.parent) {
+		ruf.mutex.Unlock()
+		return false
+	}
+	x, y = ruf.find(x), ruf.find(y)
+	if x == y {
+		ruf.history.Push(record{child: -1, parent: x})
+		ruf.mutex.Unlock()
+		return false
+	}
+	if ruf.size[x] < ruf.size[y] {
+		x, y = y, x
+	}
+	ruf.parent[y] = x
+	ruf.size[x] += ruf.size[y]
+	ruf.count--
+	ruf.history.Push(record{child: y, parent: x})
+	ruf.mutex.Unlock()
+	return true
+}
+
+//@title    Connected
+//@description
+//		以rollbackUnionFind可撤销并查集做接收者
+//		判断元素x和元素y是否在同一集合中
+//		若有元素不在并查集中则返回false
+//@receiver		ruf			*RollbackUnionFind		接受者rollbackUnionFind的指针
+//@param    	x			int						待判断的元素
+//@param    	y			int						待判断的元素
+//@return    	b			bool					在同一集合中吗?
+func (ruf *RollbackUnionFind) Connected(x, y int) (b bool) {
+	if ruf == nil {
+		return false
+	}
+	ruf.mutex.Lock()
+	if x < 0 || x >= len(ruf.parent) || y < 0 || y >= len(ruf.parent) {
+		ruf.mutex.Unlock()
+		return false
+	}
+	b = ruf.find(x) == ruf.find(y)
+	ruf.mutex.Unlock()
+	return b
+}
+
+//@title    SetSize
+//@description
+//		以rollbackUnionFind可撤销并查集做接收者
+//		返回元素x所在集合的大小
+//		若x不在并查集中则返回0
+//@receiver		ruf			*RollbackUnionFind		接受者rollbackUnionFind的指针
+//@param    	x			int						待查找的元素
+//@return    	num			int						集合大小
+func (ruf *RollbackUnionFind) SetSize(x int) (num int) {
+	if ruf == nil {
+		return 0
+	}
+	ruf.mutex.Lock()
+	if x < 0 || x >= len(ruf.parent) {
+		ruf.mutex.Unlock()
+		return 0
+	}
+	num = ruf.size[ruf.find(x)]
+	ruf.mutex.Unlock()
+	return num
+}
+
+//@title    Version
+//@description
+//		以rollbackUnionFind可撤销并查集做接收者
+//		返回当前版本,即栈中已记录的合并次数
+//		可在之后通过Rollback回滚到该版本
+//@receiver		ruf			*RollbackUnionFind		接受者rollbackUnionFind的指针
+//@param    	nil
+//@return    	version		int						当前版本
+func (ruf *RollbackUnionFind) Version() (version int) {
+	if ruf == nil {
+		return 0
+	}
+	return int(ruf.history.Size())
+}
+
+//@title    Undo
+//@description
+//		以rollbackUnionFind可撤销并查集做接收者
+//		撤销最近一次Union
+//		若没有可撤销的记录则返回false
+//@receiver		ruf			*RollbackUnionFind		接受者rollbackUnionFind的指针
+//@param    	nil
+//@return    	b			bool					撤销成功?
+func (ruf *RollbackUnionFind) Undo() (b bool) {
+	if ruf == nil {
+		return false
+	}
+	ruf.mutex.Lock()
+	if ruf.history.Empty() {
+		ruf.mutex.Unlock()
+		return false
+	}
+	ruf.undo()
+	ruf.mutex.Unlock()
+	return true
+}
+
+//@title    Rollback
+//@description
+//		以rollbackUnionFind可撤销并查集做接收者
+//		依次撤销最近的合并,直到版本回到version
+//		若version不在[0,Version()]范围内则返回false
+//@receiver		ruf			*RollbackUnionFind		接受者rollbackUnionFind的指针
+//@param    	version		int						回滚到的版本
+//@return    	b			bool					回滚成功?
+func (ruf *RollbackUnionFind) Rollback(version int) (b bool) {
+	if ruf == nil {
+		return false
+	}
+	ruf.mutex.Lock()
+	if version < 0 || version > int(ruf.history.Size()) {
+		ruf.mutex.Unlock()
+		return false
+	}
+	for int(ruf.history.Size()) > version {
+		ruf.undo()
+	}
+	ruf.mutex.Unlock()
+	return true
+}
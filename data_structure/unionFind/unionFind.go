@@ -0,0 +1,336 @@
+package unionFind
+
+//@Title		unionFind
+//@Description
+//		并查集-Disjoint Set Union
+//		以数组形式存储森林,每个集合以一棵树表示,树根即为该集合的代表元素
+//		查找时进行路径压缩,合并时按集合大小合并,单次操作的均摊时间复杂度接近O(1)
+//		元素为从0开始的连续整数,可以动态增加元素
+//		使用互斥锁实现并发控制
+
+import (
+	"sync"
+)
+
+//unionFind并查集结构体
+//parent保存每个元素的父元素,根元素的父元素为其自身
+//size保存以每个元素为根的集合大小,仅对根元素有效
+//同时保存当前集合的个数
+type UnionFind struct {
+	parent []int      //父元素
+	size   []int      //集合大小
+	count  int        //集合个数
+	mutex  sync.Mutex //并发控制锁
+}
+
+//unionFind并查集容器接口
+//存放了unionFind并查集可使用的函数
+//对应函数介绍见下方
+type unionFinder interface {
+	Size() (num int)             //返回并查集中的元素个数
+	Count() (num int)            //返回并查集中的集合个数
+	Clear()                      //清空并查集
+	Empty() (b bool)             //判断并查集是否为空
+	Add() (x int)                //增加一个单独成集合的元素并返回其编号
+	Find(x int) (root int)       //返回元素x所在集合的代表元素
+	Union(x, y int) (b bool)     //合并元素x和元素y所在的集合
+	Connected(x, y int) (b bool) //判断元素x和元素y是否在同一集合中
+	SetSize(x int) (num int)     //返回元素x所在集合的大小
+	Sets() (sets [][]int)        //返回所有集合
+}
+
+//@title    New
+//@description
+//		新建一个unionFind并查集容器并返回
+//		初始时含有0到n-1共n个元素,每个元素单独成一个集合
+//@receiver		nil
+//@param    	n			int						初始元素个数
+//@return    	uf        	*UnionFind				新建的unionFind指针
+func New(n int) (uf *UnionFind) {
+	if n < 0 {
+		n = 0
+	}
+	uf = &UnionFind{
+		parent: make([]int, n),
+		size:   make([]int, n),
+		count:  n,
+		mutex:  sync.Mutex{},
+	}
+	for i := 0; i < n; i++ {
+		uf.parent[i] = i
+		uf.size[i] = 1
+	}
+	return uf
+}
+
+//@title    add
+//@description
+//		以unionFind并查集做接收者
+//		增加一个单独成集合的元素并返回其编号
+//@receiver		uf			*UnionFind				接受者unionFind的指针
+//@param    	nil
+//@return    	x			int						新元素的编号
+func (uf *UnionFind) add() (x int) {
+	x = len(uf.parent)
+	uf.parent = append(uf.parent, x)
+	uf.size = append(uf.size, 1)
+	uf.count++
+	return x
+}
+
+//@title    find
+//@description
+//		以unionFind并查集做接收者
+//		返回元素x所在集合的根元素
+//		查找完成后将路径上的所有元素直接挂到根元素下
+//@receiver		uf			*UnionFind				接受者unionFind的指针
+//@param    	x			int						待查找的元素
+//@return    	root		int						根元素
+func (uf *UnionFind) find(x int) (root int) {
+	root = x
+	for uf.parent[root] != root {
+		root = uf.parent[root]
+	}
+	//路径压缩
+	for uf.parent[x] != root {
+		uf.parent[x], x = root, uf.parent[x]
+	}
+	return root
+}
+
+//@title    union
+//@description
+//		以unionFind并查集做接收者
+//		将元素x和元素y所在的集合合并
+//		较小的集合挂到较大的集合之下
+//		若两者已在同一集合中则返回false
+//@receiver		uf			*UnionFind				接受者unionFind的指针
+//@param    	x			int						待合并的元素
+//@param    	y			int						待合并的元素
+//@return    	b			bool					合并成功?
+func (uf *UnionFind) union(x, y int) (b bool) {
+	x, y = uf.find(x), uf.find(y)
+	if x == y {
+		return false
+	}
+	if uf.size[x] < uf.size[y] {
+		x, y = y, x
+	}
+	uf.parent[y] = x
+	uf.size[x] += uf.size[y]
+	uf.count--
+	return true
+}
+
+//@title    sets
+//@description
+//		以unionFind并查集做接收者
+//		返回所有集合,每个集合内的元素从小到大排列
+//		集合之间按其最小元素从小到大排列
+//@receiver		uf			*UnionFind				接受者unionFind的指针
+//@param    	nil
+//@return    	sets		[][]int					所有集合
+func (uf *UnionFind) sets() (sets [][]int) {
+	sets = make([][]int, 0, uf.count)
+	idx := make(map[int]int, uf.count)
+	for x := range uf.parent {
+		root := uf.find(x)
+		i, ok := idx[root]
+		if !ok {
+			i = len(sets)
+			idx[root] = i
+			sets = append(sets, make([]int, 0, uf.size[root]))
+		}
+		sets[i] = append(sets[i], x)
+	}
+	return sets
+}
+
+//@title    Size
+//@description
+//		以unionFind并查集做接收者
+//		返回该并查集中的元素个数
+//		如果容器为nil返回0
+//@receiver		uf			*UnionFind				接受者unionFind的指针
+//@param    	nil
+//@return    	num        	int						元素个数
+func (uf *UnionFind) Size() (num int) {
+	if uf == nil {
+		return 0
+	}
+	return len(uf.parent)
+}
+
+//@title    Count
+//@description
+//		以unionFind并查集做接收者
+//		返回该并查集中的集合个数
+//		如果容器为nil返回0
+//@receiver		uf			*UnionFind				接受者unionFind的指针
+//@param    	nil
+//@return    	num        	int						集合个数
+func (uf *UnionFind) Count() (num int) {
+	if uf == nil {
+		return 0
+	}
+	return uf.count
+}
+
+//@title    Clear
+//@description
+//		以unionFind并查集做接收者
+//		将该并查集中的所有元素清空
+//@receiver		uf			*UnionFind				接受者unionFind的指针
+//@param    	nil
+//@return    	nil
+func (uf *UnionFind) Clear() {
+	if uf == nil {
+		return
+	}
+	uf.mutex.Lock()
+	uf.parent = make([]int, 0)
+	uf.size = make([]int, 0)
+	uf.count = 0
+	uf.mutex.Unlock()
+}
+
+//@title    Empty
+//@description
+//		以unionFind并查集做接收者
+//		判断该并查集中是否含有元素
+//		如果容器不存在,返回true
+//@receiver		uf			*UnionFind				接受者unionFind的指针
+//@param    	nil
+//@return    	b			bool					该容器是空的吗?
+func (uf *UnionFind) Empty() (b bool) {
+	if uf == nil {
+		return true
+	}
+	return len(uf.parent) == 0
+}
+
+//@title    Add
+//@description
+//		以unionFind并查集做接收者
+//		增加一个单独成集合的元素,其编号为当前的元素个数
+//		如果容器为nil返回-1
+//@receiver		uf			*UnionFind				接受者unionFind的指针
+//@param    	nil
+//@return    	x			int						新元素的编号
+func (uf *UnionFind) Add() (x int) {
+	if uf == nil {
+		return -1
+	}
+	uf.mutex.Lock()
+	x = uf.add()
+	uf.mutex.Unlock()
+	return x
+}
+
+//@title    Find
+//@description
+//		以unionFind并查集做接收者
+//		返回元素x所在集合的代表元素
+//		若x不在并查集中则返回-1
+//@receiver		uf			*UnionFind				接受者unionFind的指针
+//@param    	x			int						待查找的元素
+//@return    	root		int						代表元素
+func (uf *UnionFind) Find(x int) (root int) {
+	if uf == nil {
+		return -1
+	}
+	uf.mutex.Lock()
+	if x < 0 || x >= len(uf.parent) {
+		uf.mutex.Unlock()
+		return -1
+	}
+	root = uf.find(x)
+	uf.mutex.Unlock()
+	return root
+}
+
+//@title    Union
+//@description
+//		以unionFind并查集做接收者
+//		合并元素x和元素y所在的集合
+//		若两者已在同一集合中或有元素不在并查集中则返回false
+//@receiver		uf			*UnionFind				接受者unionFind的指针
+//@param    	x			int						待合并的元素
+//@param    	y			int						待合并的元素
+//@return    	b			bool					合并成功?
+func (uf *UnionFind) Union(x, y int) (b bool) {
+	if uf == nil {
+		return false
+	}
+	uf.mutex.Lock()
+	if x < 0 || x >= len(uf.parent) || y < 0 || y >= len(uf.parent) {
+		uf.mutex.Unlock()
+		return false
+	}
+	b = uf.union(x, y)
+	uf.mutex.Unlock()
+	return b
+}
+
+//@title    Connected
+//@description
+//		以unionFind并查集做接收者
+//		判断元素x和元素y是否在同一集合中
+//		若有元素不在并查集中则返回false
+//@receiver		uf			*UnionFind				接受者unionFind的指针
+//@param    	x			int						待判断的元素
+//@param    	y			int						待判断的元素
+//@return    	b			bool					在同一集合中吗?
+func (uf *UnionFind) Connected(x, y int) (b bool) {
+	if uf == nil {
+		return false
+	}
+	uf.mutex.Lock()
+	if x < 0 || x >= len(uf.parent) || y < 0 || y >= len(uf.parent) {
+		uf.mutex.Unlock()
+		return false
+	}
+	b = uf.find(x) == uf.find(y)
+	uf.mutex.Unlock()
+	return b
+}
+
+//@title    SetSize
+//@description
+//		以unionFind并查集做接收者
+//		返回元素x所在集合的大小
+//		若x不在并查集中则返回0
+//@receiver		uf			*UnionFind				接受者unionFind的指针
+//@param    	x			int						待查找的元素
+//@return    	num			int						集合大小
+func (uf *UnionFind) SetSize(x int) (num int) {
+	if uf == nil {
+		return 0
+	}
+	uf.mutex.Lock()
+	if x < 0 || x >= len(uf.parent) {
+		uf.mutex.Unlock()
+		return 0
+	}
+	num = uf.size[uf.find(x)]
+	uf.mutex.Unlock()
+	return num
+}
+
+//@title    Sets
+//@description
+//		以unionFind并查集做接收者
+//		返回所有集合,每个集合内的元素从小到大排列
+//		集合之间按其最小元素从小到大排列
+//@receiver		uf			*UnionFind				接受者unionFind的指针
+//@param    	nil
+//@return    	sets		[][]int					所有集合
+func (uf *UnionFind) Sets() (sets [][]int) {
+	if uf == nil {
+		return nil
+	}
+	uf.mutex.Lock()
+	sets = uf.sets()
+	uf.mutex.Unlock()
+	return sets
+}
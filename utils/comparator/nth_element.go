@@ -31,7 +31,7 @@ func NthElement(arr *[]interface{}, n int, Cmp ...Comparator) (value interface{}
 		return nil
 	}
 	//判断待确认的第n位是否在该集合范围内
-	if len((*arr)) < n || n<0 {
+	if len((*arr)) <= n || n<0 {
 		return nil
 	}
 	//进行查找
@@ -71,7 +71,8 @@ func nthElement(arr *[]interface{},l,r int, n int, cmp Comparator){
 		}
 	}
 	//确认第n位的范围进行局部二分
-	if n-1 >= i {
+	//划分结束后[l,j]中的元素均不大于m,[j+1,r]中的元素均不小于m
+	if n > j {
 		nthElement(arr,j+1,r, n, cmp)
 	} else {
 		nthElement(arr,l,j, n, cmp)
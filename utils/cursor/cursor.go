@@ -0,0 +1,178 @@
+package cursor
+
+//@Title		cursor
+//@Description
+//		二叉搜索树的游标
+//		供不保存双亲节点的二叉搜索树(bsTree、avlTree、treap)共用
+//		游标保存从根节点到当前节点的路径,从而可以在不借助双亲节点的情况下双向移动
+//		从任意位置开始遍历k个元素的总时间复杂度为O(h + k),h为树高
+//		游标不持有元素的副本,在创建游标后对所属的树进行增删将使游标失效
+
+import (
+	"sync"
+)
+
+//Node游标可遍历的树节点接口
+//树节点需实现该接口以供游标沿路径移动
+//无对应子节点时必须返回nil接口而非nil指针
+type Node interface {
+	Left() (n Node)         //返回左子节点,不存在时返回nil
+	Right() (n Node)        //返回右子节点,不存在时返回nil
+	Count() (num int)       //返回该节点中存储的重复元素数量
+	Value() (e interface{}) //返回该节点中存储的元素
+}
+
+//Cursor游标结构体
+//该游标指向树中的某一个元素
+//若节点中存储了多个重复元素,则游标依次经过每一个重复元素
+//当游标移出树的范围后即失效,此后无法再移动
+type Cursor struct {
+	mutex sync.Locker //所属树的并发控制锁
+	stack []Node      //从根节点到当前节点的路径,栈顶为当前节点
+	idx   int         //当前元素在节点重复元素中的序号
+}
+
+//Cursor游标接口
+//存放了Cursor游标可使用的函数
+//对应函数介绍见下方
+type cursorer interface {
+	Valid() (b bool)        //判断该游标是否指向有效元素
+	Value() (e interface{}) //返回该游标指向的元素
+	Next() (b bool)         //将该游标后移一位
+	Pre() (b bool)          //将该游标前移一位
+}
+
+//@title    New
+//@description
+//		新建一个Cursor游标并返回
+//		stack为从根节点到目标节点的路径,为空时游标无效
+//		游标指向目标节点中的第一个重复元素
+//		调用者需在持有mutex时调用
+//@receiver		nil
+//@param    	mutex		sync.Locker				所属树的并发控制锁
+//@param    	stack		[]Node					从根节点到目标节点的路径
+//@return    	c        	*Cursor					新建的Cursor指针
+func New(mutex sync.Locker, stack []Node) (c *Cursor) {
+	return &Cursor{
+		mutex: mutex,
+		stack: stack,
+		idx:   0,
+	}
+}
+
+//@title    Valid
+//@description
+//		以Cursor游标做接收者
+//		判断该游标是否指向树中的有效元素
+//		游标不存在或已移出范围时返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					该游标有效吗?
+func (c *Cursor) Valid() (b bool) {
+	if c == nil {
+		return false
+	}
+	return len(c.stack) > 0
+}
+
+//@title    Value
+//@description
+//		以Cursor游标做接收者
+//		返回该游标当前指向的元素
+//		若游标无效则返回nil
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	e			interface{}				游标指向的元素
+func (c *Cursor) Value() (e interface{}) {
+	if !c.Valid() {
+		return nil
+	}
+	return c.stack[len(c.stack)-1].Value()
+}
+
+//@title    Next
+//@description
+//		以Cursor游标做接收者
+//		将该游标移动到下一个元素,即中缀序列中的后一个元素
+//		若当前节点仍有未经过的重复元素则只移动序号
+//		否则寻找后继节点:存在右子树则为右子树的最左节点,否则为首个从左侧回溯到的祖先节点
+//		移出范围后游标失效并返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					移动后游标仍有效吗?
+func (c *Cursor) Next() (b bool) {
+	if !c.Valid() {
+		return false
+	}
+	c.mutex.Lock()
+	n := c.stack[len(c.stack)-1]
+	if c.idx+1 < n.Count() {
+		//当前节点仍有重复元素
+		c.idx++
+		c.mutex.Unlock()
+		return true
+	}
+	c.idx = 0
+	if n = n.Right(); n != nil {
+		//后继节点为右子树的最左节点
+		for ; n != nil; n = n.Left() {
+			c.stack = append(c.stack, n)
+		}
+		c.mutex.Unlock()
+		return true
+	}
+	//向上回溯,直到从某个节点的左子树回到该节点
+	for len(c.stack) > 0 {
+		son := c.stack[len(c.stack)-1]
+		c.stack = c.stack[:len(c.stack)-1]
+		if len(c.stack) > 0 && c.stack[len(c.stack)-1].Left() == son {
+			break
+		}
+	}
+	c.mutex.Unlock()
+	return len(c.stack) > 0
+}
+
+//@title    Pre
+//@description
+//		以Cursor游标做接收者
+//		将该游标移动到上一个元素,即中缀序列中的前一个元素
+//		若当前节点仍有未经过的重复元素则只移动序号
+//		否则寻找前驱节点:存在左子树则为左子树的最右节点,否则为首个从右侧回溯到的祖先节点
+//		移出范围后游标失效并返回false
+//@receiver		c			*Cursor					接受者Cursor的指针
+//@param    	nil
+//@return    	b			bool					移动后游标仍有效吗?
+func (c *Cursor) Pre() (b bool) {
+	if !c.Valid() {
+		return false
+	}
+	c.mutex.Lock()
+	n := c.stack[len(c.stack)-1]
+	if c.idx > 0 {
+		//当前节点仍有重复元素
+		c.idx--
+		c.mutex.Unlock()
+		return true
+	}
+	if n = n.Left(); n != nil {
+		//前驱节点为左子树的最右节点
+		for ; n != nil; n = n.Right() {
+			c.stack = append(c.stack, n)
+		}
+	} else {
+		//向上回溯,直到从某个节点的右子树回到该节点
+		for len(c.stack) > 0 {
+			son := c.stack[len(c.stack)-1]
+			c.stack = c.stack[:len(c.stack)-1]
+			if len(c.stack) > 0 && c.stack[len(c.stack)-1].Right() == son {
+				break
+			}
+		}
+	}
+	if len(c.stack) > 0 {
+		c.idx = c.stack[len(c.stack)-1].Count() - 1
+	}
+	c.mutex.Unlock()
+	return len(c.stack) > 0
+}
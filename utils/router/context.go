@@ -0,0 +1,55 @@
+package router
+
+//@Title		router
+//@Description
+//		从请求的context中取出路由匹配结果
+//		只有由Router调用的处理函数的请求中才存在匹配结果
+
+import (
+	"github.com/hlccd/goSTL/data_structure/radix"
+	"net/http"
+)
+
+//matchKey匹配结果在context中的键
+type matchKey struct{}
+
+//@title    Route
+//@description
+//		返回请求r的路由匹配结果,包括匹配到的路由和参数映射
+//		若不存在则返回nil
+//@receiver		nil
+//@param    	r			*http.Request			请求
+//@return    	m			*radix.Match			匹配结果
+func Route(r *http.Request) (m *radix.Match) {
+	if r == nil {
+		return nil
+	}
+	m, _ = r.Context().Value(matchKey{}).(*radix.Match)
+	return m
+}
+
+//@title    Params
+//@description
+//		返回请求r匹配得到的参数名到参数内容的映射
+//		若不存在则返回nil
+//@receiver		nil
+//@param    	r			*http.Request			请求
+//@return    	ps			map[string]string		参数映射
+func Params(r *http.Request) (ps map[string]string) {
+	if m := Route(r); m != nil {
+		return m.Params
+	}
+	return nil
+}
+
+//@title    Param
+//@description
+//		返回请求r中参数名为name的参数内容
+//		若不存在则返回空串
+//@receiver		nil
+//@param    	r			*http.Request			请求
+//@param    	name		string					参数名
+//@return    	v			string					参数内容
+func Param(r *http.Request, name string) (v string) {
+	return Params(r)[name]
+}
@@ -0,0 +1,302 @@
+package router
+
+//@Title		router
+//@Description
+//		HTTP路由器-Router
+//		以radix前缀基数树为基础,为每个请求方法维护一棵路由树
+//		路由以'/'分段,':name'匹配单独一段,'*name'匹配之后的所有段,'*name'必须为最后一段
+//		':name'可带约束和后缀,如':id<int>'、':slug<[a-z-]+>'、':file.json',约束不满足时尝试下一个候选路由
+//		任一段可用'['和']'包裹表示可选,如'/[:lang]/docs'同时匹配'/docs'和'/en/docs'
+//		匹配优先级固定为静态段优先于':'动态段,':'动态段优先于'*'通配段,与注册顺序无关
+//		注册时检查冲突,同一方法下形状相同的路由(仅参数名不同,或仅末尾'/'不同)无法同时注册
+//		实现了http.Handler,匹配得到的参数放入请求的context中
+//		路径存在但方法不匹配时返回405并设置Allow头,路径不存在时返回404
+//		请求路径与注册路由的末尾'/'不一致时重定向到注册的形式
+//		路由表和路由树均使用读写锁实现并发控制,匹配时只持有读锁,同一方法的请求可以并发匹配,调用处理函数时不持有锁
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hlccd/goSTL/data_structure/radix"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrInvalid  = errors.New("router: invalid route")     //路由不合法
+	ErrConflict = errors.New("router: conflicting route") //路由冲突
+)
+
+//tree路由树接口
+//由radix前缀基数树实现
+type tree interface {
+	Store(s string, e interface{}) (b bool)
+	Match(s string) (m *radix.Match, ok bool)
+	Conflict(s string) (p string, ok bool)
+}
+
+//route路由结构体
+//作为radix中路由携带的元素
+type route struct {
+	handler http.Handler //处理函数
+	pattern string       //注册时的路由
+	isSlash bool         //注册时的路由是否以'/'结尾
+	isWild  bool         //是否含有'*'通配段
+}
+
+//router路由器结构体
+//该实例存储每个请求方法对应的路由树
+//RedirectTrailingSlash为true时对末尾'/'不一致的请求进行重定向
+//HandleMethodNotAllowed为true时对方法不匹配的请求返回405,否则返回404
+//NotFound和MethodNotAllowed为nil时使用默认的处理函数
+type Router struct {
+	trees                  map[string]tree //请求方法到路由树的映射
+	RedirectTrailingSlash  bool            //是否重定向末尾'/'不一致的请求
+	HandleMethodNotAllowed bool            //是否对方法不匹配的请求返回405
+	NotFound               http.Handler    //路径不存在时的处理函数
+	MethodNotAllowed       http.Handler    //方法不匹配时的处理函数
+	mutex                  sync.RWMutex    //并发控制锁
+}
+
+//router路由器接口
+//存放了router路由器可使用的函数
+//对应函数介绍见下方
+type router interface {
+	Handle(method, pattern string, handler http.Handler) (err error)                               //注册路由
+	HandleFunc(method, pattern string, f func(w http.ResponseWriter, r *http.Request)) (err error) //以函数注册路由
+	ServeHTTP(w http.ResponseWriter, r *http.Request)                                              //处理请求
+}
+
+//@title    New
+//@description
+//		新建一个router路由器并返回
+//		默认重定向末尾'/'不一致的请求,并对方法不匹配的请求返回405
+//@receiver		nil
+//@param    	nil
+//@return    	rt        	*Router					新建的router指针
+func New() (rt *Router) {
+	return &Router{
+		trees:                  make(map[string]tree),
+		RedirectTrailingSlash:  true,
+		HandleMethodNotAllowed: true,
+		NotFound:               nil,
+		MethodNotAllowed:       nil,
+		mutex:                  sync.RWMutex{},
+	}
+}
+
+//@title    check
+//@description
+//		检查路由是否合法
+//		路由必须以'/'开头,动态段和通配段必须有参数名且参数名不可重复,通配段必须为最后一段且不可带约束和后缀
+//		约束是否合法由radix在存储时检查
+//		返回路由是否以'/'结尾以及是否含有通配段
+//@receiver		nil
+//@param    	pattern		string					待检查的路由
+//@return    	isSlash		bool					是否以'/'结尾
+//@return    	isWild		bool					是否含有通配段
+//@return    	err			error					不合法时的错误
+func check(pattern string) (isSlash, isWild bool, err error) {
+	if len(pattern) == 0 || pattern[0] != '/' {
+		return false, false, fmt.Errorf("%w: %q must begin with '/'", ErrInvalid, pattern)
+	}
+	names := make(map[string]bool)
+	parts := strings.Split(pattern, "/")
+	for i, part := range parts {
+		if len(part) > 2 && part[0] == '[' && part[len(part)-1] == ']' {
+			//可选段
+			part = part[1 : len(part)-1]
+		}
+		if part == "" || (part[0] != ':' && part[0] != '*') {
+			continue
+		}
+		end := 1
+		for end < len(part) && (part[end] == '_' || part[end] >= 'a' && part[end] <= 'z' || part[end] >= 'A' && part[end] <= 'Z' || part[end] >= '0' && part[end] <= '9') {
+			end++
+		}
+		name := part[1:end]
+		if name == "" {
+			return false, false, fmt.Errorf("%w: %q has an unnamed parameter", ErrInvalid, pattern)
+		}
+		if names[name] {
+			return false, false, fmt.Errorf("%w: %q repeats parameter %q", ErrInvalid, pattern, name)
+		}
+		names[name] = true
+		if part[0] == '*' {
+			if end != len(part) {
+				return false, false, fmt.Errorf("%w: %q has a constrained wildcard", ErrInvalid, pattern)
+			}
+			if i != len(parts)-1 {
+				return false, false, fmt.Errorf("%w: %q has segments after wildcard", ErrInvalid, pattern)
+			}
+			isWild = true
+		}
+	}
+	return len(pattern) > 1 && pattern[len(pattern)-1] == '/', isWild, nil
+}
+
+//@title    Handle
+//@description
+//		以router路由器做接收者
+//		为请求方法method注册路由pattern及其处理函数handler
+//		若路由不合法或其约束无法解析则返回ErrInvalid
+//		若同一方法下已存在形状相同的路由则返回ErrConflict,错误信息中包含已存在的路由
+//@receiver		rt			*Router					接受者router的指针
+//@param    	method		string					请求方法
+//@param    	pattern		string					路由
+//@param    	handler		http.Handler			处理函数
+//@return    	err			error					注册失败时的错误
+func (rt *Router) Handle(method, pattern string, handler http.Handler) (err error) {
+	if rt == nil {
+		return ErrInvalid
+	}
+	if method == "" || handler == nil {
+		return fmt.Errorf("%w: %q needs a method and a handler", ErrInvalid, pattern)
+	}
+	isSlash, isWild, err := check(pattern)
+	if err != nil {
+		return err
+	}
+	rt.mutex.Lock()
+	t, ok := rt.trees[method]
+	if !ok {
+		t = radix.New()
+		rt.trees[method] = t
+	}
+	if p, ok := t.Conflict(pattern); ok {
+		rt.mutex.Unlock()
+		return fmt.Errorf("%w: %s %s conflicts with %s", ErrConflict, method, pattern, p)
+	}
+	if !t.Store(pattern, &route{handler: handler, pattern: pattern, isSlash: isSlash, isWild: isWild}) {
+		rt.mutex.Unlock()
+		return fmt.Errorf("%w: %q has an invalid constraint or duplicated optional forms", ErrInvalid, pattern)
+	}
+	rt.mutex.Unlock()
+	return nil
+}
+
+//@title    HandleFunc
+//@description
+//		以router路由器做接收者
+//		为请求方法method注册路由pattern及其处理函数f
+//		规则与Handle相同
+//@receiver		rt			*Router					接受者router的指针
+//@param    	method		string					请求方法
+//@param    	pattern		string					路由
+//@param    	f			func(w http.ResponseWriter, r *http.Request)	处理函数
+//@return    	err			error					注册失败时的错误
+func (rt *Router) HandleFunc(method, pattern string, f func(w http.ResponseWriter, r *http.Request)) (err error) {
+	if f == nil {
+		return fmt.Errorf("%w: %q needs a method and a handler", ErrInvalid, pattern)
+	}
+	return rt.Handle(method, pattern, http.HandlerFunc(f))
+}
+
+//@title    lookup
+//@description
+//		以router路由器做接收者
+//		在请求方法method的路由树中匹配path
+//		HEAD请求在HEAD的路由树中匹配不到时使用GET的路由,即使已注册了其他HEAD路由
+//@receiver		rt			*Router					接受者router的指针
+//@param    	method		string					请求方法
+//@param    	path		string					请求路径
+//@return    	m			*radix.Match			匹配结果
+//@return    	ok			bool					匹配成功?
+func (rt *Router) lookup(method, path string) (m *radix.Match, ok bool) {
+	rt.mutex.RLock()
+	t, has := rt.trees[method]
+	get, hasGet := rt.trees[http.MethodGet]
+	rt.mutex.RUnlock()
+	if has {
+		if m, ok = t.Match(path); ok {
+			return m, true
+		}
+	}
+	if method == http.MethodHead && hasGet {
+		return get.Match(path)
+	}
+	return nil, false
+}
+
+//@title    allowed
+//@description
+//		以router路由器做接收者
+//		返回路径path可以匹配到的所有请求方法,按字典序排列
+//		GET可以匹配时,由于HEAD请求会回退到GET的路由,同样列出HEAD
+//@receiver		rt			*Router					接受者router的指针
+//@param    	path		string					请求路径
+//@return    	ms			[]string				可用的请求方法
+func (rt *Router) allowed(path string) (ms []string) {
+	ms = make([]string, 0)
+	isHead, isGet := false, false
+	rt.mutex.RLock()
+	for method, t := range rt.trees {
+		if _, ok := t.Match(path); ok {
+			ms = append(ms, method)
+			isHead = isHead || method == http.MethodHead
+			isGet = isGet || method == http.MethodGet
+		}
+	}
+	rt.mutex.RUnlock()
+	if isGet && !isHead {
+		ms = append(ms, http.MethodHead)
+	}
+	sort.Strings(ms)
+	return ms
+}
+
+//@title    ServeHTTP
+//@description
+//		以router路由器做接收者
+//		按请求方法和路径匹配路由并调用其处理函数,匹配结果放入请求的context中
+//		路径与路由末尾'/'不一致时,GET和HEAD请求以301重定向,其他请求以308重定向以保留方法和请求体
+//		重定向目标由清理后的路径构建,连续的'/'会被合并,因此不会产生以"//"开头的地址
+//		路径存在但方法不匹配时返回405并在Allow头中列出可用的方法
+//		路径不存在时返回404
+//@receiver		rt			*Router					接受者router的指针
+//@param    	w			http.ResponseWriter		响应
+//@param    	r			*http.Request			请求
+//@return    	nil
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p := r.URL.Path
+	if m, ok := rt.lookup(r.Method, p); ok {
+		rte := m.Value.(*route)
+		if rt.RedirectTrailingSlash && !rte.isWild && p != "/" && strings.HasSuffix(p, "/") != rte.isSlash {
+			//匹配时已舍弃空段,重定向目标也需按清理后的路径构建,避免形如"//host"的地址跳转到其他站点
+			u := *r.URL
+			u.RawPath = ""
+			u.Path = path.Clean("/" + p)
+			if rte.isSlash && u.Path != "/" {
+				u.Path += "/"
+			}
+			code := http.StatusPermanentRedirect
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				code = http.StatusMovedPermanently
+			}
+			http.Redirect(w, r, u.String(), code)
+			return
+		}
+		rte.handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), matchKey{}, m)))
+		return
+	}
+	if rt.HandleMethodNotAllowed {
+		if ms := rt.allowed(p); len(ms) > 0 {
+			w.Header().Set("Allow", strings.Join(ms, ", "))
+			if rt.MethodNotAllowed != nil {
+				rt.MethodNotAllowed.ServeHTTP(w, r)
+			} else {
+				http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			}
+			return
+		}
+	}
+	if rt.NotFound != nil {
+		rt.NotFound.ServeHTTP(w, r)
+	} else {
+		http.NotFound(w, r)
+	}
+}
@@ -0,0 +1,219 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+//echo返回一个将路由和参数写入响应体的处理函数
+func echo(name string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		m := Route(r)
+		w.Write([]byte(name + " " + m.Pattern))
+		for _, k := range []string{"id", "name", "path"} {
+			if v, ok := m.Params[k]; ok {
+				w.Write([]byte(" " + k + "=" + v))
+			}
+		}
+	}
+}
+
+func serve(rt *Router, method, target string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+	return w
+}
+
+func TestRouter_Priority(t *testing.T) {
+	//以不同的注册顺序注册,匹配结果应当一致
+	routes := []struct{ pattern, name string }{
+		{"/users/*path", "wild"},
+		{"/users/:id", "param"},
+		{"/users/new", "static"},
+		{"/users/:id/posts", "posts"},
+		{"/users/new/:name", "newName"},
+	}
+	cases := []struct{ target, body string }{
+		{"/users/new", "static /users/new"},
+		{"/users/42", "param /users/:id id=42"},
+		{"/users/42/posts", "posts /users/:id/posts id=42"},
+		{"/users/new/posts", "newName /users/new/:name name=posts"},
+		{"/users/42/comments", "wild /users/*path path=42/comments"},
+		{"/users/new/a/b", "wild /users/*path path=new/a/b"},
+	}
+	for _, order := range [][]int{{0, 1, 2, 3, 4}, {4, 3, 2, 1, 0}, {2, 0, 4, 1, 3}} {
+		rt := New()
+		for _, i := range order {
+			if err := rt.Handle(http.MethodGet, routes[i].pattern, echo(routes[i].name)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for _, c := range cases {
+			w := serve(rt, http.MethodGet, c.target)
+			if w.Code != http.StatusOK || w.Body.String() != c.body {
+				t.Fatalf("order %v GET %s = %d %q, want %q", order, c.target, w.Code, w.Body.String(), c.body)
+			}
+		}
+	}
+}
+
+func TestRouter_Conflict(t *testing.T) {
+	rt := New()
+	if err := rt.Handle(http.MethodGet, "/a/:x", echo("x")); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{"/a/:y", "/a/:x", "/a/:x/"} {
+		if err := rt.Handle(http.MethodGet, p, echo("y")); !errors.Is(err, ErrConflict) {
+			t.Fatalf("Handle(%q) = %v, want ErrConflict", p, err)
+		}
+	}
+	//不同方法或不同形状不冲突
+	if err := rt.Handle(http.MethodPost, "/a/:y", echo("y")); err != nil {
+		t.Fatal(err)
+	}
+	if err := rt.Handle(http.MethodGet, "/a/b", echo("b")); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{"a", "/a/*", "/a/*p/b", "/a/:x/:x", ""} {
+		if err := rt.Handle(http.MethodGet, p, echo("bad")); !errors.Is(err, ErrInvalid) {
+			t.Fatalf("Handle(%q) = %v, want ErrInvalid", p, err)
+		}
+	}
+}
+
+func TestRouter_NotFoundAndMethodNotAllowed(t *testing.T) {
+	rt := New()
+	rt.Handle(http.MethodGet, "/items/:id", echo("get"))
+	rt.Handle(http.MethodPut, "/items/:id", echo("put"))
+	if w := serve(rt, http.MethodGet, "/nothing"); w.Code != http.StatusNotFound {
+		t.Fatalf("GET /nothing = %d", w.Code)
+	}
+	w := serve(rt, http.MethodDelete, "/items/1")
+	if w.Code != http.StatusMethodNotAllowed || w.Header().Get("Allow") != "GET, HEAD, PUT" {
+		t.Fatalf("DELETE /items/1 = %d, Allow %q", w.Code, w.Header().Get("Allow"))
+	}
+	//HEAD未注册时使用GET的路由
+	if w := serve(rt, http.MethodHead, "/items/1"); w.Code != http.StatusOK {
+		t.Fatalf("HEAD /items/1 = %d", w.Code)
+	}
+	//注册了其他HEAD路由后,HEAD树中匹配不到的路径仍回退到GET的路由
+	rt.Handle(http.MethodHead, "/other", echo("head"))
+	if w := serve(rt, http.MethodHead, "/items/1"); w.Code != http.StatusOK {
+		t.Fatalf("HEAD /items/1 with a HEAD tree = %d", w.Code)
+	}
+	w = serve(rt, http.MethodDelete, "/items/1")
+	if w.Code != http.StatusMethodNotAllowed || w.Header().Get("Allow") != "GET, HEAD, PUT" {
+		t.Fatalf("DELETE /items/1 with a HEAD tree = %d, Allow %q", w.Code, w.Header().Get("Allow"))
+	}
+	if w := serve(rt, http.MethodHead, "/nothing"); w.Code != http.StatusNotFound {
+		t.Fatalf("HEAD /nothing = %d", w.Code)
+	}
+	rt.HandleMethodNotAllowed = false
+	rt.NotFound = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	if w := serve(rt, http.MethodDelete, "/items/1"); w.Code != http.StatusTeapot {
+		t.Fatalf("DELETE /items/1 without 405 = %d", w.Code)
+	}
+}
+
+func TestRouter_TrailingSlash(t *testing.T) {
+	rt := New()
+	rt.Handle(http.MethodGet, "/docs/", echo("docs"))
+	rt.Handle(http.MethodGet, "/about", echo("about"))
+	rt.Handle(http.MethodPost, "/submit", echo("submit"))
+	rt.Handle(http.MethodGet, "/files/*path", echo("files"))
+	rt.Handle(http.MethodGet, "/:x", echo("x"))
+	cases := []struct {
+		method, target string
+		code           int
+		location       string
+	}{
+		{http.MethodGet, "/docs", http.StatusMovedPermanently, "/docs/"},
+		{http.MethodGet, "/about/?q=1", http.StatusMovedPermanently, "/about?q=1"},
+		{http.MethodPost, "/submit/", http.StatusPermanentRedirect, "/submit"},
+		{http.MethodGet, "/docs/", http.StatusOK, ""},
+		{http.MethodGet, "/files/a/", http.StatusOK, ""},
+		//重定向目标不可为"//host"形式的其他站点
+		{http.MethodGet, "//evil.com/", http.StatusMovedPermanently, "/evil.com"},
+		{http.MethodGet, "/%2Fevil.com/", http.StatusMovedPermanently, "/evil.com"},
+		{http.MethodGet, "//about/", http.StatusMovedPermanently, "/about"},
+	}
+	for _, c := range cases {
+		w := serve(rt, c.method, c.target)
+		if w.Code != c.code || w.Header().Get("Location") != c.location {
+			t.Fatalf("%s %s = %d %q, want %d %q", c.method, c.target, w.Code, w.Header().Get("Location"), c.code, c.location)
+		}
+	}
+	rt.RedirectTrailingSlash = false
+	if w := serve(rt, http.MethodGet, "/docs"); w.Code != http.StatusOK {
+		t.Fatalf("GET /docs without redirect = %d", w.Code)
+	}
+}
+
+func TestRouter_Constraints(t *testing.T) {
+	rt := New()
+	for _, r := range []struct{ pattern, name string }{
+		{"/items/:id<int>", "int"},
+		{"/items/:name<[a-z]+>", "slug"},
+		{"/items/:id.json", "json"},
+		{"/[:lang<en|fr>]/about", "about"},
+	} {
+		if err := rt.Handle(http.MethodGet, r.pattern, echo(r.name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cases := []struct {
+		target string
+		code   int
+		body   string
+	}{
+		{"/items/42", http.StatusOK, "int /items/:id<int> id=42"},
+		{"/items/abc", http.StatusOK, "slug /items/:name<[a-z]+> name=abc"},
+		{"/items/7.json", http.StatusOK, "json /items/:id.json id=7"},
+		{"/items/ABC", http.StatusNotFound, ""},
+		{"/about", http.StatusOK, "about /[:lang<en|fr>]/about"},
+		{"/fr/about", http.StatusOK, "about /[:lang<en|fr>]/about"},
+		{"/de/about", http.StatusNotFound, ""},
+	}
+	for _, c := range cases {
+		w := serve(rt, http.MethodGet, c.target)
+		if w.Code != c.code || c.code == http.StatusOK && w.Body.String() != c.body {
+			t.Fatalf("GET %s = %d %q, want %d %q", c.target, w.Code, w.Body.String(), c.code, c.body)
+		}
+	}
+	for _, p := range []string{"/items/:x<int>", "/about"} {
+		if err := rt.Handle(http.MethodGet, p, echo("dup")); !errors.Is(err, ErrConflict) {
+			t.Fatalf("Handle(%q) = %v, want ErrConflict", p, err)
+		}
+	}
+	for _, p := range []string{"/a/:x<[>", "/a/*p<int>", "/a/[:]"} {
+		if err := rt.Handle(http.MethodGet, p, echo("bad")); !errors.Is(err, ErrInvalid) {
+			t.Fatalf("Handle(%q) = %v, want ErrInvalid", p, err)
+		}
+	}
+}
+
+func TestRouter_StarInPath(t *testing.T) {
+	rt := New()
+	rt.Handle(http.MethodGet, "/x/:id", echo("x"))
+	rt.Handle(http.MethodGet, "/files/*path", echo("files"))
+	//请求路径中以'*'开头的段是普通内容,不能截断后续的段
+	cases := []struct {
+		target string
+		code   int
+		body   string
+	}{
+		{"/x/*", http.StatusOK, "x /x/:id id=*"},
+		{"/x/*/y/z", http.StatusNotFound, ""},
+		{"/files/*a/b/c", http.StatusOK, "files /files/*path path=*a/b/c"},
+	}
+	for _, c := range cases {
+		w := serve(rt, http.MethodGet, c.target)
+		if w.Code != c.code || c.code == http.StatusOK && w.Body.String() != c.body {
+			t.Fatalf("GET %s = %d %q, want %d %q", c.target, w.Code, w.Body.String(), c.code, c.body)
+		}
+	}
+}